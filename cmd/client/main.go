@@ -1,7 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/m1khal3v/gophkeeper/internal/client/app"
+	"github.com/m1khal3v/gophkeeper/internal/client/cli"
 	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
 )
 
@@ -12,12 +16,19 @@ var (
 )
 
 func main() {
-	buildlog.Print(buildVersion, buildDate, buildCommit)
-
-	app, err := app.New()
+	app, err := app.New(buildlog.Info{
+		Version: buildVersion,
+		Date:    buildDate,
+		Commit:  buildCommit,
+	})
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(cli.ExitCode(err))
+	}
+
+	if app.Interactive() {
+		buildlog.Print(buildVersion, buildDate, buildCommit)
 	}
 
-	app.Run()
+	os.Exit(app.Run())
 }
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
 	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
 	"github.com/m1khal3v/gophkeeper/internal/server/app"
 )
@@ -14,13 +19,49 @@ var (
 func main() {
 	buildlog.Print(buildVersion, buildDate, buildCommit)
 
-	app, err := app.New()
-	if err != nil {
-		panic(err)
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+func run(args []string) error {
+	err := start(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
 	}
 
-	err = app.Run()
+	return err
+}
+
+func start(args []string) error {
+	// server migrate <up|down|status> [flags]
+	if len(args) > 0 && args[0] == "migrate" {
+		var command string
+		if len(args) > 1 {
+			command, args = args[1], args[2:]
+		}
+
+		return app.Migrate(command, args)
+	}
+
+	app, err := app.New(buildlog.Info{
+		Version: buildVersion,
+		Date:    buildDate,
+		Commit:  buildCommit,
+	}, args)
 	if err != nil {
-		panic(err)
+		return err
 	}
+
+	return app.Run()
+}
+
+// exitCode is 2 for invalid configuration and usage, like flag parsing errors
+func exitCode(err error) int {
+	if errors.Is(err, app.ErrConfig) || errors.Is(err, app.ErrUnknownMigrateCommand) {
+		return 2
+	}
+
+	return 1
 }
@@ -0,0 +1,149 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/cli"
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	conn     *grpc.ClientConn
+	client   proto.AgentServiceClient
+	prompter command.PasswordPrompter
+}
+
+// NewClient connects to the agent, prompts of the agent commands are asked with prompter
+func NewClient(socket string, prompter command.PasswordPrompter) (*Client, error) {
+	conn, err := grpc.NewClient(
+		"unix://"+socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:     conn,
+		client:   proto.NewAgentServiceClient(conn),
+		prompter: prompter,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) Execute(ctx context.Context, args []string) (command.Result, error) {
+	var answers [][]byte
+	defer func() {
+		for _, answer := range answers {
+			clear(answer)
+		}
+	}()
+
+	prompter := prompterFrom(ctx, c.prompter)
+	resp, err := c.client.Execute(ctx, &proto.AgentExecuteRequest{Args: args})
+	// the agent asks one prompt at a time and executes the command again with all answers
+	for err == nil && resp.Prompt != "" {
+		if prompter == nil {
+			return nil, errors.New("the command needs an interactive terminal")
+		}
+
+		var answer []byte
+		if answer, err = prompter.Password(resp.Prompt); err != nil {
+			return nil, err
+		}
+		answers = append(answers, answer)
+		resp, err = c.client.Execute(ctx, &proto.AgentExecuteRequest{Args: args, Answers: answers})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can`t execute command in agent: %w", err)
+	}
+	if resp.ExitCode != cli.ExitOK {
+		return nil, &RemoteError{Message: resp.Error, Code: int(resp.ExitCode)}
+	}
+
+	return &Result{text: resp.Text, data: resp.Json}, nil
+}
+
+// ResolveSecret returns a field of an item, the client needs it for commands it runs itself
+func (c *Client) ResolveSecret(ctx context.Context, key, field string) (string, error) {
+	_, text, err := c.ResolveField(ctx, key, field)
+
+	return text, err
+}
+
+func (c *Client) ResolveField(ctx context.Context, key, field string) (string, string, error) {
+	resp, err := c.client.Secret(ctx, &proto.AgentSecretRequest{Key: key, Field: field})
+	if err != nil {
+		return "", "", fmt.Errorf("can`t get secret from agent: %w", err)
+	}
+	if resp.ExitCode != cli.ExitOK {
+		return "", "", &RemoteError{Message: resp.Error, Code: int(resp.ExitCode)}
+	}
+
+	return resp.Field, resp.Value, nil
+}
+
+func (c *Client) Unlock(ctx context.Context, password []byte) error {
+	_, err := c.client.Unlock(ctx, &proto.AgentUnlockRequest{MasterPassword: password})
+	if status.Code(err) == codes.Unauthenticated {
+		return fmt.Errorf("%w: %s", manager.ErrInvalidMasterPassword, status.Convert(err).Message())
+	}
+	if err != nil {
+		return fmt.Errorf("can`t unlock agent: %w", err)
+	}
+
+	return nil
+}
+
+type Result struct {
+	text string
+	data json.RawMessage
+}
+
+func (r *Result) Text() string {
+	return r.text
+}
+
+func (r *Result) MarshalJSON() ([]byte, error) {
+	return r.data, nil
+}
+
+// RemoteError keeps the exit code of a failed agent command,
+// Unwrap returns the matching sentinel so local error checks keep working
+type RemoteError struct {
+	Message string
+	Code    int
+}
+
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+func (e *RemoteError) Unwrap() error {
+	switch e.Code {
+	case cli.ExitUsage:
+		return command.ErrInvalidArgs
+	case cli.ExitNotFound:
+		return manager.ErrNotFound
+	case cli.ExitAuth:
+		return vault.ErrLocked
+	case cli.ExitSync:
+		return synchronizer.ErrSyncFailed
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/cli"
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteError(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+	}{
+		{code: cli.ExitUsage, want: command.ErrInvalidArgs},
+		{code: cli.ExitNotFound, want: manager.ErrNotFound},
+		{code: cli.ExitAuth, want: vault.ErrLocked},
+		{code: cli.ExitSync, want: synchronizer.ErrSyncFailed},
+	}
+
+	for _, tt := range tests {
+		err := &RemoteError{Message: "remote", Code: tt.code}
+		assert.ErrorIs(t, err, tt.want)
+		assert.Equal(t, tt.code, cli.ExitCode(err))
+	}
+
+	err := &RemoteError{Message: "remote", Code: cli.ExitFailure}
+	assert.Nil(t, err.Unwrap())
+	assert.Equal(t, cli.ExitFailure, cli.ExitCode(err))
+}
+
+func TestResult(t *testing.T) {
+	result := &Result{text: "value", data: json.RawMessage(`{"key":"a"}`)}
+	assert.Equal(t, "value", result.Text())
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"a"}`, string(data))
+}
@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/prompt"
+)
+
+type Executor interface {
+	Execute(ctx context.Context, args []string) (command.Result, error)
+}
+
+// Command forwards a command with a local spec to the agent,
+// file paths are made absolute first, the agent has its own working directory
+type Command struct {
+	executor Executor
+	spec     command.Spec
+	prepare  func(args []string) ([]string, error)
+}
+
+func NewCommand(executor Executor, spec command.Spec) *Command {
+	return NewArgsCommand(executor, spec, spec.AbsPaths)
+}
+
+// NewArgsCommand prepares args with a command specific function instead of the spec
+func NewArgsCommand(executor Executor, spec command.Spec, prepare func(args []string) ([]string, error)) *Command {
+	return &Command{
+		executor: executor,
+		spec:     spec,
+		prepare:  prepare,
+	}
+}
+
+func (c *Command) Spec() command.Spec {
+	return c.spec
+}
+
+func (c *Command) Execute(ctx context.Context, args []string) (command.Result, error) {
+	args, err := c.prepare(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, password, err := readPasswordFD(args)
+	if err != nil {
+		return nil, err
+	}
+	if password != nil {
+		defer clear(password)
+		ctx = withPrompter(ctx, password)
+	}
+
+	return c.executor.Execute(ctx, append([]string{c.spec.Name}, args...))
+}
+
+// readPasswordFD reads --password-fd in the client, a descriptor of the client means nothing to the agent,
+// the password answers the prompts of the command instead. Flags are already prepared as --name=value.
+func readPasswordFD(args []string) ([]string, fixedPrompter, error) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		fd, ok := strings.CutPrefix(arg, "--password-fd=")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(fd)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid --password-fd %q", command.ErrInvalidArgs, fd)
+		}
+		password, err := prompt.FromFD(n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can`t read password: %w", err)
+		}
+
+		return append(slices.Clone(args[:i]), args[i+1:]...), password, nil
+	}
+
+	return args, nil, nil
+}
+
+// StdinCommand reads the request from the local stdin and passes it as args,
+// the agent can`t read stdin of the client
+type StdinCommand struct {
+	*Command
+	stdin io.Reader
+	read  func(args []string, r io.Reader) ([]string, error)
+}
+
+func NewStdinCommand(executor Executor, spec command.Spec, read func(args []string, r io.Reader) ([]string, error)) *StdinCommand {
+	return &StdinCommand{
+		Command: NewCommand(executor, spec),
+		stdin:   os.Stdin,
+		read:    read,
+	}
+}
+
+func (c *StdinCommand) Execute(ctx context.Context, args []string) (command.Result, error) {
+	args, err := c.read(args, c.stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Command.Execute(ctx, args)
+}
+
+// CopyCommand copies a field in the client when get is called with --copy, the clipboard of the
+// agent is not the terminal of the user, so only the field is fetched from the agent
+type CopyCommand struct {
+	*Command
+	secrets   command.FieldResolver
+	clipboard command.Clipboard
+}
+
+func NewCopyCommand(client *Client, spec command.Spec, clipboard command.Clipboard) *CopyCommand {
+	return &CopyCommand{
+		Command:   NewCommand(client, spec),
+		secrets:   client,
+		clipboard: clipboard,
+	}
+}
+
+func (c *CopyCommand) Execute(ctx context.Context, args []string) (command.Result, error) {
+	flags, err := c.spec.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if !flags.Bool("copy") {
+		return c.Command.Execute(ctx, args)
+	}
+
+	field, text, err := c.secrets.ResolveField(ctx, flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		return nil, err
+	}
+
+	return command.CopyField(c.clipboard, flags.Arg(0), field, text)
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExecutor struct {
+	args   []string
+	answer []byte
+}
+
+func (m *mockExecutor) Execute(ctx context.Context, args []string) (command.Result, error) {
+	m.args = args
+	m.answer = nil
+	if prompter := prompterFrom(ctx, nil); prompter != nil {
+		m.answer, _ = prompter.Password("archive password: ")
+	}
+
+	return command.Message("ok"), nil
+}
+
+func TestStdinCommand_Execute(t *testing.T) {
+	executor := &mockExecutor{}
+	cmd := NewStdinCommand(executor, command.Spec{Name: "git-credential"}, command.CredentialArgs)
+	cmd.stdin = strings.NewReader("protocol=https\nhost=github.com\n\n")
+
+	_, err := cmd.Execute(context.Background(), []string{"get"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git-credential", "get", "protocol=https", "host=github.com"}, executor.args)
+
+	_, err = cmd.Execute(context.Background(), []string{"erase", "host=gitlab.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git-credential", "erase", "host=gitlab.com"}, executor.args)
+}
+
+func TestCommand_Execute_AbsPaths(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	executor := &mockExecutor{}
+	cmd := NewCommand(executor, command.NewRenderCommand(nil, nil).Spec())
+	_, err = cmd.Execute(context.Background(), []string{"app.tmpl", "-o", "/tmp/app.env"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"render", "--o=/tmp/app.env", "--", filepath.Join(wd, "app.tmpl")}, executor.args)
+
+	_, err = cmd.Execute(context.Background(), []string{"--unknown", "app.tmpl"})
+	assert.ErrorIs(t, err, command.ErrInvalidArgs)
+
+	cmd = NewArgsCommand(executor, command.NewSetCommand(nil, nil).Spec(), command.SetArgs)
+	_, err = cmd.Execute(context.Background(), []string{"photo", "binary", "photo.jpg"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"set", "photo", "binary", filepath.Join(wd, "photo.jpg")}, executor.args)
+}
+
+func TestCommand_Execute_PasswordFD(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = writer.WriteString("archive-pass\n")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	// the descriptor of the client is read locally and answers the prompts of the agent
+	executor := &mockExecutor{}
+	cmd := NewCommand(executor, command.NewExportCommand(nil, nil, nil).Spec())
+	_, err = cmd.Execute(context.Background(), []string{"--password-fd", strconv.Itoa(int(reader.Fd())), "backup.gkb"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"export", "--", filepath.Join(wd, "backup.gkb")}, executor.args)
+	assert.Equal(t, []byte("archive-pass"), executor.answer)
+
+	_, err = cmd.Execute(context.Background(), []string{"--password-fd", "x", "backup.gkb"})
+	assert.ErrorIs(t, err, command.ErrInvalidArgs)
+}
+
+type mockClipboard struct {
+	text string
+}
+
+func (m *mockClipboard) Copy(text string) error {
+	m.text = text
+	return nil
+}
+
+func (m *mockClipboard) ClearAfter() time.Duration {
+	return time.Minute
+}
+
+func TestCopyCommand_Execute(t *testing.T) {
+	clipboard := &mockClipboard{}
+	cmd := NewCopyCommand(newTestAgent(t, &mockVault{}, nil), command.NewGetCommand(nil, nil, nil).Spec(), clipboard)
+
+	result, err := cmd.Execute(context.Background(), []string{"github", "--copy"})
+	require.NoError(t, err)
+	assert.Equal(t, "secret-password", clipboard.text)
+	assert.Equal(t, &command.CopyResult{Key: "github", Field: "password", ClearAfter: "1m0s"}, result)
+
+	_, err = cmd.Execute(context.Background(), []string{"--copy", "github", "login"})
+	require.NoError(t, err)
+	assert.Equal(t, "secret-login", clipboard.text)
+
+	_, err = cmd.Execute(context.Background(), []string{"missing", "--copy"})
+	assert.ErrorIs(t, err, manager.ErrNotFound)
+
+	// without --copy the item is shown by the agent
+	result, err = cmd.Execute(context.Background(), []string{"github"})
+	require.NoError(t, err)
+	assert.Equal(t, "<github>", result.Text())
+}
@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"go.uber.org/zap"
+)
+
+// Listen creates the agent socket, only processes of the same user can connect to it
+func Listen(socket string) (net.Listener, error) {
+	if err := prepareSocketDir(filepath.Dir(socket)); err != nil {
+		return nil, err
+	}
+
+	if conn, err := net.Dial("unix", socket); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("agent is already running on %s", socket)
+	}
+	// socket left by an agent that was killed
+	if err := os.Remove(socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("can`t remove stale socket: %w", err)
+	}
+
+	listener, err := listenPrivate(socket)
+	if err != nil {
+		return nil, fmt.Errorf("can`t listen on socket: %w", err)
+	}
+
+	return &peerListener{Listener: listener, uid: os.Getuid()}, nil
+}
+
+// peerListener drops connections of other users, in case the socket permissions were changed
+type peerListener struct {
+	net.Listener
+	uid int
+}
+
+func (l *peerListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		uid, err := peerUID(conn)
+		if err == nil && uid == l.uid {
+			return conn, nil
+		}
+		if errors.Is(err, errors.ErrUnsupported) {
+			// the socket directory is the only protection on this platform
+			return conn, nil
+		}
+
+		logger.Logger.Warn("Agent connection refused", zap.Int("uid", uid), zap.Error(err))
+		conn.Close()
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !unix
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+func prepareSocketDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("can`t create socket directory: %w", err)
+	}
+
+	return nil
+}
+
+func listenPrivate(socket string) (net.Listener, error) {
+	return net.Listen("unix", socket)
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "agent", "agent.sock")
+
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Dir(socket))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	_, err = Listen(socket)
+	assert.ErrorContains(t, err, "agent is already running")
+
+	require.NoError(t, listener.Close())
+}
+
+func TestListen_StaleSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "agent", "agent.sock")
+	require.NoError(t, os.Mkdir(filepath.Dir(socket), 0700))
+	require.NoError(t, os.WriteFile(socket, nil, 0600))
+
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+}
+
+func TestListen_InsecureDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "agent")
+	require.NoError(t, os.Mkdir(dir, 0700))
+	// MkdirAll doesn't fix permissions of an existing directory
+	require.NoError(t, os.Chmod(dir, 0770))
+
+	_, err := Listen(filepath.Join(dir, "agent.sock"))
+	assert.ErrorContains(t, err, "must be a directory owned by uid")
+}
+
+func TestPeerListener_OtherUser(t *testing.T) {
+	listener, err := Listen(filepath.Join(t.TempDir(), "agent", "agent.sock"))
+	require.NoError(t, err)
+	// pretend the agent runs as another user
+	listener.(*peerListener).uid = os.Getuid() + 1
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+		close(accepted)
+	}()
+
+	conn, err := net.Dial("unix", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the connection is closed by the agent
+	_, err = conn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+
+	require.NoError(t, listener.Close())
+	_, ok := <-accepted
+	assert.False(t, ok)
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// prepareSocketDir refuses a directory another user could have created in a shared location like /tmp
+func prepareSocketDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("can`t create socket directory: %w", err)
+	}
+
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("can`t check socket directory: %w", err)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !info.IsDir() || !ok || int(stat.Uid) != os.Getuid() || info.Mode().Perm() != 0700 {
+		return fmt.Errorf("socket directory %s must be a directory owned by uid %d with mode 0700", dir, os.Getuid())
+	}
+
+	return nil
+}
+
+// listenPrivate creates the socket with 0600 permissions, a chmod after listen would leave a window
+func listenPrivate(socket string) (net.Listener, error) {
+	umask := syscall.Umask(0177)
+	defer syscall.Umask(umask)
+
+	return net.Listen("unix", socket)
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd
+
+package agent
+
+import (
+	"errors"
+	"net"
+)
+
+func peerUID(conn net.Conn) (int, error) {
+	return -1, errors.ErrUnsupported
+}
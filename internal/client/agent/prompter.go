@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+)
+
+var errAnswerRequired = errors.New("the command needs an answer from the client")
+
+// Prompter replays answers sent by the client, the agent has no terminal to ask them.
+// A prompt without an answer interrupts the command, the client asks it
+// and executes the command again with one more answer.
+type Prompter struct {
+	answers [][]byte
+	prompt  string
+}
+
+func NewPrompter() *Prompter {
+	return &Prompter{}
+}
+
+func (p *Prompter) Password(message string) ([]byte, error) {
+	if len(p.answers) == 0 {
+		p.prompt = message
+		return nil, errAnswerRequired
+	}
+
+	answer := bytes.Clone(p.answers[0])
+	p.answers = p.answers[1:]
+
+	return answer, nil
+}
+
+func (p *Prompter) reset(answers [][]byte) {
+	p.answers = answers
+	p.prompt = ""
+}
+
+type prompterKey struct{}
+
+// withPrompter overrides the prompter of the client for one command
+func withPrompter(ctx context.Context, prompter command.PasswordPrompter) context.Context {
+	return context.WithValue(ctx, prompterKey{}, prompter)
+}
+
+func prompterFrom(ctx context.Context, fallback command.PasswordPrompter) command.PasswordPrompter {
+	if prompter, ok := ctx.Value(prompterKey{}).(command.PasswordPrompter); ok {
+		return prompter
+	}
+
+	return fallback
+}
+
+// fixedPrompter answers every prompt with the same password
+type fixedPrompter []byte
+
+func (p fixedPrompter) Password(message string) ([]byte, error) {
+	return bytes.Clone(p), nil
+}
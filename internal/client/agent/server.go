@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/cli"
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CommandGetter interface {
+	Get(name string) (command.Command, error)
+}
+
+type Vault interface {
+	Unlock(ctx context.Context, password []byte) error
+	// Touch postpones auto-lock, every request counts as activity
+	Touch()
+}
+
+type Server struct {
+	proto.UnimplementedAgentServiceServer
+	registry CommandGetter
+	vault    Vault
+	prompter *Prompter
+	secrets  command.FieldResolver
+	// commands share the prompter, so they are executed one at a time
+	mu sync.Mutex
+}
+
+// NewServer serves commands from the registry, prompter must be the one passed to the commands
+func NewServer(registry CommandGetter, vault Vault, prompter *Prompter, secrets command.FieldResolver) *Server {
+	return &Server{
+		registry: registry,
+		vault:    vault,
+		prompter: prompter,
+		secrets:  secrets,
+	}
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := grpc.NewServer()
+	proto.RegisterAgentServiceServer(server, s)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.GracefulStop()
+		case <-done:
+		}
+	}()
+
+	return server.Serve(listener)
+}
+
+func (s *Server) Execute(ctx context.Context, request *proto.AgentExecuteRequest) (*proto.AgentExecuteResponse, error) {
+	if len(request.Args) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "command is required")
+	}
+
+	cmd, err := s.registry.Get(request.Args[0])
+	if err != nil {
+		return errorResponse(err), nil
+	}
+
+	if cmd.Spec().Terminal {
+		return errorResponse(fmt.Errorf("%s needs a terminal and can`t be executed by the agent", request.Args[0])), nil
+	}
+
+	defer func() {
+		for _, answer := range request.Answers {
+			clear(answer)
+		}
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vault.Touch()
+	s.prompter.reset(request.Answers)
+	result, err := cmd.Execute(ctx, request.Args[1:])
+	if errors.Is(err, errAnswerRequired) {
+		return &proto.AgentExecuteResponse{Prompt: s.prompter.prompt}, nil
+	}
+	if err != nil {
+		return errorResponse(err), nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		return nil, status.Errorf(codes.Internal, "can`t marshal result: %s", err)
+	}
+
+	return &proto.AgentExecuteResponse{
+		Text: result.Text(),
+		Json: buf.Bytes(),
+	}, nil
+}
+
+func (s *Server) Secret(ctx context.Context, request *proto.AgentSecretRequest) (*proto.AgentSecretResponse, error) {
+	s.vault.Touch()
+	field, secret, err := s.secrets.ResolveField(ctx, request.Key, request.Field)
+	if err != nil {
+		return &proto.AgentSecretResponse{Error: err.Error(), ExitCode: int32(cli.ExitCode(err))}, nil
+	}
+
+	return &proto.AgentSecretResponse{Value: secret, Field: field}, nil
+}
+
+func (s *Server) Unlock(ctx context.Context, request *proto.AgentUnlockRequest) (*proto.AgentUnlockResponse, error) {
+	defer clear(request.MasterPassword)
+
+	if err := s.vault.Unlock(ctx, request.MasterPassword); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return &proto.AgentUnlockResponse{}, nil
+}
+
+func errorResponse(err error) *proto.AgentExecuteResponse {
+	return &proto.AgentExecuteResponse{
+		Error:    err.Error(),
+		ExitCode: int32(cli.ExitCode(err)),
+	}
+}
@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/cli"
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCommand struct {
+	name     string
+	terminal bool
+	execute  func(ctx context.Context, args []string) (command.Result, error)
+}
+
+func (m *mockCommand) Spec() command.Spec {
+	return command.Spec{Name: m.name, Args: []command.Arg{{Name: "key"}}, Terminal: m.terminal}
+}
+
+func (m *mockCommand) Execute(ctx context.Context, args []string) (command.Result, error) {
+	return m.execute(ctx, args)
+}
+
+type mockVault struct {
+	password []byte
+	touched  atomic.Int32
+}
+
+func (m *mockVault) Touch() {
+	m.touched.Add(1)
+}
+
+func (m *mockVault) Unlock(ctx context.Context, password []byte) error {
+	if string(password) != "master" {
+		return manager.ErrInvalidMasterPassword
+	}
+	m.password = bytes.Clone(password)
+
+	return nil
+}
+
+type mockSecrets struct{}
+
+func (m *mockSecrets) ResolveField(ctx context.Context, key, field string) (string, string, error) {
+	if key != "github" {
+		return "", "", manager.ErrNotFound
+	}
+	if field == "" {
+		field = "password"
+	}
+
+	return field, "secret-" + field, nil
+}
+
+type mockPrompter struct {
+	messages []string
+}
+
+func (m *mockPrompter) Password(message string) ([]byte, error) {
+	m.messages = append(m.messages, message)
+	return []byte(fmt.Sprintf("answer-%d", len(m.messages))), nil
+}
+
+func newTestAgent(t *testing.T, vault Vault, clientPrompter command.PasswordPrompter) *Client {
+	prompter := NewPrompter()
+	registry := cli.NewCommandRegistry(&mockCommand{
+		name: "confirm",
+		execute: func(ctx context.Context, args []string) (command.Result, error) {
+			first, err := prompter.Password("first: ")
+			if err != nil {
+				return nil, err
+			}
+			second, err := prompter.Password("second: ")
+			if err != nil {
+				return nil, err
+			}
+
+			return command.Message(string(first) + " " + string(second)), nil
+		},
+	}, &mockCommand{
+		name:     "run",
+		terminal: true,
+		execute: func(ctx context.Context, args []string) (command.Result, error) {
+			return nil, errors.New("must not be executed")
+		},
+	}, &mockCommand{
+		name: "get",
+		execute: func(ctx context.Context, args []string) (command.Result, error) {
+			// forwarded commands separate positionals like the flag package does
+			if len(args) > 0 && args[0] == "--" {
+				args = args[1:]
+			}
+			switch {
+			case len(args) == 0:
+				return nil, fmt.Errorf("%w: <key>", command.ErrInvalidArgs)
+			case args[0] == "missing":
+				return nil, manager.ErrNotFound
+			case args[0] == "broken":
+				return nil, errors.New("decrypt error")
+			default:
+				return command.Message("<" + args[0] + ">"), nil
+			}
+		},
+	})
+
+	socket := filepath.Join(t.TempDir(), "agent", "agent.sock")
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() {
+		served <- NewServer(registry, vault, prompter, &mockSecrets{}).Serve(ctx, listener)
+	}()
+
+	client, err := NewClient(socket, clientPrompter)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+		cancel()
+		assert.NoError(t, <-served)
+	})
+
+	return client
+}
+
+func TestServer_Execute(t *testing.T) {
+	vault := &mockVault{}
+	client := newTestAgent(t, vault, nil)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStdout string
+		wantStderr string
+		wantCode   int
+	}{
+		{name: "success", args: []string{"get", "key"}, wantStdout: "<key>\n", wantCode: cli.ExitOK},
+		{name: "invalid args", args: []string{"get"}, wantStderr: "error: args: <key>\nusage: get <key>\n", wantCode: cli.ExitUsage},
+		{name: "not found", args: []string{"get", "missing"}, wantStderr: "error: data not found\n", wantCode: cli.ExitNotFound},
+		{name: "failure", args: []string{"get", "broken"}, wantStderr: "error: decrypt error\n", wantCode: cli.ExitFailure},
+	}
+
+	registry := cli.NewCommandRegistry(NewCommand(client, (&mockCommand{name: "get"}).Spec()))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := cli.Exec(context.Background(), registry, tt.args, cli.OutputTable, &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+
+	var stdout bytes.Buffer
+	code := cli.Exec(context.Background(), registry, []string{"get", "key"}, cli.OutputJSON, &stdout, &stdout)
+	assert.Equal(t, cli.ExitOK, code)
+	assert.JSONEq(t, `{"message": "<key>"}`, stdout.String())
+	// every executed command postpones auto-lock
+	assert.Equal(t, int32(len(tests)+1), vault.touched.Load())
+}
+
+func TestServer_Execute_Rejected(t *testing.T) {
+	client := newTestAgent(t, &mockVault{}, nil)
+
+	_, err := client.Execute(context.Background(), []string{"put"})
+	assert.ErrorIs(t, err, command.ErrInvalidArgs)
+	assert.EqualError(t, err, `unknown command: put, run "help" to list commands`)
+
+	_, err = client.Execute(context.Background(), []string{"run", "true"})
+	assert.EqualError(t, err, "run needs a terminal and can`t be executed by the agent")
+
+	_, err = client.Execute(context.Background(), nil)
+	assert.ErrorContains(t, err, "command is required")
+}
+
+func TestServer_Unlock(t *testing.T) {
+	vault := &mockVault{}
+	client := newTestAgent(t, vault, nil)
+
+	err := client.Unlock(context.Background(), []byte("wrong"))
+	assert.ErrorIs(t, err, manager.ErrInvalidMasterPassword)
+
+	require.NoError(t, client.Unlock(context.Background(), []byte("master")))
+	assert.Equal(t, []byte("master"), vault.password)
+}
+
+func TestServer_Execute_Prompt(t *testing.T) {
+	prompter := &mockPrompter{}
+	client := newTestAgent(t, &mockVault{}, prompter)
+
+	// prompts are asked by the client one by one
+	result, err := client.Execute(context.Background(), []string{"confirm"})
+	require.NoError(t, err)
+	assert.Equal(t, "answer-1 answer-2", result.Text())
+	assert.Equal(t, []string{"first: ", "second: "}, prompter.messages)
+
+	_, err = newTestAgent(t, &mockVault{}, nil).Execute(context.Background(), []string{"confirm"})
+	assert.EqualError(t, err, "the command needs an interactive terminal")
+}
+
+func TestServer_Secret(t *testing.T) {
+	vault := &mockVault{}
+	client := newTestAgent(t, vault, nil)
+
+	secret, err := client.ResolveSecret(context.Background(), "github", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-password", secret)
+	assert.Equal(t, int32(1), vault.touched.Load())
+
+	field, secret, err := client.ResolveField(context.Background(), "github", "")
+	require.NoError(t, err)
+	assert.Equal(t, "password", field)
+	assert.Equal(t, "secret-password", secret)
+
+	_, err = client.ResolveSecret(context.Background(), "missing", "")
+	assert.ErrorIs(t, err, manager.ErrNotFound)
+}
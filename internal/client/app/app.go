@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -12,33 +13,93 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/agent"
 	"github.com/m1khal3v/gophkeeper/internal/client/cli"
+	"github.com/m1khal3v/gophkeeper/internal/client/clipboard"
 	"github.com/m1khal3v/gophkeeper/internal/client/command"
 	"github.com/m1khal3v/gophkeeper/internal/client/grpc"
+	"github.com/m1khal3v/gophkeeper/internal/client/httpapi"
+	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
 	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	commonmetrics "github.com/m1khal3v/gophkeeper/internal/common/metrics"
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
 	_ "github.com/mattn/go-sqlite3"
 	"go.uber.org/zap"
+	"golang.org/x/term"
 
 	"github.com/m1khal3v/gophkeeper/internal/client/config"
 	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metrics"
+	"github.com/m1khal3v/gophkeeper/internal/client/migrations"
+	"github.com/m1khal3v/gophkeeper/internal/client/prompt"
 	"github.com/m1khal3v/gophkeeper/internal/client/repository"
 	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+)
+
+const (
+	historyLimit = 1000
+
+	tracingShutdownTimeout = 5 * time.Second
+	migrateTimeout         = time.Minute
+
+	httpShellFormat = "HTTP API: http://127.0.0.1:%d, token %s\n"
+	// same format as the agent socket, so the output can be evaluated by shell
+	httpAgentFormat = "GOPHKEEPER_HTTP_URL=http://127.0.0.1:%d; export GOPHKEEPER_HTTP_URL;\n" +
+		"GOPHKEEPER_HTTP_TOKEN=%s; export GOPHKEEPER_HTTP_TOKEN;\n"
 )
 
 type App struct {
-	syncer   *synchronizer.Synchronizer
-	registry cli.CommandRegistry
-	db       *sql.DB
+	syncer      *synchronizer.Synchronizer
+	registry    *cli.CommandRegistry
+	editor      *cli.Editor
+	clipboard   *clipboard.OSC52
+	agentServer *agent.Server
+	agentClient *agent.Client
+	socket      string
+	httpServer  *httpapi.Server
+	httpPort    int
+	httpToken   string
+	metrics     *metrics.Metrics
+	metricsAddr string
+	tracing     func(context.Context) error
+	completion  string
+	docker      bool
+	db          *sql.DB
+	command     []string
+	sync        bool
+	output      string
+	authorized  bool
 }
 
-func New() (*App, error) {
+func New(build buildlog.Info) (*App, error) {
 	conf, err := config.ParseArgs()
 	if err != nil {
-		return nil, fmt.Errorf("can`t parse arguments: %w", err)
+		return nil, fmt.Errorf("can`t parse arguments: %w: %w", command.ErrInvalidArgs, err)
+	}
+
+	if err := cli.ValidateOutput(conf.Output); err != nil {
+		return nil, fmt.Errorf("%w: %w", command.ErrInvalidArgs, err)
+	}
+
+	if conf.Completion != "" {
+		return &App{
+			registry:   newRegistry(nil, nil, nil, nil, nil, nil),
+			completion: conf.Completion,
+		}, nil
 	}
 
 	logger.Init("client", zap.InfoLevel.String())
 
+	if !conf.Agent && conf.AgentSocket != "" {
+		return newAgentClient(conf)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), "gophkeeper-client", build.Version, conf.TracesExporter)
+	if err != nil {
+		return nil, fmt.Errorf("can`t init tracing: %w", err)
+	}
+
 	dbPath := conf.DBPath
 	if err := touchFilepath(dbPath); err != nil {
 		return nil, fmt.Errorf("can`t touch filepath: %w", err)
@@ -49,18 +110,17 @@ func New() (*App, error) {
 		return nil, fmt.Errorf("can`t open db: %w", err)
 	}
 
-	userDataRepo, err := repository.NewUserDataRepository(db)
-	if err != nil {
-		return nil, fmt.Errorf("can`t create data repo: %w", err)
+	if err := migrateDB(db, dbPath); err != nil {
+		return nil, err
 	}
 
-	metaRepo, err := repository.NewMetaRepository(db)
-	if err != nil {
-		return nil, fmt.Errorf("can`t create meta repo: %w", err)
-	}
+	userDataRepo := repository.NewUserDataRepository(db)
+	metaRepo := repository.NewMetaRepository(db)
+	historyRepo := repository.NewHistoryRepository(db)
 
 	userDataManager := manager.NewUserDataManager(userDataRepo)
 	metaManager := manager.NewMetaManager(metaRepo)
+	historyManager := manager.NewHistoryManager(historyRepo, historyLimit)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -69,35 +129,202 @@ func New() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	masterPassword, err := readMasterPassword(context.Background(), conf, !ok)
+	if err != nil {
+		return nil, fmt.Errorf("can`t read master password: %w", err)
+	}
+
+	defer clear(masterPassword)
+
 	if !ok {
-		if err := metaManager.SetMasterPassword(ctx, conf.MasterPassword); err != nil {
+		if err := metaManager.SetMasterPassword(ctx, string(masterPassword)); err != nil {
 			return nil, err
 		}
-	} else {
-		if err := metaManager.ValidateMasterPassword(ctx, conf.MasterPassword); err != nil {
-			return nil, fmt.Errorf("invalid master password: %w", err)
-		}
 	}
 
-	client, err := grpc.NewClient(conf.ServerAddr)
+	// one-shot commands are short-lived, auto-lock is only useful in the interactive shell
+	idleLock := conf.IdleLock
+	if len(conf.Command) > 0 {
+		idleLock = 0
+	}
+	keys := vault.New(metaManager, idleLock)
+	if err := keys.Unlock(ctx, masterPassword); err != nil {
+		return nil, fmt.Errorf("can`t validate master password: %w", err)
+	}
+	keys.OnAutoLock(func() {
+		logger.Logger.Info("Vault locked after inactivity, run unlock to continue")
+	})
+
+	var tlsConfig *grpc.TLSConfig
+	if conf.TLSEnabled() {
+		tlsConfig = &grpc.TLSConfig{
+			CAFile:     conf.TLSCAFile,
+			ServerName: conf.TLSServerName,
+			CertFile:   conf.TLSCertFile,
+			KeyFile:    conf.TLSKeyFile,
+			Pins:       conf.TLSPins,
+		}
+	}
+	client, err := grpc.NewClient(conf.ServerAddr, tlsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("can`t create client: %w", err)
 	}
 
+	authToken, err := metaManager.GetAuthToken(ctx, masterPassword)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load auth token: %w", err)
+	}
+	client.SetAuthToken(authToken)
+
 	syncer := synchronizer.New(client, userDataManager, metaManager, time.Duration(conf.SyncIntervalSec)*time.Second)
+	var clientMetrics *metrics.Metrics
+	if conf.MetricsAddr != "" {
+		clientMetrics = metrics.New()
+		syncer.SetRecorder(clientMetrics)
+	}
+
+	// the agent has no terminal of its own, so fields are copied
+	// and prompts are asked by the client
+	var agentServer *agent.Server
+	var registry *cli.CommandRegistry
+	var osc52 *clipboard.OSC52
+	if conf.Agent {
+		prompter := agent.NewPrompter()
+		registry = newRegistry(userDataManager, metaManager, client, keys, nil, prompter)
+		agentServer = agent.NewServer(registry, keys, prompter, command.NewVaultSecrets(userDataManager, keys))
+	} else {
+		osc52 = clipboard.NewOSC52(conf.ClipboardTimeout)
+		registry = newRegistry(userDataManager, metaManager, client, keys, osc52, prompt.NewTTYPrompter())
+	}
+
+	var httpServer *httpapi.Server
+	var httpToken string
+	if conf.HTTPPort > 0 {
+		if httpToken, err = httpapi.NewToken(); err != nil {
+			return nil, err
+		}
+		httpServer = httpapi.NewServer(userDataManager, keys, httpToken, conf.HTTPOrigins)
+	}
+
+	var editor *cli.Editor
+	if len(conf.Command) == 0 && !conf.Agent && term.IsTerminal(int(os.Stdin.Fd())) {
+		history, err := cli.NewHistory(ctx, historyManager, registry, keys, historyLimit)
+		if err != nil {
+			logger.Logger.Warn("Can`t load history", zap.Error(err))
+		}
+		editor = cli.NewEditor(os.Stdin, os.Stdout, history, cli.NewCompleter(registry, userDataManager))
+		editor.OnActivity(keys.Touch)
+	}
+	registry.OnActivity(keys.Touch)
 
 	return &App{
-		syncer: syncer,
-		registry: cli.CommandRegistry{
-			"get":      command.NewGetCommand(userDataManager, []byte(conf.MasterPassword)),
-			"set":      command.NewSetCommand(userDataManager, []byte(conf.MasterPassword)),
-			"login":    command.NewLoginCommand(client, []byte(conf.MasterPassword)),
-			"register": command.NewRegisterCommand(client, []byte(conf.MasterPassword)),
-		},
-		db: db,
+		syncer:      syncer,
+		registry:    registry,
+		editor:      editor,
+		clipboard:   osc52,
+		agentServer: agentServer,
+		socket:      conf.AgentSocket,
+		httpServer:  httpServer,
+		httpPort:    conf.HTTPPort,
+		httpToken:   httpToken,
+		metrics:     clientMetrics,
+		metricsAddr: conf.MetricsAddr,
+		tracing:     shutdownTracing,
+		db:          db,
+		docker:      conf.DockerCredential,
+		command:     conf.Command,
+		sync:        conf.Sync,
+		output:      conf.Output,
+		authorized:  authToken != "",
 	}, nil
 }
 
+// newAgentClient forwards commands to a running agent, unlock and help are handled locally,
+// run starts the program and get --copy copies the field locally with secrets resolved by the agent
+func newAgentClient(conf *config.Config) (*App, error) {
+	client, err := agent.NewClient(conf.AgentSocket, prompt.NewTTYPrompter())
+	if err != nil {
+		return nil, fmt.Errorf("can`t create agent client: %w", err)
+	}
+
+	osc52 := clipboard.NewOSC52(conf.ClipboardTimeout)
+	registry := cli.NewCommandRegistry()
+	for _, spec := range newRegistry(nil, nil, nil, nil, nil, nil).Specs() {
+		switch spec.Name {
+		case "help":
+		case "unlock":
+			registry.Register(command.NewUnlockCommand(client, prompt.NewTTYPrompter()))
+		case "run":
+			registry.Register(command.NewRunCommand(client))
+		case "get":
+			registry.Register(agent.NewCopyCommand(client, spec, osc52))
+		case "set":
+			registry.Register(agent.NewArgsCommand(client, spec, command.SetArgs))
+		case "git-credential":
+			registry.Register(agent.NewStdinCommand(client, spec, command.CredentialArgs))
+		case "docker-credential":
+			registry.Register(agent.NewStdinCommand(client, spec, command.DockerCredentialArgs))
+		default:
+			registry.Register(agent.NewCommand(client, spec))
+		}
+	}
+	registry.Register(command.NewHelpCommand(registry))
+
+	return &App{
+		registry:    registry,
+		clipboard:   osc52,
+		agentClient: client,
+		docker:      conf.DockerCredential,
+		command:     conf.Command,
+		output:      conf.Output,
+	}, nil
+}
+
+func newRegistry(userDataManager *manager.UserDataManager, metaManager *manager.MetaManager, client *grpc.Client, keys *vault.Vault, clipboard command.Clipboard, prompter command.PasswordPrompter) *cli.CommandRegistry {
+	registry := cli.NewCommandRegistry(
+		command.NewGetCommand(userDataManager, keys, clipboard),
+		command.NewSetCommand(userDataManager, keys),
+		command.NewListCommand(userDataManager, keys),
+		command.NewTagCommand(userDataManager, keys),
+		command.NewMetaCommand(userDataManager, keys),
+		command.NewExportCommand(userDataManager, prompter, keys),
+		command.NewImportCommand(userDataManager, prompter, keys),
+		command.NewImportFromCommand(userDataManager, keys),
+		command.NewLoginCommand(client, metaManager, keys),
+		command.NewRegisterCommand(client, metaManager, keys),
+		command.NewRunCommand(command.NewVaultSecrets(userDataManager, keys)),
+		command.NewRenderCommand(userDataManager, keys),
+		command.NewCredentialCommand(userDataManager, keys),
+		command.NewDockerCredentialCommand(userDataManager, keys),
+		command.NewLockCommand(keys),
+		command.NewUnlockCommand(keys, prompter),
+	)
+	registry.Register(command.NewHelpCommand(registry))
+
+	return registry
+}
+
+func migrateDB(db *sql.DB, dbPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	result, err := migrations.Migrate(ctx, db, dbPath)
+	if err != nil {
+		return fmt.Errorf("can`t migrate db: %w", err)
+	}
+
+	if result.From != result.To {
+		logger.Logger.Info("Database migrated",
+			zap.Int("from", result.From),
+			zap.Int("to", result.To),
+			zap.String("backup", result.Backup),
+		)
+	}
+
+	return nil
+}
+
 func touchFilepath(path string) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0700); err != nil {
@@ -116,13 +343,56 @@ func touchFilepath(path string) error {
 	return nil
 }
 
-func (a *App) Run() {
+func (a *App) Interactive() bool {
+	return len(a.command) == 0 && a.completion == "" && a.agentServer == nil
+}
+
+func (a *App) Run() int {
+	if a.completion != "" {
+		if err := cli.CompletionScript(os.Stdout, a.completion, filepath.Base(os.Args[0]), config.Flags(), a.registry); err != nil {
+			cli.RenderError(os.Stderr, cli.OutputTable, err)
+
+			return cli.ExitCode(err)
+		}
+
+		return cli.ExitOK
+	}
+
+	if a.agentClient != nil {
+		return a.runAgentClient()
+	}
+
 	defer a.db.Close()
+	defer a.flushTraces()
+
+	if a.agentServer != nil {
+		return a.runAgent()
+	}
+
+	if len(a.command) > 0 {
+		return a.runOnce()
+	}
+
 	defer a.syncer.Stop()
+	defer a.clipboard.Clear()
 
 	var wg sync.WaitGroup
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
+	if err := a.startHTTP(ctx, &wg, os.Stderr, httpShellFormat); err != nil {
+		stop()
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+	if err := a.startMetrics(ctx, &wg); err != nil {
+		stop()
+		wg.Wait()
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
@@ -131,9 +401,205 @@ func (a *App) Run() {
 	}()
 	go func() {
 		defer wg.Done()
-		cli.Run(ctx, a.registry)
+		if a.editor != nil {
+			cli.RunEditor(ctx, a.registry, a.output, a.editor)
+		} else {
+			cli.Run(ctx, a.registry, a.output)
+		}
 		stop()
 	}()
 
 	wg.Wait()
+
+	return cli.ExitOK
+}
+
+func (a *App) runAgent() int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := agent.Listen(a.socket)
+	if err != nil {
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+	// same format as ssh-agent, so the output can be evaluated by shell
+	fmt.Printf("%s=%s; export %s;\n", config.AgentSocketEnv, a.socket, config.AgentSocketEnv)
+	logger.Logger.Info("Agent started", zap.String("socket", a.socket))
+
+	defer a.syncer.Stop()
+
+	var wg sync.WaitGroup
+	if err := a.startHTTP(ctx, &wg, os.Stdout, httpAgentFormat); err != nil {
+		listener.Close()
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+	if err := a.startMetrics(ctx, &wg); err != nil {
+		stop()
+		wg.Wait()
+		listener.Close()
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.syncer.Start(ctx)
+	}()
+
+	err = a.agentServer.Serve(ctx, listener)
+	stop()
+	wg.Wait()
+	if err != nil {
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitFailure
+	}
+
+	return cli.ExitOK
+}
+
+// startHTTP serves the HTTP API until ctx is done and prints its address and token to w
+func (a *App) startHTTP(ctx context.Context, wg *sync.WaitGroup, w io.Writer, format string) error {
+	if a.httpServer == nil {
+		return nil
+	}
+
+	listener, err := httpapi.Listen(a.httpPort)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, format, a.httpPort, a.httpToken)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := a.httpServer.Serve(ctx, listener); err != nil {
+			logger.Logger.Error("HTTP API stopped", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (a *App) flushTraces() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := a.tracing(ctx); err != nil {
+		logger.Logger.Warn("Can`t flush traces", zap.Error(err))
+	}
+}
+
+// startMetrics serves Prometheus metrics until ctx is done
+func (a *App) startMetrics(ctx context.Context, wg *sync.WaitGroup) error {
+	if a.metrics == nil {
+		return nil
+	}
+
+	listener, err := commonmetrics.Listen(a.metricsAddr)
+	if err != nil {
+		return err
+	}
+	logger.Logger.Info("Metrics started", zap.String("addr", listener.Addr().String()))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := commonmetrics.Serve(ctx, listener, a.metrics.Registry()); err != nil {
+			logger.Logger.Error("Metrics stopped", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (a *App) runAgentClient() int {
+	defer a.agentClient.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if len(a.command) > 0 {
+		defer a.waitClipboard(ctx)
+
+		return a.exec(ctx)
+	}
+	defer a.clipboard.Clear()
+	cli.Run(ctx, a.registry, a.output)
+
+	return cli.ExitOK
+}
+
+func (a *App) runOnce() int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	defer a.waitClipboard(ctx)
+
+	if a.sync {
+		if err := a.syncOnce(ctx); err != nil {
+			cli.RenderError(os.Stderr, a.output, err)
+
+			return cli.ExitCode(err)
+		}
+	}
+
+	code := a.exec(ctx)
+	if code != cli.ExitOK || !a.sync {
+		return code
+	}
+
+	// push changes made by the command
+	if err := a.syncOnce(ctx); err != nil {
+		cli.RenderError(os.Stderr, a.output, err)
+
+		return cli.ExitCode(err)
+	}
+
+	return cli.ExitOK
+}
+
+func (a *App) exec(ctx context.Context) int {
+	if !a.docker {
+		return cli.Exec(ctx, a.registry, a.command, a.output, os.Stdout, os.Stderr)
+	}
+
+	// docker reads errors of a credential helper from stdout and recognizes "not found" by the message
+	cmd, err := a.registry.Get(a.command[0])
+	if err == nil {
+		var result command.Result
+		if result, err = cmd.Execute(ctx, a.command[1:]); err == nil {
+			err = cli.Render(os.Stdout, cli.OutputTable, result)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stdout, err)
+
+		return cli.ExitCode(err)
+	}
+
+	return cli.ExitOK
+}
+
+// a one-shot process has to stay alive until the copied value is cleared
+func (a *App) waitClipboard(ctx context.Context) {
+	if !a.clipboard.Pending() {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "waiting to clear the clipboard, press Ctrl+C to clear it now")
+	a.clipboard.Wait(ctx)
+}
+
+func (a *App) syncOnce(ctx context.Context) error {
+	if !a.authorized {
+		return fmt.Errorf("%w: not logged in, run login first", synchronizer.ErrSyncFailed)
+	}
+
+	return a.syncer.SyncOnce(ctx)
 }
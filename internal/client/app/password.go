@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/config"
+	"github.com/m1khal3v/gophkeeper/internal/client/prompt"
+)
+
+func readMasterPassword(ctx context.Context, conf *config.Config, confirm bool) ([]byte, error) {
+	switch {
+	case conf.MasterPassword != "":
+		return []byte(conf.MasterPassword), nil
+	case conf.PasswordFD >= 0:
+		return prompt.FromFD(conf.PasswordFD)
+	case conf.PasswordCommand != "":
+		return prompt.FromCommand(ctx, conf.PasswordCommand)
+	}
+
+	if _, ok := os.LookupEnv(conf.PasswordEnv); ok && conf.PasswordEnv != "" {
+		return prompt.FromEnv(conf.PasswordEnv)
+	}
+
+	password, err := promptMasterPassword(prompt.NewTTYPrompter(), confirm)
+	if errors.Is(err, prompt.ErrNoTerminal) {
+		return nil, fmt.Errorf("%w: use -password-fd, -password-command or $%s", err, config.MasterPasswordEnv)
+	}
+
+	return password, err
+}
+
+// promptMasterPassword asks for the master password, and to repeat it when confirm is set.
+// Buffers that are not returned are cleared.
+func promptMasterPassword(prompter command.PasswordPrompter, confirm bool) ([]byte, error) {
+	password, err := prompter.Password("Master password: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, prompt.ErrEmptySecret
+	}
+	if !confirm {
+		return password, nil
+	}
+
+	repeated, err := prompter.Password("Repeat master password: ")
+	defer clear(repeated)
+	if err != nil {
+		clear(password)
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(password, repeated) != 1 {
+		clear(password)
+		return nil, errors.New("master passwords do not match")
+	}
+
+	return password, nil
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferPrompter struct {
+	answers [][]byte
+	errs    []error
+	calls   int
+}
+
+func (p *bufferPrompter) Password(message string) ([]byte, error) {
+	i := p.calls
+	p.calls++
+
+	return p.answers[i], p.errs[i]
+}
+
+func TestPromptMasterPassword(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		password, repeated := []byte("secret"), []byte("secret")
+		prompter := &bufferPrompter{answers: [][]byte{password, repeated}, errs: []error{nil, nil}}
+
+		result, err := promptMasterPassword(prompter, true)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), result)
+		assert.Equal(t, make([]byte, 6), repeated)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		password, repeated := []byte("secret"), []byte("secreT")
+		prompter := &bufferPrompter{answers: [][]byte{password, repeated}, errs: []error{nil, nil}}
+
+		_, err := promptMasterPassword(prompter, true)
+		assert.EqualError(t, err, "master passwords do not match")
+		assert.Equal(t, make([]byte, 6), password)
+		assert.Equal(t, make([]byte, 6), repeated)
+	})
+
+	t.Run("repeat error", func(t *testing.T) {
+		password := []byte("secret")
+		prompter := &bufferPrompter{answers: [][]byte{password, nil}, errs: []error{nil, errors.New("interrupted")}}
+
+		_, err := promptMasterPassword(prompter, true)
+		assert.EqualError(t, err, "interrupted")
+		assert.Equal(t, make([]byte, 6), password)
+	})
+
+	t.Run("without confirmation", func(t *testing.T) {
+		prompter := &bufferPrompter{answers: [][]byte{[]byte("secret")}, errs: []error{nil}}
+
+		result, err := promptMasterPassword(prompter, false)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), result)
+		assert.Equal(t, 1, prompter.calls)
+	})
+}
@@ -0,0 +1,155 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	Format  = "gophkeeper-archive"
+	Version = 1
+
+	kdfArgon2id = "argon2id"
+	saltSize    = 16
+	keySize     = 32
+)
+
+var (
+	ErrInvalidFormat      = errors.New("not a gophkeeper archive")
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrInvalidPassword    = errors.New("invalid archive password or corrupted archive")
+)
+
+type Item struct {
+	Key       string             `json:"key"`
+	Value     []byte             `json:"value"`
+	Meta      *metadata.Metadata `json:"meta,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type KDF struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+var DefaultKDF = KDF{
+	Name:    kdfArgon2id,
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+type envelope struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	KDF       KDF       `json:"kdf"`
+	Payload   []byte    `json:"payload"`
+}
+
+type payload struct {
+	Items []*Item `json:"items"`
+}
+
+func Write(w io.Writer, password []byte, items []*Item) error {
+	return WriteWithKDF(w, password, items, DefaultKDF)
+}
+
+func WriteWithKDF(w io.Writer, password []byte, items []*Item, kdf KDF) error {
+	if len(password) == 0 {
+		return errors.New("archive password is empty")
+	}
+
+	kdf.Salt = make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, kdf.Salt); err != nil {
+		return err
+	}
+
+	key, err := deriveKey(password, kdf)
+	if err != nil {
+		return err
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if err := json.NewEncoder(gz).Encode(payload{Items: items}); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	encrypted, err := aes.Encrypt(key, compressed.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(envelope{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		KDF:       kdf,
+		Payload:   encrypted,
+	})
+}
+
+func Read(r io.Reader, password []byte) ([]*Item, error) {
+	var env envelope
+	if err := json.NewDecoder(r).Decode(&env); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	if env.Format != Format {
+		return nil, ErrInvalidFormat
+	}
+	if env.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+
+	key, err := deriveKey(password, env.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := aes.Decrypt(key, env.Payload)
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var p payload
+	if err := json.NewDecoder(gz).Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid archive payload: %w", err)
+	}
+
+	return p.Items, nil
+}
+
+func deriveKey(password []byte, kdf KDF) ([]byte, error) {
+	switch kdf.Name {
+	case kdfArgon2id:
+		if len(kdf.Salt) == 0 || kdf.Time == 0 || kdf.Memory == 0 || kdf.Threads == 0 {
+			return nil, errors.New("invalid argon2id parameters")
+		}
+		return argon2.IDKey(password, kdf.Salt, kdf.Time, kdf.Memory, kdf.Threads, keySize), nil
+	default:
+		return nil, fmt.Errorf("unsupported kdf: %s", kdf.Name)
+	}
+}
@@ -0,0 +1,101 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKDF = KDF{
+	Name:    kdfArgon2id,
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+}
+
+func testItems() []*Item {
+	return []*Item{
+		{
+			Key:       "text",
+			Value:     []byte("value"),
+			Meta:      &metadata.Metadata{Tags: []string{"tag"}, Folder: "work"},
+			UpdatedAt: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Key:       "binary",
+			Value:     []byte{0, 1, 2, 3, 255},
+			UpdatedAt: time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteWithKDF(&buf, []byte("archive-pass"), testItems(), testKDF)
+	require.NoError(t, err)
+
+	items, err := Read(&buf, []byte("archive-pass"))
+	require.NoError(t, err)
+	assert.Equal(t, testItems(), items)
+}
+
+func TestWrite_DefaultKDF(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, []byte("archive-pass"), testItems())
+	require.NoError(t, err)
+
+	var env envelope
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &env))
+	assert.Equal(t, Format, env.Format)
+	assert.Equal(t, Version, env.Version)
+	assert.Equal(t, DefaultKDF.Name, env.KDF.Name)
+	assert.Equal(t, DefaultKDF.Memory, env.KDF.Memory)
+	assert.Len(t, env.KDF.Salt, saltSize)
+}
+
+func TestWrite_EmptyPassword(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteWithKDF(&buf, nil, testItems(), testKDF)
+	assert.Error(t, err)
+}
+
+func TestRead_WrongPassword(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWithKDF(&buf, []byte("archive-pass"), testItems(), testKDF))
+
+	items, err := Read(&buf, []byte("wrong-pass"))
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+	assert.Nil(t, items)
+}
+
+func TestRead_InvalidEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "not json", data: "garbage", wantErr: ErrInvalidFormat},
+		{name: "wrong format", data: `{"format":"other","version":1}`, wantErr: ErrInvalidFormat},
+		{name: "future version", data: `{"format":"gophkeeper-archive","version":99}`, wantErr: ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Read(bytes.NewBufferString(tt.data), []byte("pass"))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, items)
+		})
+	}
+}
+
+func TestRead_UnsupportedKDF(t *testing.T) {
+	data := `{"format":"gophkeeper-archive","version":1,"kdf":{"name":"md5"}}`
+	items, err := Read(bytes.NewBufferString(data), []byte("pass"))
+	assert.Error(t, err)
+	assert.Nil(t, items)
+}
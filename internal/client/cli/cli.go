@@ -1,59 +1,70 @@
 package cli
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strings"
 
-	"github.com/m1khal3v/gophkeeper/internal/client/command"
 	"github.com/m1khal3v/gophkeeper/internal/common/logger"
 	"go.uber.org/zap"
 )
 
-type CommandRegistry map[string]command.Command
+func Run(ctx context.Context, registry *CommandRegistry, output string) {
+	run(ctx, registry, output, os.Stdin, os.Stderr, func() { fmt.Print(prompt) })
+}
+
+func RunEditor(ctx context.Context, registry *CommandRegistry, output string, editor *Editor) {
+	run(ctx, registry, output, editor, os.Stderr, editor.startCommand)
+}
 
-func Run(ctx context.Context, registry CommandRegistry) {
-	reader := bufio.NewScanner(os.Stdin)
+// run renders errors like Exec, so the shell and one-shot mode share the error format
+func run(ctx context.Context, registry *CommandRegistry, output string, input io.Reader, stderr io.Writer, showPrompt func()) {
+	lexer := NewLexer(input)
 
 	logger.Logger.Info("GophKeeper started")
-	fmt.Print("> ")
 
-	for reader.Scan() {
-		line := strings.TrimSpace(reader.Text())
-		if len(line) == 0 {
-			fmt.Print("> ")
+	for {
+		showPrompt()
+		parts, err := lexer.Next()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			RenderError(stderr, output, err)
 
 			continue
 		}
+		if err != nil {
+			logger.Logger.Fatal("STDIN read error", zap.Error(err))
+		}
+
+		if len(parts) == 0 {
+			continue
+		}
 
-		parts := strings.Fields(line)
 		cmdName := parts[0]
 		args := parts[1:]
 
-		cmd, ok := registry[cmdName]
-		if !ok {
-			logger.Logger.Error("Unknown command", zap.String("command", cmdName))
-			fmt.Print("> ")
+		cmd, err := registry.Get(cmdName)
+		if err != nil {
+			RenderError(stderr, output, err)
 
 			continue
 		}
 
+		registry.activity()
 		result, err := cmd.Execute(ctx, args)
 		if err != nil {
-			logger.Logger.Error("Command execution error", zap.Error(err))
-			fmt.Print("> ")
+			RenderError(stderr, output, withUsage(cmd, err))
 
 			continue
 		}
 
-		fmt.Println(result)
-		fmt.Print("> ")
-	}
-
-	err := reader.Err()
-	if err != nil {
-		logger.Logger.Fatal("STDIN read error", zap.Error(err))
+		if err := Render(os.Stdout, output, result); err != nil {
+			RenderError(stderr, output, err)
+		}
 	}
 }
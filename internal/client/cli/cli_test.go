@@ -4,120 +4,128 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
 
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
-	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
 	"github.com/m1khal3v/gophkeeper/internal/common/logger"
 )
 
 type mockCommand struct {
-	execute func(ctx context.Context, args []string) (string, error)
+	name    string
+	args    []command.Arg
+	execute func(ctx context.Context, args []string) (command.Result, error)
 }
 
-func (m *mockCommand) Execute(ctx context.Context, args []string) (string, error) {
+func (m *mockCommand) Spec() command.Spec {
+	return command.Spec{Name: m.name, Args: m.args}
+}
+
+func (m *mockCommand) Execute(ctx context.Context, args []string) (command.Result, error) {
 	return m.execute(ctx, args)
 }
 
 func TestRun(t *testing.T) {
 	tests := []struct {
-		name              string
-		input             string
-		registry          CommandRegistry
-		expectedOutput    string
-		expectedLogChecks func(t *testing.T, logs []observer.LoggedEntry)
+		name           string
+		input          string
+		registry       *CommandRegistry
+		expectedOutput string
+		expectedStderr string
 	}{
 		{
 			name:  "valid command with args",
 			input: "cmd1 arg1 arg2\n",
-			registry: CommandRegistry{
-				"cmd1": &mockCommand{
-					execute: func(ctx context.Context, args []string) (string, error) {
+			registry: NewCommandRegistry(
+				&mockCommand{
+					name: "cmd1",
+					execute: func(ctx context.Context, args []string) (command.Result, error) {
 						if len(args) != 2 || args[0] != "arg1" || args[1] != "arg2" {
 							t.Errorf("unexpected args: %v", args)
 						}
-						return "success", nil
+						return command.Message("success"), nil
 					},
 				},
-			},
+			),
 			expectedOutput: "success\n> ",
-			expectedLogChecks: func(t *testing.T, logs []observer.LoggedEntry) {
-			},
 		},
 		{
 			name:  "command with no args",
 			input: "cmd2\n",
-			registry: CommandRegistry{
-				"cmd2": &mockCommand{
-					execute: func(ctx context.Context, args []string) (string, error) {
+			registry: NewCommandRegistry(
+				&mockCommand{
+					name: "cmd2",
+					execute: func(ctx context.Context, args []string) (command.Result, error) {
 						if len(args) != 0 {
 							t.Errorf("unexpected args: %v", args)
 						}
-						return "executed", nil
+						return command.Message("executed"), nil
 					},
 				},
-			},
+			),
 			expectedOutput: "executed\n> ",
-			expectedLogChecks: func(t *testing.T, logs []observer.LoggedEntry) {
-			},
 		},
 		{
 			name:           "unknown command",
 			input:          "unknowncmd\n",
-			registry:       CommandRegistry{},
+			registry:       NewCommandRegistry(),
 			expectedOutput: "> ",
-			expectedLogChecks: func(t *testing.T, logs []observer.LoggedEntry) {
-				errorFound := false
-				for _, entry := range logs {
-					if entry.Message == "Unknown command" && entry.Level == zapcore.ErrorLevel {
-						errorFound = true
-					}
-				}
-				if !errorFound {
-					t.Errorf("expected error log 'Unknown command', not found")
-				}
-			},
+			expectedStderr: "error: unknown command: unknowncmd, run \"help\" to list commands\n",
 		},
 		{
 			name:  "command execution error",
 			input: "cmd1 error\n",
-			registry: CommandRegistry{
-				"cmd1": &mockCommand{
-					execute: func(ctx context.Context, args []string) (string, error) {
-						return "", errors.New("execution failed")
+			registry: NewCommandRegistry(
+				&mockCommand{
+					name: "cmd1",
+					execute: func(ctx context.Context, args []string) (command.Result, error) {
+						return nil, errors.New("execution failed")
+					},
+				},
+			),
+			expectedOutput: "> ",
+			expectedStderr: "error: execution failed\n",
+		},
+		{
+			name:  "quoted args and heredoc",
+			input: "cmd1 'my key' \"a b\" <<EOF\nline 1\nline 2\nEOF\n",
+			registry: NewCommandRegistry(
+				&mockCommand{
+					name: "cmd1",
+					execute: func(ctx context.Context, args []string) (command.Result, error) {
+						if len(args) != 3 || args[0] != "my key" || args[1] != "a b" || args[2] != "line 1\nline 2" {
+							t.Errorf("unexpected args: %q", args)
+						}
+						return command.Message("success"), nil
 					},
 				},
-			},
+			),
+			expectedOutput: "success\n> ",
+		},
+		{
+			name:           "unbalanced quote",
+			input:          "cmd1 \"arg\n",
+			registry:       NewCommandRegistry(),
 			expectedOutput: "> ",
-			expectedLogChecks: func(t *testing.T, logs []observer.LoggedEntry) {
-				errorFound := false
-				for _, entry := range logs {
-					if entry.Message == "Command execution error" && entry.Level == zapcore.ErrorLevel {
-						errorFound = true
-					}
-				}
-				if !errorFound {
-					t.Errorf("expected error log 'Command execution error', not found")
-				}
-			},
+			expectedStderr: "error: syntax error at line 1, column 6: unbalanced double quote\n",
 		},
 		{
 			name:  "empty line",
 			input: "\n",
-			registry: CommandRegistry{
-				"cmd1": &mockCommand{
-					execute: func(ctx context.Context, args []string) (string, error) {
-						return "ignored", nil
+			registry: NewCommandRegistry(
+				&mockCommand{
+					name: "cmd1",
+					execute: func(ctx context.Context, args []string) (command.Result, error) {
+						return command.Message("ignored"), nil
 					},
 				},
-			},
+			),
 			expectedOutput: "> ",
-			expectedLogChecks: func(t *testing.T, logs []observer.LoggedEntry) {
-			},
 		},
 	}
 
@@ -139,14 +147,18 @@ func TestRun(t *testing.T) {
 				wIn.Close()
 			}()
 
-			core, obs := observer.New(zapcore.DebugLevel)
-			logger.Logger = zap.New(core)
-			defer func() { _ = logger.Logger.Sync() }()
+			rErr, wErr, _ := os.Pipe()
+			stderr := os.Stderr
+			defer func() { os.Stderr = stderr }()
+			os.Stderr = wErr
+
+			logger.Logger = zap.NewNop()
 
 			ctx := context.Background()
-			Run(ctx, tt.registry)
+			Run(ctx, tt.registry, OutputTable)
 
 			wOut.Close()
+			wErr.Close()
 
 			var writer bytes.Buffer
 			_, _ = writer.ReadFrom(rOut)
@@ -156,7 +168,62 @@ func TestRun(t *testing.T) {
 				t.Errorf("unexpected output, got: %q, want suffix: %q", actualOutput, tt.expectedOutput)
 			}
 
-			tt.expectedLogChecks(t, obs.All())
+			var errWriter bytes.Buffer
+			_, _ = errWriter.ReadFrom(rErr)
+			if errWriter.String() != tt.expectedStderr {
+				t.Errorf("unexpected stderr, got: %q, want: %q", errWriter.String(), tt.expectedStderr)
+			}
 		})
 	}
 }
+
+func TestRun_StructuredErrors(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	registry := NewCommandRegistry(&mockCommand{
+		name: "get",
+		args: []command.Arg{{Name: "key"}},
+		execute: func(ctx context.Context, args []string) (command.Result, error) {
+			return nil, fmt.Errorf("%w: <key> is required", command.ErrInvalidArgs)
+		},
+	})
+
+	// errors of the shell have the same format as in one-shot mode
+	var stderr bytes.Buffer
+	run(context.Background(), registry, OutputJSON, strings.NewReader("unknown\nget\n"), &stderr, func() {})
+
+	want := "{\n  \"error\": \"unknown command: unknown, run \\\"help\\\" to list commands\"\n}\n" +
+		"{\n  \"error\": \"args: <key> is required\",\n  \"usage\": \"get <key>\"\n}\n"
+	if stderr.String() != want {
+		t.Errorf("unexpected stderr, got: %q, want: %q", stderr.String(), want)
+	}
+}
+
+func TestRun_Activity(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	registry := NewCommandRegistry(&mockCommand{
+		name: "cmd1",
+		execute: func(ctx context.Context, args []string) (command.Result, error) {
+			return command.Message("ok"), nil
+		},
+	})
+	activity := 0
+	registry.OnActivity(func() { activity++ })
+
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stdout = devNull
+
+	// only executed commands count, unknown commands and empty lines don't
+	run(context.Background(), registry, OutputTable, strings.NewReader("cmd1\nunknown\n\ncmd1 arg\n"), io.Discard, func() {})
+
+	if activity != 2 {
+		t.Errorf("expected 2 activity calls, got %d", activity)
+	}
+}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+type KeyLister interface {
+	List(ctx context.Context) ([]*model.UserData, error)
+}
+
+type Completer struct {
+	registry *CommandRegistry
+	keys     KeyLister
+}
+
+func NewCompleter(registry *CommandRegistry, keys KeyLister) *Completer {
+	return &Completer{registry: registry, keys: keys}
+}
+
+// Complete returns the position where the word under the cursor starts
+// and the candidates that word can be replaced with.
+func (c *Completer) Complete(ctx context.Context, prefix string) (int, []string) {
+	start, quote := lastWordStart(prefix)
+
+	args, err := Split(prefix[:start])
+	if err != nil {
+		return start, nil
+	}
+	// the word being completed may have an unterminated quote
+	rawWord := prefix[start:]
+	if quote != 0 {
+		rawWord += string(quote)
+	}
+	word, err := Split(rawWord)
+	if err != nil || len(word) > 1 {
+		return start, nil
+	}
+	partial := ""
+	if len(word) == 1 {
+		partial = word[0]
+	}
+
+	var candidates []string
+	if len(args) == 0 {
+		candidates = c.registry.Names()
+	} else if spec, ok := c.registry.Spec(args[0]); ok {
+		candidates = c.argCandidates(ctx, spec, args[1:], partial)
+	}
+
+	var matched []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, partial) {
+			matched = append(matched, candidate)
+		}
+	}
+
+	return start, matched
+}
+
+func (c *Completer) argCandidates(ctx context.Context, spec command.Spec, args []string, partial string) []string {
+	position, pending := positional(spec, args)
+	if pending != nil {
+		return pending.Choices
+	}
+
+	if strings.HasPrefix(partial, "-") {
+		flags := make([]string, 0, len(spec.Flags))
+		for _, flag := range spec.Flags {
+			flags = append(flags, flag.Option())
+		}
+
+		return flags
+	}
+
+	arg, ok := spec.Arg(position)
+	if !ok {
+		return nil
+	}
+
+	switch arg.Kind {
+	case command.ArgKey:
+		return c.listKeys(ctx)
+	case command.ArgType:
+		return value.TypeNames()
+	case command.ArgChoice:
+		return arg.Choices
+	case command.ArgCommand:
+		return c.registry.Names()
+	default:
+		return nil
+	}
+}
+
+// positional returns the index of the next positional argument
+// and the flag waiting for its value, if any
+func positional(spec command.Spec, args []string) (int, *command.Flag) {
+	position := 0
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") || args[i] == "-" {
+			position++
+
+			continue
+		}
+		if strings.Contains(args[i], "=") {
+			continue
+		}
+		flag, ok := spec.Flag(args[i])
+		if !ok || !flag.HasValue() {
+			continue
+		}
+		if i == len(args)-1 {
+			return position, &flag
+		}
+		i++
+	}
+
+	return position, nil
+}
+
+func (c *Completer) listKeys(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	items, err := c.keys.List(ctx)
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.DataKey)
+	}
+
+	return keys
+}
+
+// AutoComplete implements term.Terminal.AutoCompleteCallback semantics for the tab key.
+// When several candidates remain, they are passed to show.
+func (c *Completer) AutoComplete(line string, pos int, show func([]string)) (string, int) {
+	start, candidates := c.Complete(context.Background(), line[:pos])
+	if len(candidates) == 0 {
+		return line, pos
+	}
+
+	replacement := escapeWord(candidates[0]) + " "
+	if len(candidates) > 1 {
+		common := commonPrefix(candidates)
+		replacement = escapeWord(common)
+		if len(replacement) <= pos-start {
+			show(candidates)
+
+			return line, pos
+		}
+	}
+
+	newLine := line[:start] + replacement + line[pos:]
+
+	return newLine, start + len(replacement)
+}
+
+func lastWordStart(prefix string) (int, byte) {
+	start := 0
+	var quote byte
+	for i := 0; i < len(prefix); i++ {
+		switch ch := prefix[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else if ch == '\\' && quote == '"' {
+				i++
+			}
+		case ch == '\\':
+			i++
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == ' ' || ch == '\t':
+			start = i + 1
+		}
+	}
+
+	return start, quote
+}
+
+func escapeWord(word string) string {
+	var builder strings.Builder
+	for _, r := range word {
+		if strings.ContainsRune(" \t'\"\\", r) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+
+	return prefix
+}
@@ -0,0 +1,454 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/config"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+)
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// scriptAction is what a shell should offer for a word: fixed words, files or nothing
+type scriptAction struct {
+	words []string
+	files bool
+}
+
+func (a scriptAction) empty() bool {
+	return len(a.words) == 0 && !a.files
+}
+
+type scriptCommand struct {
+	spec       command.Spec
+	positions  []scriptAction
+	variadic   bool
+	flags      []string
+	valueFlags []string
+}
+
+type completionScript struct {
+	program     string
+	function    string
+	globalFlags []config.Flag
+	commands    []*scriptCommand
+}
+
+func CompletionScript(w io.Writer, shell, program string, globalFlags []config.Flag, registry *CommandRegistry) error {
+	script := &completionScript{
+		program:     program,
+		function:    "_" + nonIdentifier.ReplaceAllString(program, "_"),
+		globalFlags: globalFlags,
+	}
+	for _, spec := range registry.Specs() {
+		script.commands = append(script.commands, newScriptCommand(spec, registry))
+	}
+
+	var builder strings.Builder
+	switch shell {
+	case ShellBash:
+		script.bash(&builder)
+	case ShellZsh:
+		script.zsh(&builder)
+	case ShellFish:
+		script.fish(&builder)
+	default:
+		return fmt.Errorf("%w: unsupported shell %q, use bash, zsh or fish", command.ErrInvalidArgs, shell)
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+func newScriptCommand(spec command.Spec, registry *CommandRegistry) *scriptCommand {
+	cmd := &scriptCommand{spec: spec}
+	for _, arg := range spec.Args {
+		cmd.positions = append(cmd.positions, argAction(arg, registry))
+		cmd.variadic = arg.Variadic
+	}
+	for _, flag := range spec.Flags {
+		cmd.flags = append(cmd.flags, flag.Option())
+		if flag.HasValue() {
+			cmd.valueFlags = append(cmd.valueFlags, flag.Option())
+		}
+	}
+
+	return cmd
+}
+
+// item keys are not offered: listing them requires the master password
+func argAction(arg command.Arg, registry *CommandRegistry) scriptAction {
+	switch arg.Kind {
+	case command.ArgType:
+		return scriptAction{words: value.TypeNames()}
+	case command.ArgChoice:
+		return scriptAction{words: arg.Choices}
+	case command.ArgCommand:
+		return scriptAction{words: registry.Names()}
+	case command.ArgFile:
+		return scriptAction{files: true}
+	default:
+		return scriptAction{}
+	}
+}
+
+func (s *completionScript) commandNames() []string {
+	names := make([]string, 0, len(s.commands))
+	for _, cmd := range s.commands {
+		names = append(names, cmd.spec.Name)
+	}
+
+	return names
+}
+
+func (s *completionScript) globalFlagNames(withValue bool) []string {
+	var names []string
+	for _, flag := range s.globalFlags {
+		if !withValue || flag.HasValue {
+			names = append(names, "-"+flag.Name)
+		}
+	}
+
+	return names
+}
+
+// bash and zsh share the same word scanning, only the way to offer candidates differs
+func (s *completionScript) scanWords(b *strings.Builder, words, current string, offset int) {
+	fmt.Fprintf(b, `    local i word db="" cmd="" cmdpos=0 n=0 valueflags=""
+    for ((i = %d; i < %s; i++)); do
+        word="${%s[i]}"
+        case "$word" in
+            %s) ((i++)) ;;
+            -*) ;;
+            *)
+                if [[ -z "$db" ]]; then
+                    db="$word"
+                else
+                    cmd="$word"
+                    cmdpos=$i
+                    break
+                fi
+                ;;
+        esac
+    done
+`, offset, current, words, strings.Join(s.globalFlagNames(true), "|"))
+}
+
+func (s *completionScript) scanArgs(b *strings.Builder, words, current string) {
+	b.WriteString("    case \"$cmd\" in\n")
+	for _, cmd := range s.commands {
+		if len(cmd.valueFlags) > 0 {
+			fmt.Fprintf(b, "        %s) valueflags=\"%s\" ;;\n", cmd.spec.Name, strings.Join(cmd.valueFlags, " "))
+		}
+	}
+	fmt.Fprintf(b, `    esac
+    for ((i = cmdpos + 1; i < %s; i++)); do
+        word="${%s[i]}"
+        case "$word" in
+            --*=*) ;;
+            -*) [[ " $valueflags " == *" $word "* ]] && ((i++)) ;;
+            *) ((n++)) ;;
+        esac
+    done
+`, current, words)
+}
+
+func (s *completionScript) bash(b *strings.Builder) {
+	reply := func(action scriptAction) string {
+		if action.files {
+			return `COMPREPLY=($(compgen -f -- "$cur"))`
+		}
+		return fmt.Sprintf(`COMPREPLY=($(compgen -W "%s" -- "$cur"))`, strings.Join(action.words, " "))
+	}
+
+	fmt.Fprintf(b, "# bash completion for %s, generated by %q\n", s.program, s.program+" -completion bash")
+	fmt.Fprintf(b, "%s() {\n", s.function)
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n    COMPREPLY=()\n")
+	s.scanWords(b, "COMP_WORDS", "COMP_CWORD", 1)
+
+	b.WriteString("\n    if [[ -z \"$db\" ]]; then\n        case \"$prev\" in\n")
+	s.valueCases(b, "            ", s.globalValueFlags(), reply)
+	fmt.Fprintf(b, `        esac
+        if [[ "$cur" == -* ]]; then
+            %s
+        else
+            %s
+        fi
+        return
+    fi
+
+    if [[ -z "$cmd" ]]; then
+        %s
+        return
+    fi
+
+`, reply(scriptAction{words: s.globalFlagNames(false)}), reply(scriptAction{files: true}), reply(scriptAction{words: s.commandNames()}))
+	s.scanArgs(b, "COMP_WORDS", "COMP_CWORD")
+
+	b.WriteString("\n    case \"$cmd\" in\n")
+	for _, cmd := range s.commands {
+		s.commandCases(b, cmd, "%s)", reply)
+	}
+	b.WriteString("    esac\n}\n\n")
+	fmt.Fprintf(b, "complete -o filenames -F %s %s\n", s.function, s.program)
+}
+
+func (s *completionScript) zsh(b *strings.Builder) {
+	reply := func(action scriptAction) string {
+		if action.files {
+			return "_files"
+		}
+		return "compadd -- " + strings.Join(action.words, " ")
+	}
+
+	fmt.Fprintf(b, "#compdef %s\n# zsh completion for %s, generated by %q\n\n", s.program, s.program, s.program+" -completion zsh")
+	fmt.Fprintf(b, "%s() {\n", s.function)
+	b.WriteString("    local cur=\"${words[CURRENT]}\" prev=\"${words[CURRENT-1]}\"\n")
+	s.scanWords(b, "words", "CURRENT", 2)
+
+	b.WriteString("\n    if [[ -z \"$db\" ]]; then\n        case \"$prev\" in\n")
+	s.valueCases(b, "            ", s.globalValueFlags(), reply)
+	b.WriteString("        esac\n        if [[ \"$cur\" == -* ]]; then\n            local -a flags=(\n")
+	for _, flag := range s.globalFlags {
+		fmt.Fprintf(b, "                %s\n", shellQuote("-"+flag.Name+":"+flag.Usage))
+	}
+	b.WriteString("            )\n            _describe 'option' flags\n        else\n            _files\n        fi\n        return\n    fi\n\n")
+
+	b.WriteString("    if [[ -z \"$cmd\" ]]; then\n        local -a commands=(\n")
+	for _, cmd := range s.commands {
+		fmt.Fprintf(b, "            %s\n", shellQuote(cmd.spec.Name+":"+cmd.spec.Synopsis))
+	}
+	b.WriteString("        )\n        _describe 'command' commands\n        return\n    fi\n\n")
+	s.scanArgs(b, "words", "CURRENT")
+
+	b.WriteString("\n    case \"$cmd\" in\n")
+	for _, cmd := range s.commands {
+		s.commandCases(b, cmd, "(%s)", reply)
+	}
+	b.WriteString("    esac\n}\n\n")
+	fmt.Fprintf(b, "if [[ \"$funcstack[1]\" == %s ]]; then\n    %s \"$@\"\nelse\n    compdef %s %s\nfi\n", s.function, s.function, s.function, s.program)
+}
+
+func (s *completionScript) fish(b *strings.Builder) {
+	state := s.function + "_state"
+	is := s.function + "_is"
+	arg := s.function + "_arg"
+	inCommand := s.function + "_command"
+
+	fmt.Fprintf(b, "# fish completion for %s, generated by %q\n\n", s.program, s.program+" -completion fish")
+
+	fmt.Fprintf(b, `function %s
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l db
+    set -l cmd
+    set -l n 0
+    set -l skip 0
+    for token in $tokens
+        if test $skip -eq 1
+            set skip 0
+            continue
+        end
+        if test -z "$cmd"
+            switch $token
+                case %s
+                    set skip 1
+                case '-*'
+                case '*'
+                    if test -z "$db"
+                        set db $token
+                    else
+                        set cmd $token
+                    end
+            end
+            continue
+        end
+        switch $token
+            case '--*=*'
+            case '-*'
+                switch "$cmd $token"
+`, state, fishPatterns(s.globalFlagNames(true)))
+	for _, cmd := range s.commands {
+		for _, flag := range cmd.valueFlags {
+			fmt.Fprintf(b, "                    case %s\n                        set skip 1\n", shellQuote(cmd.spec.Name+" "+flag))
+		}
+	}
+	fmt.Fprintf(b, `                end
+            case '*'
+                set n (math $n + 1)
+        end
+    end
+    if test $skip -eq 1; and test -z "$cmd"
+        echo value
+    else if test $skip -eq 1
+        echo $cmd value
+    else if test -z "$db"
+        echo db
+    else if test -z "$cmd"
+        echo command
+    else
+        echo $cmd $n
+    end
+end
+
+function %s
+    test (%s) = "$argv"
+end
+
+function %s
+    set -l state (string split ' ' (%s))
+    test "$state[1]" = $argv[1]
+end
+
+function %s
+    set -l state (string split ' ' (%s))
+    test "$state[1]" = $argv[1]; or return 1
+    string match -qr '^[0-9]+$' -- "$state[2]"; or return 1
+    if set -q argv[3]
+        test "$state[2]" -ge $argv[2]
+    else
+        test "$state[2]" -eq $argv[2]
+    end
+end
+
+`, is, state, inCommand, state, arg, state)
+
+	p := s.program
+	fmt.Fprintf(b, "complete -c %s -f\n", p)
+	fmt.Fprintf(b, "complete -c %s -n '%s db' -F\n", p, is)
+	for _, flag := range s.globalFlags {
+		line := fmt.Sprintf("complete -c %s -n '%s db; or %s value' -o %s", p, is, is, flag.Name)
+		if flag.HasValue {
+			line += " -x"
+			if len(flag.Choices) > 0 {
+				line += " -a " + shellQuote(strings.Join(flag.Choices, " "))
+			}
+		}
+		fmt.Fprintf(b, "%s -d %s\n", line, shellQuote(flag.Usage))
+	}
+	for _, cmd := range s.commands {
+		fmt.Fprintf(b, "complete -c %s -n '%s command' -a %s -d %s\n", p, is, cmd.spec.Name, shellQuote(cmd.spec.Synopsis))
+	}
+	for _, cmd := range s.commands {
+		for _, flag := range cmd.spec.Flags {
+			option := "-l " + flag.Name
+			if len(flag.Name) == 1 {
+				option = "-s " + flag.Name
+			}
+			line := fmt.Sprintf("complete -c %s -n '%s %s' %s", p, inCommand, cmd.spec.Name, option)
+			if flag.HasValue() {
+				line += " -x"
+				if len(flag.Choices) > 0 {
+					line += " -a " + shellQuote(strings.Join(flag.Choices, " "))
+				}
+			}
+			fmt.Fprintf(b, "%s -d %s\n", line, shellQuote(flag.Usage))
+		}
+		for i, action := range cmd.positions {
+			if action.empty() {
+				continue
+			}
+			condition := fmt.Sprintf("%s %s %d", arg, cmd.spec.Name, i)
+			if cmd.variadic && i == len(cmd.positions)-1 {
+				condition += " +"
+			}
+			if action.files {
+				fmt.Fprintf(b, "complete -c %s -n '%s' -F\n", p, condition)
+			} else {
+				fmt.Fprintf(b, "complete -c %s -n '%s' -a %s\n", p, condition, shellQuote(strings.Join(action.words, " ")))
+			}
+		}
+	}
+}
+
+type flagValue struct {
+	name    string
+	choices []string
+}
+
+func (s *completionScript) globalValueFlags() []flagValue {
+	var flags []flagValue
+	for _, flag := range s.globalFlags {
+		if flag.HasValue {
+			flags = append(flags, flagValue{name: "-" + flag.Name, choices: flag.Choices})
+		}
+	}
+
+	return flags
+}
+
+// valueCases writes "case $prev" arms: flags with choices offer them, others offer nothing
+func (s *completionScript) valueCases(b *strings.Builder, indent string, flags []flagValue, reply func(scriptAction) string) {
+	var free []string
+	for _, flag := range flags {
+		if len(flag.choices) == 0 {
+			free = append(free, flag.name)
+			continue
+		}
+		fmt.Fprintf(b, "%s%s) %s; return ;;\n", indent, flag.name, reply(scriptAction{words: flag.choices}))
+	}
+	if len(free) > 0 {
+		fmt.Fprintf(b, "%s%s) return ;;\n", indent, strings.Join(free, "|"))
+	}
+}
+
+func (s *completionScript) commandCases(b *strings.Builder, cmd *scriptCommand, pattern string, reply func(scriptAction) string) {
+	var positions []string
+	for i, action := range cmd.positions {
+		if action.empty() {
+			continue
+		}
+		position := fmt.Sprint(i)
+		if cmd.variadic && i == len(cmd.positions)-1 {
+			position = "*"
+		}
+		positions = append(positions, fmt.Sprintf("                "+pattern+" %s ;;\n", position, reply(action)))
+	}
+	if len(cmd.flags) == 0 && len(positions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "        %s)\n", cmd.spec.Name)
+	if len(cmd.valueFlags) > 0 {
+		var flags []flagValue
+		for _, flag := range cmd.spec.Flags {
+			if flag.HasValue() {
+				flags = append(flags, flagValue{name: flag.Option(), choices: flag.Choices})
+			}
+		}
+		b.WriteString("            case \"$prev\" in\n")
+		s.valueCases(b, "                ", flags, reply)
+		b.WriteString("            esac\n")
+	}
+	if len(cmd.flags) > 0 {
+		fmt.Fprintf(b, "            if [[ \"$cur\" == -* ]]; then\n                %s\n                return\n            fi\n", reply(scriptAction{words: cmd.flags}))
+	}
+	if len(positions) > 0 {
+		b.WriteString("            case $n in\n" + strings.Join(positions, "") + "            esac\n")
+	}
+	b.WriteString("            ;;\n")
+}
+
+func fishPatterns(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, shellQuote(word))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
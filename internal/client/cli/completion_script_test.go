@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionScript(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{
+			shell: ShellBash,
+			want: []string{
+				"complete -o filenames -F _gophkeeper_client gophkeeper-client",
+				`-output) COMPREPLY=($(compgen -W "table json yaml" -- "$cur")); return ;;`,
+				`COMPREPLY=($(compgen -W "export get help import list login meta set tag" -- "$cur"))`,
+				`--mode) COMPREPLY=($(compgen -W "merge replace" -- "$cur")); return ;;`,
+				`1) COMPREPLY=($(compgen -W "login_password text binary card" -- "$cur")) ;;`,
+				`list) valueflags="--tag --folder" ;;`,
+			},
+		},
+		{
+			shell: ShellZsh,
+			want: []string{
+				"#compdef gophkeeper-client",
+				"'set:add or replace an item'",
+				"(1) compadd -- login_password text binary card ;;",
+				"(0) _files ;;",
+				"compdef _gophkeeper_client gophkeeper-client",
+			},
+		},
+		{
+			shell: ShellFish,
+			want: []string{
+				"complete -c gophkeeper-client -n '_gophkeeper_client_is command' -a set -d 'add or replace an item'",
+				"complete -c gophkeeper-client -n '_gophkeeper_client_arg set 1' -a 'login_password text binary card'",
+				"complete -c gophkeeper-client -n '_gophkeeper_client_command import' -l mode -x -a 'merge replace'",
+				"complete -c gophkeeper-client -n '_gophkeeper_client_is db; or _gophkeeper_client_is value' -o output -x -a 'table json yaml'",
+				"case 'list --tag'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := CompletionScript(&buf, tt.shell, "gophkeeper-client", config.Flags(), newTestRegistry())
+			require.NoError(t, err)
+
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+
+			// only the shell available on the machine can check the syntax
+			if path, err := exec.LookPath(tt.shell); err == nil {
+				check := exec.Command(path, "-n")
+				check.Stdin = strings.NewReader(buf.String())
+				output, err := check.CombinedOutput()
+				assert.NoError(t, err, string(output))
+			}
+		})
+	}
+}
+
+func TestCompletionScript_UnsupportedShell(t *testing.T) {
+	var buf bytes.Buffer
+	err := CompletionScript(&buf, "powershell", "gophkeeper-client", config.Flags(), newTestRegistry())
+
+	assert.ErrorIs(t, err, command.ErrInvalidArgs)
+	assert.Empty(t, buf.String())
+}
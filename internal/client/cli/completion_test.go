@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockKeyLister struct {
+	keys []string
+	err  error
+}
+
+func (m *mockKeyLister) List(ctx context.Context) ([]*model.UserData, error) {
+	items := make([]*model.UserData, 0, len(m.keys))
+	for _, key := range m.keys {
+		items = append(items, &model.UserData{DataKey: key})
+	}
+	return items, m.err
+}
+
+func newTestCompleter(keys ...string) *Completer {
+	return NewCompleter(newTestRegistry(), &mockKeyLister{keys: keys})
+}
+
+func TestCompleter_Complete(t *testing.T) {
+	completer := newTestCompleter("github", "gitlab", "my bank")
+
+	tests := []struct {
+		name       string
+		prefix     string
+		wantStart  int
+		wantResult []string
+	}{
+		{name: "all commands", prefix: "", wantStart: 0, wantResult: []string{"export", "get", "help", "import", "list", "login", "meta", "set", "tag"}},
+		{name: "command prefix", prefix: "l", wantStart: 0, wantResult: []string{"list", "login"}},
+		{name: "keys", prefix: "get git", wantStart: 4, wantResult: []string{"github", "gitlab"}},
+		{name: "escaped key", prefix: `get my\ b`, wantStart: 4, wantResult: []string{"my bank"}},
+		{name: "quoted key", prefix: `get "my `, wantStart: 4, wantResult: []string{"my bank"}},
+		{name: "value types", prefix: "set github ", wantStart: 11, wantResult: []string{"login_password", "text", "binary", "card"}},
+		{name: "value type prefix", prefix: "set github  t", wantStart: 12, wantResult: []string{"text"}},
+		{name: "meta fields", prefix: "meta github f", wantStart: 12, wantResult: []string{"folder", "favorite"}},
+		{name: "tag action", prefix: "tag r", wantStart: 4, wantResult: []string{"remove"}},
+		{name: "tag key", prefix: "tag add gitl", wantStart: 8, wantResult: []string{"gitlab"}},
+		{name: "copy field", prefix: "get github --copy p", wantStart: 18, wantResult: []string{"password"}},
+		{name: "no more args", prefix: "get github --copy login ", wantStart: 24, wantResult: nil},
+		{name: "no positional args", prefix: "list ", wantStart: 5, wantResult: nil},
+		{name: "unknown command", prefix: "put ", wantStart: 4, wantResult: nil},
+		{name: "flags", prefix: "list --f", wantStart: 5, wantResult: []string{"--folder", "--favorite"}},
+		{name: "flag value", prefix: "import --mode ", wantStart: 14, wantResult: []string{"merge", "replace"}},
+		{name: "args after flag", prefix: "tag --x add gi", wantStart: 12, wantResult: []string{"github", "gitlab"}},
+		{name: "args after flag value", prefix: "list --tag work ", wantStart: 16, wantResult: nil},
+		{name: "help command", prefix: "help s", wantStart: 5, wantResult: []string{"set"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, got := completer.Complete(context.Background(), tt.prefix)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantResult, got)
+		})
+	}
+}
+
+func TestCompleter_CompleteKeysError(t *testing.T) {
+	completer := NewCompleter(NewCommandRegistry(command.NewGetCommand(nil, nil, nil)), &mockKeyLister{err: errors.New("db error")})
+
+	_, got := completer.Complete(context.Background(), "get ")
+	assert.Empty(t, got)
+}
+
+func TestCompleter_AutoComplete(t *testing.T) {
+	completer := newTestCompleter("github", "gitlab", "my bank")
+
+	tests := []struct {
+		name     string
+		line     string
+		pos      int
+		wantLine string
+		wantPos  int
+		wantShow []string
+	}{
+		{name: "single candidate", line: "ge", pos: 2, wantLine: "get ", wantPos: 4},
+		{name: "escaped candidate", line: "get my", pos: 6, wantLine: `get my\ bank `, wantPos: 13},
+		{name: "common prefix", line: "get g", pos: 5, wantLine: "get git", wantPos: 7},
+		{name: "ambiguous", line: "get git", pos: 7, wantLine: "get git", wantPos: 7, wantShow: []string{"github", "gitlab"}},
+		{name: "cursor in the middle", line: "se github", pos: 2, wantLine: "set  github", wantPos: 4},
+		{name: "no candidates", line: "get x", pos: 5, wantLine: "get x", wantPos: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shown []string
+			line, pos := completer.AutoComplete(tt.line, tt.pos, func(candidates []string) {
+				shown = candidates
+			})
+			assert.Equal(t, tt.wantLine, line)
+			assert.Equal(t, tt.wantPos, pos)
+			assert.Equal(t, tt.wantShow, shown)
+		})
+	}
+}
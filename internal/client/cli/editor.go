@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	prompt             = "> "
+	continuationPrompt = "... "
+)
+
+type Editor struct {
+	in           *os.File
+	terminal     *term.Terminal
+	history      term.History
+	buf          []byte
+	continuation bool
+	onActivity   func()
+}
+
+func NewEditor(in *os.File, out io.Writer, history *History, completer *Completer) *Editor {
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, prompt)
+
+	editor := &Editor{in: in, terminal: terminal, history: discardHistory{}}
+	if history != nil {
+		editor.history = history
+	}
+	// the callback sees every key press, so it also reports typing as activity
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if editor.onActivity != nil {
+			editor.onActivity()
+		}
+		if key != '\t' || completer == nil {
+			return "", 0, false
+		}
+		newLine, newPos := completer.AutoComplete(line, pos, editor.show)
+
+		return newLine, newPos, true
+	}
+
+	return editor
+}
+
+// OnActivity sets a callback called on every key press
+func (e *Editor) OnActivity(callback func()) {
+	e.onActivity = callback
+}
+
+// Read feeds the lexer line by line, so the terminal stays in raw mode only while a line is edited
+func (e *Editor) Read(p []byte) (int, error) {
+	if len(e.buf) == 0 {
+		line, err := e.readLine()
+		if err != nil {
+			return 0, err
+		}
+		e.buf = append([]byte(line), '\n')
+	}
+
+	n := copy(p, e.buf)
+	e.buf = e.buf[n:]
+
+	return n, nil
+}
+
+func (e *Editor) readLine() (string, error) {
+	fd := int(e.in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, state)
+
+	if width, height, err := term.GetSize(fd); err == nil && width > 0 {
+		e.terminal.SetSize(width, height)
+	}
+
+	// heredoc bodies are neither recorded nor recalled separately from their command
+	if e.continuation {
+		e.terminal.SetPrompt(continuationPrompt)
+		e.terminal.History = discardHistory{}
+	} else {
+		e.terminal.SetPrompt(prompt)
+		e.terminal.History = e.history
+	}
+
+	line, err := e.terminal.ReadLine()
+	if errors.Is(err, term.ErrPasteIndicator) {
+		err = nil
+	}
+	e.continuation = true
+
+	return line, err
+}
+
+func (e *Editor) startCommand() {
+	e.continuation = false
+}
+
+func (e *Editor) show(candidates []string) {
+	e.terminal.Write([]byte(strings.Join(candidates, "  ") + "\n"))
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+)
+
+const (
+	ExitOK = iota
+	ExitFailure
+	ExitUsage
+	ExitNotFound
+	ExitAuth
+	ExitSync
+)
+
+func Exec(ctx context.Context, registry *CommandRegistry, args []string, output string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		RenderError(stderr, output, errors.New("command is required"))
+
+		return ExitUsage
+	}
+
+	// a whole command line passed as a single argument, e.g. 'set note text "a b"'
+	if len(args) == 1 && strings.ContainsAny(args[0], " \t\n'\"\\") {
+		parsed, err := Split(args[0])
+		if err != nil {
+			RenderError(stderr, output, err)
+
+			return ExitCode(err)
+		}
+		if len(parsed) == 0 {
+			RenderError(stderr, output, errors.New("command is required"))
+
+			return ExitUsage
+		}
+		args = parsed
+	}
+
+	cmd, err := registry.Get(args[0])
+	if err != nil {
+		RenderError(stderr, output, err)
+
+		return ExitUsage
+	}
+
+	result, err := cmd.Execute(ctx, args[1:])
+	if err != nil {
+		err = withUsage(cmd, err)
+		RenderError(stderr, output, err)
+
+		return ExitCode(err)
+	}
+
+	if err := Render(stdout, output, result); err != nil {
+		RenderError(stderr, output, err)
+
+		return ExitFailure
+	}
+
+	return ExitOK
+}
+
+func ExitCode(err error) int {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, command.ErrInvalidArgs), errors.Is(err, command.ErrUnknownCommand), errors.As(err, new(*SyntaxError)):
+		return ExitUsage
+	case errors.Is(err, manager.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, manager.ErrInvalidMasterPassword), errors.Is(err, vault.ErrLocked):
+		return ExitAuth
+	case errors.Is(err, synchronizer.ErrSyncFailed):
+		return ExitSync
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		// run passes the exit code of the program through
+		return exitErr.ExitCode()
+	default:
+		return ExitFailure
+	}
+}
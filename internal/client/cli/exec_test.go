@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExec(t *testing.T) {
+	registry := NewCommandRegistry(
+		&mockCommand{
+			name: "get",
+			args: []command.Arg{{Name: "key"}},
+			execute: func(ctx context.Context, args []string) (command.Result, error) {
+				switch {
+				case len(args) == 0:
+					return nil, fmt.Errorf("%w: <key>", command.ErrInvalidArgs)
+				case args[0] == "missing":
+					return nil, manager.ErrNotFound
+				case args[0] == "broken":
+					return nil, errors.New("decrypt error")
+				default:
+					return command.Message("value of " + args[0]), nil
+				}
+			},
+		},
+	)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "success", args: []string{"get", "key"}, wantCode: ExitOK, wantStdout: "value of key\n"},
+		{name: "no command", args: nil, wantCode: ExitUsage, wantStderr: "error: command is required\n"},
+		{name: "unknown command", args: []string{"put"}, wantCode: ExitUsage, wantStderr: "error: unknown command: put, run \"help\" to list commands\n"},
+		{name: "invalid args", args: []string{"get"}, wantCode: ExitUsage, wantStderr: "error: args: <key>\nusage: get <key>\n"},
+		{name: "not found", args: []string{"get", "missing"}, wantCode: ExitNotFound, wantStderr: "error: data not found\n"},
+		{name: "single line command", args: []string{`get "my key"`}, wantCode: ExitOK, wantStdout: "value of my key\n"},
+		{name: "unbalanced quote", args: []string{`get "my key`}, wantCode: ExitUsage, wantStderr: "error: syntax error at line 1, column 5: unbalanced double quote\n"},
+		{name: "failure", args: []string{"get", "broken"}, wantCode: ExitFailure, wantStderr: "error: decrypt error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Exec(context.Background(), registry, tt.args, OutputTable, &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
+
+func TestExec_StructuredOutput(t *testing.T) {
+	registry := NewCommandRegistry(
+		&mockCommand{
+			name: "set",
+			args: []command.Arg{{Name: "key"}},
+			execute: func(ctx context.Context, args []string) (command.Result, error) {
+				if len(args) == 0 {
+					return nil, fmt.Errorf("%w: <key>", command.ErrInvalidArgs)
+				}
+				return command.Message("saved successful"), nil
+			},
+		},
+	)
+
+	var stdout, stderr bytes.Buffer
+	code := Exec(context.Background(), registry, []string{"set", "key"}, OutputJSON, &stdout, &stderr)
+	assert.Equal(t, ExitOK, code)
+	assert.JSONEq(t, `{"message": "saved successful"}`, stdout.String())
+
+	stdout.Reset()
+	code = Exec(context.Background(), registry, []string{"set"}, OutputYAML, &stdout, &stderr)
+	assert.Equal(t, ExitUsage, code)
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "error: 'args: <key>'\nusage: set <key>\n", stderr.String())
+
+	stderr.Reset()
+	code = Exec(context.Background(), registry, []string{"set"}, OutputJSON, &stdout, &stderr)
+	assert.Equal(t, ExitUsage, code)
+	assert.JSONEq(t, `{"error": "args: <key>", "usage": "set <key>"}`, stderr.String())
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitAuth, ExitCode(fmt.Errorf("wrapped: %w", manager.ErrInvalidMasterPassword)))
+	assert.Equal(t, ExitAuth, ExitCode(vault.ErrLocked))
+	assert.Equal(t, ExitSync, ExitCode(synchronizer.ErrSyncFailed))
+	assert.Equal(t, ExitUsage, ExitCode(fmt.Errorf("%w: put", command.ErrUnknownCommand)))
+	assert.Equal(t, ExitFailure, ExitCode(errors.New("other")))
+
+	err := exec.Command("sh", "-c", "exit 7").Run()
+	assert.Equal(t, 7, ExitCode(fmt.Errorf("sh: %w", err)))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"go.uber.org/zap"
+)
+
+type HistoryStorage interface {
+	List(ctx context.Context, masterPassword []byte) ([]string, error)
+	Add(ctx context.Context, entry string, masterPassword []byte) error
+}
+
+type History struct {
+	storage  HistoryStorage
+	registry *CommandRegistry
+	keys     command.KeyProvider
+	entries  []string
+	limit    int
+}
+
+func NewHistory(ctx context.Context, storage HistoryStorage, registry *CommandRegistry, keys command.KeyProvider, limit int) (*History, error) {
+	masterPassword, err := keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	entries, err := storage.List(ctx, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	return &History{
+		storage:  storage,
+		registry: registry,
+		keys:     keys,
+		entries:  entries,
+		limit:    limit,
+	}, nil
+}
+
+func (h *History) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if !h.registry.InHistory(entry) {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+
+	// while the vault is locked the entry is kept in memory only
+	masterPassword, err := h.keys.MasterPassword()
+	if err != nil {
+		return
+	}
+	defer clear(masterPassword)
+
+	if err := h.storage.Add(context.Background(), entry, masterPassword); err != nil {
+		logger.Logger.Warn("Can`t save history entry", zap.Error(err))
+	}
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+func (h *History) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+type discardHistory struct{}
+
+func (discardHistory) Add(string)    {}
+func (discardHistory) Len() int      { return 0 }
+func (discardHistory) At(int) string { return "" }
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockHistoryStorage struct {
+	entries []string
+	listErr error
+	addErr  error
+}
+
+func (m *mockHistoryStorage) List(ctx context.Context, masterPassword []byte) ([]string, error) {
+	return m.entries, m.listErr
+}
+
+func (m *mockHistoryStorage) Add(ctx context.Context, entry string, masterPassword []byte) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+type mockKeys struct {
+	password []byte
+}
+
+func (m *mockKeys) MasterPassword() ([]byte, error) {
+	if m.password == nil {
+		return nil, errors.New("vault is locked")
+	}
+	return bytes.Clone(m.password), nil
+}
+
+func TestHistory(t *testing.T) {
+	storage := &mockHistoryStorage{entries: []string{"list", "get a", "get b"}}
+	history, err := NewHistory(context.Background(), storage, newTestRegistry(), &mockKeys{password: []byte("master")}, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, history.Len())
+	assert.Equal(t, "get b", history.At(0))
+	assert.Equal(t, "list", history.At(2))
+
+	history.Add("  meta github  ")
+	history.Add("meta github")
+	history.Add("set github login_password user secret")
+	history.Add(`login "user" secret`)
+	history.Add("sett github login_password user secret")
+	history.Add(`set github login_password "user secret`)
+	history.Add("")
+
+	assert.Equal(t, 3, history.Len())
+	assert.Equal(t, "meta github", history.At(0))
+	assert.Equal(t, "get a", history.At(2))
+	assert.Equal(t, []string{"list", "get a", "get b", "meta github"}, storage.entries)
+}
+
+func TestHistory_LoadLimit(t *testing.T) {
+	storage := &mockHistoryStorage{entries: []string{"list", "get a", "get b"}}
+	history, err := NewHistory(context.Background(), storage, newTestRegistry(), &mockKeys{password: []byte("master")}, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, history.Len())
+	assert.Equal(t, "get a", history.At(1))
+}
+
+func TestHistory_Errors(t *testing.T) {
+	_, err := NewHistory(context.Background(), &mockHistoryStorage{listErr: errors.New("decrypt error")}, newTestRegistry(), &mockKeys{password: []byte("master")}, 10)
+	assert.EqualError(t, err, "decrypt error")
+
+	history, err := NewHistory(context.Background(), &mockHistoryStorage{addErr: errors.New("db error")}, newTestRegistry(), &mockKeys{password: []byte("master")}, 10)
+	require.NoError(t, err)
+
+	history.Add("list")
+	assert.Equal(t, 1, history.Len())
+}
+
+func TestHistory_Locked(t *testing.T) {
+	keys := &mockKeys{password: []byte("master")}
+	storage := &mockHistoryStorage{}
+	history, err := NewHistory(context.Background(), storage, newTestRegistry(), keys, 10)
+	require.NoError(t, err)
+
+	keys.password = nil
+	history.Add("list")
+	assert.Equal(t, 1, history.Len())
+	assert.Empty(t, storage.entries)
+
+	_, err = NewHistory(context.Background(), storage, newTestRegistry(), keys, 10)
+	assert.EqualError(t, err, "vault is locked")
+}
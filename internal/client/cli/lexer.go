@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const heredocPrefix = "<<"
+
+type SyntaxError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+type Lexer struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func NewLexer(r io.Reader) *Lexer {
+	return &Lexer{reader: bufio.NewReader(r)}
+}
+
+func Split(input string) ([]string, error) {
+	lexer := NewLexer(strings.NewReader(input))
+	args, err := lexer.Next()
+	if errors.Is(err, io.EOF) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rest, err := lexer.Next(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) > 0 {
+			return nil, &SyntaxError{Line: lexer.line, Column: 1, Msg: "only one command is allowed"}
+		}
+	}
+
+	return args, nil
+}
+
+// Next returns the arguments of the next command, an empty slice for a blank line
+// and io.EOF when the input is exhausted. A *SyntaxError consumes the broken command.
+func (l *Lexer) Next() ([]string, error) {
+	line, err := l.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	args, heredocs, err := l.tokenize(line)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, heredoc := range heredocs {
+		body, err := l.readHeredoc(heredoc.tag)
+		if err != nil {
+			return nil, err
+		}
+		args[heredoc.index] = body
+	}
+
+	return args, nil
+}
+
+func (l *Lexer) readLine() (string, error) {
+	line, err := l.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return "", io.EOF
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	l.line++
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (l *Lexer) readHeredoc(tag string) (string, error) {
+	start := l.line
+	var lines []string
+	for {
+		line, err := l.readLine()
+		if errors.Is(err, io.EOF) {
+			return "", &SyntaxError{Line: start, Column: 1, Msg: fmt.Sprintf("unterminated heredoc, expected %q line", tag)}
+		}
+		if err != nil {
+			return "", err
+		}
+		if line == tag {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+type heredoc struct {
+	index int
+	tag   string
+}
+
+func (l *Lexer) tokenize(line string) ([]string, []heredoc, error) {
+	args := []string{}
+	var heredocs []heredoc
+	var token strings.Builder
+	inToken, quoted := false, false
+
+	flush := func() {
+		if !inToken {
+			return
+		}
+		arg := token.String()
+		if tag, ok := strings.CutPrefix(arg, heredocPrefix); ok && !quoted && isHeredocTag(tag) {
+			heredocs = append(heredocs, heredoc{index: len(args), tag: tag})
+		}
+		args = append(args, arg)
+		token.Reset()
+		inToken, quoted = false, false
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			flush()
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, nil, l.syntaxError(i, "unexpected end of line after backslash")
+			}
+			i++
+			token.WriteRune(runes[i])
+			inToken = true
+		case r == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, nil, l.syntaxError(i, "unbalanced single quote")
+			}
+			token.WriteString(string(runes[i+1 : end]))
+			i = end
+			inToken, quoted = true, true
+		case r == '"':
+			end, err := l.readDoubleQuoted(runes, i, &token)
+			if err != nil {
+				return nil, nil, err
+			}
+			i = end
+			inToken, quoted = true, true
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	flush()
+
+	return args, heredocs, nil
+}
+
+func (l *Lexer) readDoubleQuoted(runes []rune, start int, token *strings.Builder) (int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			return i, nil
+		case '\\':
+			if i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+			}
+		}
+		token.WriteRune(runes[i])
+	}
+
+	return 0, l.syntaxError(start, "unbalanced double quote")
+}
+
+func (l *Lexer) syntaxError(index int, msg string) error {
+	return &SyntaxError{Line: l.line, Column: index + 1, Msg: msg}
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isHeredocTag(tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for _, r := range tag {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
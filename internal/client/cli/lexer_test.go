@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{name: "plain", input: "set key text value", want: []string{"set", "key", "text", "value"}},
+		{name: "extra spaces", input: "  get \t key  ", want: []string{"get", "key"}},
+		{name: "empty", input: "", want: []string{}},
+		{name: "single quotes", input: `set key text 'it is "raw" \n'`, want: []string{"set", "key", "text", `it is "raw" \n`}},
+		{name: "double quotes", input: `set key text "say \"hi\" \\ \n"`, want: []string{"set", "key", "text", `say "hi" \ \n`}},
+		{name: "empty quotes", input: `set key text ""`, want: []string{"set", "key", "text", ""}},
+		{name: "adjacent quotes", input: `get my' 'key"s"`, want: []string{"get", "my keys"}},
+		{name: "backslash escapes", input: `get my\ key \'x\"`, want: []string{"get", "my key", `'x"`}},
+		{name: "quoted heredoc marker", input: `set key text '<<EOF'`, want: []string{"set", "key", "text", "<<EOF"}},
+		{name: "heredoc", input: "set note text <<EOF\nfirst line\n  second 'line'\nEOF", want: []string{"set", "note", "text", "first line\n  second 'line'"}},
+		{name: "empty heredoc", input: "set note text <<END\nEND\n", want: []string{"set", "note", "text", ""}},
+		{name: "unbalanced double quote", input: `set key text "value`, wantErr: "syntax error at line 1, column 14: unbalanced double quote"},
+		{name: "unbalanced single quote", input: `get 'key`, wantErr: "syntax error at line 1, column 5: unbalanced single quote"},
+		{name: "trailing backslash", input: `get key\`, wantErr: "syntax error at line 1, column 8: unexpected end of line after backslash"},
+		{name: "unterminated heredoc", input: "set note text <<EOF\nline", wantErr: `syntax error at line 1, column 1: unterminated heredoc, expected "EOF" line`},
+		{name: "several commands", input: "get a\nget b", wantErr: "syntax error at line 2, column 1: only one command is allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(tt.input)
+			if tt.wantErr != "" {
+				var syntaxErr *SyntaxError
+				require.ErrorAs(t, err, &syntaxErr)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLexer_Next(t *testing.T) {
+	lexer := NewLexer(strings.NewReader("get a\r\n\nset \"b\nset c text <<EOF\nx\nEOF\nlist\n"))
+
+	args, err := lexer.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"get", "a"}, args)
+
+	args, err = lexer.Next()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	_, err = lexer.Next()
+	assert.EqualError(t, err, "syntax error at line 3, column 5: unbalanced double quote")
+
+	args, err = lexer.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"set", "c", "text", "x"}, args)
+
+	args, err = lexer.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list"}, args)
+
+	_, err = lexer.Next()
+	assert.True(t, errors.Is(err, io.EOF))
+}
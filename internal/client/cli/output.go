@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+func ValidateOutput(output string) error {
+	switch output {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s, expected table, json or yaml", output)
+	}
+}
+
+func Render(w io.Writer, output string, result command.Result) error {
+	switch output {
+	case OutputJSON, OutputYAML:
+		return renderStructured(w, output, result)
+	default:
+		text := result.Text()
+		if text == "" {
+			return nil
+		}
+		_, err := fmt.Fprintln(w, text)
+		return err
+	}
+}
+
+func RenderError(w io.Writer, output string, err error) {
+	switch output {
+	case OutputJSON, OutputYAML:
+		structured := struct {
+			Error string `json:"error"`
+			Usage string `json:"usage,omitempty"`
+		}{Error: err.Error()}
+		var usageErr *UsageError
+		if errors.As(err, &usageErr) {
+			structured.Error, structured.Usage = usageErr.Err.Error(), usageErr.Usage
+		}
+		renderErr := renderStructured(w, output, structured)
+		if renderErr == nil {
+			return
+		}
+	}
+
+	fmt.Fprintf(w, "error: %s\n", err)
+}
+
+// yaml is produced from the json representation,
+// so both formats share the same schema and field order
+func renderStructured(w io.Writer, output string, v any) error {
+	var buf bytes.Buffer
+	jsonEncoder := json.NewEncoder(&buf)
+	jsonEncoder.SetEscapeHTML(false)
+	jsonEncoder.SetIndent("", "  ")
+	if err := jsonEncoder.Encode(v); err != nil {
+		return fmt.Errorf("can`t marshal result: %w", err)
+	}
+	data := buf.Bytes()
+
+	if output == OutputJSON {
+		_, err := w.Write(data)
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("can`t convert result to yaml: %w", err)
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
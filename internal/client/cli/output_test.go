@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItem() *command.Item {
+	return &command.Item{
+		Key:       "visa",
+		Type:      "card",
+		Value:     &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"},
+		Meta:      &metadata.Metadata{Tags: []string{"bank"}},
+		UpdatedAt: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	for _, output := range []string{OutputTable, OutputJSON, OutputYAML} {
+		assert.NoError(t, ValidateOutput(output))
+	}
+	assert.Error(t, ValidateOutput("xml"))
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{
+			output: OutputTable,
+			want:   testItem().Text() + "\n",
+		},
+		{
+			output: OutputJSON,
+			want: `{
+  "key": "visa",
+  "type": "card",
+  "value": {
+    "number": "4111111111111111",
+    "holder": "JOHN DOE",
+    "expire_month": 5,
+    "expire_year": 2027,
+    "cvc": "123"
+  },
+  "meta": {
+    "tags": [
+      "bank"
+    ]
+  },
+  "updated_at": "2025-05-01T10:00:00Z"
+}
+`,
+		},
+		{
+			output: OutputYAML,
+			want: `key: visa
+type: card
+value:
+  number: "4111111111111111"
+  holder: JOHN DOE
+  expire_month: 5
+  expire_year: 2027
+  cvc: "123"
+meta:
+  tags:
+    - bank
+updated_at: "2025-05-01T10:00:00Z"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Render(&buf, tt.output, testItem()))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRender_EmptyText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, OutputTable, &command.RunResult{Command: "true"}))
+	assert.Equal(t, "", buf.String())
+
+	require.NoError(t, Render(&buf, OutputJSON, &command.RunResult{Command: "true"}))
+	assert.JSONEq(t, `{"command": "true", "exit_code": 0}`, buf.String())
+}
+
+func TestRenderError(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: OutputTable, want: "error: data not found\n"},
+		{output: OutputJSON, want: "{\n  \"error\": \"data not found\"\n}\n"},
+		{output: OutputYAML, want: "error: data not found\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			var buf bytes.Buffer
+			RenderError(&buf, tt.output, errors.New("data not found"))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+)
+
+type CommandRegistry struct {
+	commands   map[string]command.Command
+	onActivity func()
+}
+
+func NewCommandRegistry(commands ...command.Command) *CommandRegistry {
+	registry := &CommandRegistry{commands: make(map[string]command.Command, len(commands))}
+	for _, cmd := range commands {
+		registry.Register(cmd)
+	}
+
+	return registry
+}
+
+func (r *CommandRegistry) Register(cmd command.Command) {
+	r.commands[cmd.Spec().Name] = cmd
+}
+
+// OnActivity sets a callback called before every command executed by the shell
+func (r *CommandRegistry) OnActivity(callback func()) {
+	r.onActivity = callback
+}
+
+func (r *CommandRegistry) activity() {
+	if r.onActivity != nil {
+		r.onActivity()
+	}
+}
+
+func (r *CommandRegistry) Get(name string) (command.Command, error) {
+	cmd, ok := r.commands[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, run \"help\" to list commands", command.ErrUnknownCommand, name)
+	}
+
+	return cmd, nil
+}
+
+func (r *CommandRegistry) Names() []string {
+	names := make([]string, 0, len(r.commands))
+	for name := range r.commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func (r *CommandRegistry) Specs() []command.Spec {
+	specs := make([]command.Spec, 0, len(r.commands))
+	for _, name := range r.Names() {
+		specs = append(specs, r.commands[name].Spec())
+	}
+
+	return specs
+}
+
+func (r *CommandRegistry) Spec(name string) (command.Spec, bool) {
+	cmd, ok := r.commands[name]
+	if !ok {
+		return command.Spec{}, false
+	}
+
+	return cmd.Spec(), true
+}
+
+// InHistory reports whether a line may be saved to history, it must parse and start with a registered
+// command that isn`t sensitive. A mistyped command may carry a secret as well
+func (r *CommandRegistry) InHistory(line string) bool {
+	args, err := Split(line)
+	if err != nil || len(args) == 0 {
+		return false
+	}
+
+	spec, ok := r.Spec(args[0])
+
+	return ok && !spec.Sensitive
+}
+
+type UsageError struct {
+	Err   error
+	Usage string
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error() + "\nusage: " + e.Usage
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+func withUsage(cmd command.Command, err error) error {
+	if !errors.Is(err, command.ErrInvalidArgs) {
+		return err
+	}
+
+	return &UsageError{Err: err, Usage: cmd.Spec().Usage()}
+}
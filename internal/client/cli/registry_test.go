@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/command"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() *CommandRegistry {
+	registry := NewCommandRegistry(
+		command.NewGetCommand(nil, nil, nil),
+		command.NewSetCommand(nil, nil),
+		command.NewListCommand(nil, nil),
+		command.NewLoginCommand(nil, nil, nil),
+		command.NewMetaCommand(nil, nil),
+		command.NewTagCommand(nil, nil),
+		command.NewImportCommand(nil, nil, nil),
+		command.NewExportCommand(nil, nil, nil),
+	)
+	registry.Register(command.NewHelpCommand(registry))
+
+	return registry
+}
+
+func TestCommandRegistry(t *testing.T) {
+	registry := newTestRegistry()
+
+	cmd, err := registry.Get("get")
+	require.NoError(t, err)
+	assert.Equal(t, "get", cmd.Spec().Name)
+
+	_, err = registry.Get("put")
+	assert.ErrorIs(t, err, command.ErrUnknownCommand)
+	assert.EqualError(t, err, `unknown command: put, run "help" to list commands`)
+
+	assert.Equal(t, []string{"export", "get", "help", "import", "list", "login", "meta", "set", "tag"}, registry.Names())
+
+	specs := registry.Specs()
+	require.Len(t, specs, 9)
+	assert.Equal(t, "export", specs[0].Name)
+
+	spec, ok := registry.Spec("set")
+	require.True(t, ok)
+	assert.Equal(t, "set <key> <type> <value...>", spec.Usage())
+
+	_, ok = registry.Spec("put")
+	assert.False(t, ok)
+}
+
+func TestCommandRegistry_InHistory(t *testing.T) {
+	registry := newTestRegistry()
+
+	assert.False(t, registry.InHistory("set key text secret"))
+	assert.False(t, registry.InHistory(`  login user "pass`))
+	// the archive password is asked for, so the path alone is safe to keep
+	assert.True(t, registry.InHistory("export backup.gk"))
+	assert.True(t, registry.InHistory("get key"))
+	// a mistyped command may be a sensitive one
+	assert.False(t, registry.InHistory("sett key text secret"))
+	assert.False(t, registry.InHistory(`get "key`))
+	assert.False(t, registry.InHistory(""))
+}
+
+func TestUsageError(t *testing.T) {
+	cmd := command.NewGetCommand(nil, nil, nil)
+
+	err := withUsage(cmd, fmt.Errorf("%w: <key>", command.ErrInvalidArgs))
+	assert.EqualError(t, err, "args: <key>\nusage: get [--copy] [--mask] <key> [login|password|number|holder|expire|cvc|text]")
+	assert.ErrorIs(t, err, command.ErrInvalidArgs)
+
+	other := errors.New("other")
+	assert.Equal(t, other, withUsage(cmd, other))
+
+	_, err = cmd.Execute(context.Background(), nil)
+	assert.ErrorIs(t, withUsage(cmd, err), command.ErrInvalidArgs)
+}
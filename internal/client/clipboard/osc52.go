@@ -0,0 +1,127 @@
+package clipboard
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"go.uber.org/zap"
+)
+
+const ttyPath = "/dev/tty"
+
+// OSC52 sets the clipboard of the terminal emulator with an escape sequence,
+// it works over ssh and without a display server
+type OSC52 struct {
+	path    string
+	tmux    bool
+	timeout time.Duration
+	mu      sync.Mutex
+	timer   *time.Timer
+	cleared chan struct{}
+}
+
+// NewOSC52 creates a clipboard that is cleared after timeout, timeout <= 0 disables clearing
+func NewOSC52(timeout time.Duration) *OSC52 {
+	return &OSC52{
+		path:    ttyPath,
+		tmux:    os.Getenv("TMUX") != "",
+		timeout: timeout,
+	}
+}
+
+func (c *OSC52) ClearAfter() time.Duration {
+	return c.timeout
+}
+
+func (c *OSC52) Copy(text string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.write(text); err != nil {
+		return err
+	}
+
+	c.stop()
+	if c.timeout > 0 {
+		c.cleared = make(chan struct{})
+		c.timer = time.AfterFunc(c.timeout, c.Clear)
+	}
+
+	return nil
+}
+
+func (c *OSC52) Pending() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cleared != nil
+}
+
+// Wait blocks until the clipboard is cleared by timeout, cancelling ctx clears it immediately
+func (c *OSC52) Wait(ctx context.Context) {
+	c.mu.Lock()
+	cleared := c.cleared
+	c.mu.Unlock()
+	if cleared == nil {
+		return
+	}
+
+	select {
+	case <-cleared:
+	case <-ctx.Done():
+		c.Clear()
+	}
+}
+
+func (c *OSC52) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cleared == nil {
+		return
+	}
+	if err := c.write(""); err != nil {
+		logger.Logger.Warn("Can`t clear clipboard", zap.Error(err))
+	}
+	c.stop()
+}
+
+func (c *OSC52) stop() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if c.cleared != nil {
+		close(c.cleared)
+		c.cleared = nil
+	}
+}
+
+func (c *OSC52) write(text string) error {
+	tty, err := os.OpenFile(c.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("clipboard requires a terminal: %w", err)
+	}
+	defer tty.Close()
+
+	if _, err := tty.WriteString(sequence(text, c.tmux)); err != nil {
+		return fmt.Errorf("can`t write to terminal: %w", err)
+	}
+
+	return nil
+}
+
+func sequence(text string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if tmux {
+		// tmux passes the sequence to the outer terminal only inside a DCS wrapper
+		return "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+
+	return seq
+}
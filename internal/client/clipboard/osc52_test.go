@@ -0,0 +1,71 @@
+package clipboard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClipboard(t *testing.T, timeout time.Duration) (*OSC52, string) {
+	path := filepath.Join(t.TempDir(), "tty")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	return &OSC52{path: path, timeout: timeout}, path
+}
+
+func TestOSC52_Copy(t *testing.T) {
+	clipboard, path := newTestClipboard(t, 0)
+
+	require.NoError(t, clipboard.Copy("secret"))
+	assert.False(t, clipboard.Pending())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b]52;c;c2VjcmV0\x07", string(data))
+}
+
+func TestOSC52_ClearAfterTimeout(t *testing.T) {
+	clipboard, path := newTestClipboard(t, 10*time.Millisecond)
+
+	require.NoError(t, clipboard.Copy("secret"))
+	assert.True(t, clipboard.Pending())
+
+	clipboard.Wait(context.Background())
+	assert.False(t, clipboard.Pending())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b]52;c;c2VjcmV0\x07\x1b]52;c;\x07", string(data))
+}
+
+func TestOSC52_WaitCancelled(t *testing.T) {
+	clipboard, path := newTestClipboard(t, time.Hour)
+
+	require.NoError(t, clipboard.Copy("secret"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	clipboard.Wait(ctx)
+	assert.False(t, clipboard.Pending())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b]52;c;c2VjcmV0\x07\x1b]52;c;\x07", string(data))
+}
+
+func TestOSC52_NoTerminal(t *testing.T) {
+	clipboard := &OSC52{path: filepath.Join(t.TempDir(), "missing")}
+
+	err := clipboard.Copy("secret")
+	assert.ErrorContains(t, err, "clipboard requires a terminal")
+	assert.False(t, clipboard.Pending())
+}
+
+func TestSequence_Tmux(t *testing.T) {
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;YQ==\x07\x1b\\", sequence("a", true))
+}
@@ -1,7 +1,17 @@
 package command
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidArgs = errors.New("args")
+
+type KeyProvider interface {
+	MasterPassword() ([]byte, error)
+}
 
 type Command interface {
-	Execute(ctx context.Context, args []string) (string, error)
+	Spec() Spec
+	Execute(ctx context.Context, args []string) (Result, error)
 }
@@ -0,0 +1,364 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	credentialGet   = "get"
+	credentialStore = "store"
+	credentialErase = "erase"
+)
+
+type CredentialStorage interface {
+	Get(ctx context.Context, key string) (*model.UserData, error)
+	List(ctx context.Context) ([]*model.UserData, error)
+	Upsert(ctx context.Context, data *model.UserData) error
+}
+
+// Credential is a set of git credential attributes, see gitcredentials(7)
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// ReadCredential reads key=value lines up to an empty line or EOF, unknown attributes are ignored
+func ReadCredential(r io.Reader) ([]string, error) {
+	var attributes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		attributes = append(attributes, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can`t read credential: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// CredentialArgs appends attributes read from r when none are given in args
+func CredentialArgs(args []string, r io.Reader) ([]string, error) {
+	if len(args) != 1 {
+		return args, nil
+	}
+
+	attributes, err := ReadCredential(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, attributes...), nil
+}
+
+func parseCredential(attributes []string) (*Credential, error) {
+	credential := &Credential{}
+	for _, attribute := range attributes {
+		name, val, ok := strings.Cut(attribute, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid attribute %q, expected name=value", ErrInvalidArgs, attribute)
+		}
+
+		switch name {
+		case "protocol":
+			credential.Protocol = val
+		case "host":
+			credential.Host = val
+		case "path":
+			credential.Path = val
+		case "username":
+			credential.Username = val
+		case "password":
+			credential.Password = val
+		case "url":
+			parsed, err := url.Parse(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid url: %w", ErrInvalidArgs, err)
+			}
+			credential.Protocol, credential.Host = parsed.Scheme, parsed.Host
+			credential.Path = strings.TrimPrefix(parsed.Path, "/")
+			if parsed.User != nil {
+				credential.Username = parsed.User.Username()
+			}
+		}
+	}
+
+	return credential, nil
+}
+
+// match returns how specific the item url is for the credential, or -1 if it doesn't match.
+// An item url without a scheme is https, git must not get a password for http unless the item says so
+func (c *Credential) match(itemURL string) int {
+	if !strings.Contains(itemURL, "://") {
+		itemURL = "https://" + itemURL
+	}
+	parsed, err := url.Parse(itemURL)
+	if err != nil || parsed.Scheme != c.Protocol || !strings.EqualFold(parsed.Host, c.Host) {
+		return -1
+	}
+
+	prefix := strings.Trim(parsed.Path, "/")
+	path := strings.Trim(c.Path, "/")
+	// git sends path only with credential.useHttpPath, without it any path of the host matches
+	if prefix == "" || path == "" {
+		return 0
+	}
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return -1
+	}
+
+	return len(prefix)
+}
+
+func (c *Credential) url() string {
+	u := url.URL{Scheme: c.Protocol, Host: c.Host, Path: c.Path}
+	if c.Path != "" {
+		u.Path = "/" + strings.TrimPrefix(c.Path, "/")
+	}
+
+	return u.String()
+}
+
+type CredentialResult struct {
+	Key      string `json:"key,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Text is in the git credential format, it is empty when nothing was found or for store and erase
+func (r *CredentialResult) Text() string {
+	if r.Username == "" && r.Password == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("username=%s\npassword=%s", r.Username, r.Password)
+}
+
+type credentialItem struct {
+	data  *model.UserData
+	meta  *metadata.Metadata
+	value *value.LoginPassword
+	score int
+}
+
+type CredentialCommand struct {
+	dataManager CredentialStorage
+	keys        KeyProvider
+	stdin       io.Reader
+}
+
+func NewCredentialCommand(dataManager CredentialStorage, keys KeyProvider) *CredentialCommand {
+	return &CredentialCommand{
+		dataManager: dataManager,
+		keys:        keys,
+		stdin:       os.Stdin,
+	}
+}
+
+func (c *CredentialCommand) Spec() Spec {
+	return Spec{
+		Name:     "git-credential",
+		Synopsis: "git credential helper, reads attributes from stdin",
+		Args: []Arg{
+			{Name: "action", Kind: ArgChoice, Choices: []string{credentialGet, credentialStore, credentialErase}},
+			{Name: "attribute=value", Optional: true, Variadic: true},
+		},
+		Examples: []string{
+			"git-credential get protocol=https host=github.com",
+			`git config --global credential.helper "gophkeeper-client -password-command 'pass show gophkeeper' vault.db git-credential"`,
+		},
+		Sensitive: true,
+	}
+}
+
+func (c *CredentialCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: <get|store|erase> [attribute=value...]", ErrInvalidArgs)
+	}
+	action := args[0]
+	if action != credentialGet && action != credentialStore && action != credentialErase {
+		return nil, fmt.Errorf("%w: unknown action %q, expected get, store or erase", ErrInvalidArgs, action)
+	}
+
+	args, err := CredentialArgs(args, c.stdin)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := parseCredential(args[1:])
+	if err != nil {
+		return nil, err
+	}
+	// helpers must ignore requests they can`t handle, so git falls back to other helpers
+	if credential.Protocol == "" || credential.Host == "" {
+		return &CredentialResult{}, nil
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	switch action {
+	case credentialGet:
+		return c.get(ctx, credential, masterPassword)
+	case credentialStore:
+		return c.store(ctx, credential, masterPassword)
+	default:
+		return c.erase(ctx, credential, masterPassword)
+	}
+}
+
+func (c *CredentialCommand) get(ctx context.Context, credential *Credential, masterPassword []byte) (Result, error) {
+	item, err := c.find(ctx, credential, masterPassword)
+	if err != nil || item == nil {
+		return &CredentialResult{}, err
+	}
+	// attributes are separated by newlines, a value with one would inject attributes, git rejects them too
+	if strings.ContainsAny(item.value.Login+item.value.Password, "\n\x00") {
+		return nil, fmt.Errorf("can`t return credential %q: login and password must not contain a newline or NUL", item.data.DataKey)
+	}
+
+	return &CredentialResult{
+		Key:      item.data.DataKey,
+		Username: item.value.Login,
+		Password: item.value.Password,
+	}, nil
+}
+
+func (c *CredentialCommand) store(ctx context.Context, credential *Credential, masterPassword []byte) (Result, error) {
+	if credential.Username == "" || credential.Password == "" {
+		return &CredentialResult{}, nil
+	}
+
+	item, err := c.find(ctx, credential, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil && item.value.Password == credential.Password {
+		return &CredentialResult{Key: item.data.DataKey}, nil
+	}
+
+	data, meta := &model.UserData{}, &metadata.Metadata{}
+	if item != nil {
+		data, meta = item.data, item.meta
+	} else {
+		if data.DataKey, err = c.newKey(ctx, credential); err != nil {
+			return nil, err
+		}
+		meta.AddURL(credential.url())
+	}
+
+	raw, err := (&value.LoginPassword{Login: credential.Username, Password: credential.Password}).ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	if data.DataValue, err = aes.Encrypt(masterPassword, raw); err != nil {
+		return nil, err
+	}
+	if data.DataMeta, err = encryptMetadata(masterPassword, meta); err != nil {
+		return nil, err
+	}
+	data.UpdatedAt = time.Now()
+	data.DeletedAt = time.Unix(0, 0)
+
+	if err := c.dataManager.Upsert(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return &CredentialResult{Key: data.DataKey}, nil
+}
+
+// erase removes the item only if it still has the rejected password, so a rotated token survives
+func (c *CredentialCommand) erase(ctx context.Context, credential *Credential, masterPassword []byte) (Result, error) {
+	item, err := c.find(ctx, credential, masterPassword)
+	if err != nil || item == nil {
+		return &CredentialResult{}, err
+	}
+	if credential.Password != "" && item.value.Password != credential.Password {
+		return &CredentialResult{}, nil
+	}
+
+	now := time.Now()
+	item.data.UpdatedAt = now
+	item.data.DeletedAt = now
+	if err := c.dataManager.Upsert(ctx, item.data); err != nil {
+		return nil, err
+	}
+
+	return &CredentialResult{Key: item.data.DataKey}, nil
+}
+
+// find returns the login_password item with the most specific matching url
+func (c *CredentialCommand) find(ctx context.Context, credential *Credential, masterPassword []byte) (*credentialItem, error) {
+	items, err := c.dataManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *credentialItem
+	for _, data := range items {
+		meta, err := decryptMetadata(masterPassword, data)
+		if err != nil {
+			return nil, err
+		}
+
+		score := -1
+		for _, itemURL := range meta.URLs {
+			score = max(score, credential.match(itemURL))
+		}
+		if score < 0 || (best != nil && score <= best.score) {
+			continue
+		}
+
+		val, err := value.Decrypt(masterPassword, data.DataValue)
+		if err != nil {
+			return nil, err
+		}
+		loginPassword, ok := val.(*value.LoginPassword)
+		if !ok || (credential.Username != "" && loginPassword.Login != credential.Username) {
+			continue
+		}
+
+		best = &credentialItem{data: data, meta: meta, value: loginPassword, score: score}
+	}
+
+	return best, nil
+}
+
+func (c *CredentialCommand) newKey(ctx context.Context, credential *Credential) (string, error) {
+	for _, key := range []string{credential.Host, credential.Username + "@" + credential.Host} {
+		existing, err := c.dataManager.Get(ctx, key)
+		if errors.Is(err, manager.ErrNotFound) {
+			return key, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if existing.DeletedAt.Unix() > 0 {
+			return key, nil
+		}
+	}
+
+	return "", fmt.Errorf("can`t store credential: items %q and %q already exist", credential.Host, credential.Username+"@"+credential.Host)
+}
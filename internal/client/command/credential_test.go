@@ -0,0 +1,175 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCredentialCommand(t *testing.T) (*CredentialCommand, map[string]*model.UserData) {
+	password := []byte("1234567890abcdef")
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "github", []byte("\x01{\"login\":\"octocat\",\"password\":\"ghp_personal\"}"),
+			&metadata.Metadata{URLs: []string{"https://github.com"}}, updatedAt),
+		newEncryptedItem(t, password, "github-work", []byte("\x01{\"login\":\"octocat-work\",\"password\":\"ghp_work\"}"),
+			&metadata.Metadata{URLs: []string{"github.com/acme"}}, updatedAt),
+		newEncryptedItem(t, password, "intranet", []byte("\x01{\"login\":\"admin\",\"password\":\"intranet\"}"),
+			&metadata.Metadata{URLs: []string{"http://intranet.local"}}, updatedAt),
+		newEncryptedItem(t, password, "injected", []byte("\x01{\"login\":\"octocat\",\"password\":\"token\\nhost=evil.com\"}"),
+			&metadata.Metadata{URLs: []string{"https://injected.example"}}, updatedAt),
+		newEncryptedItem(t, password, "gitlab-note", []byte("\x02{\"text\":\"not a credential\"}"),
+			&metadata.Metadata{URLs: []string{"https://gitlab.com"}}, updatedAt),
+	)
+
+	return NewCredentialCommand(storage, staticKey(password)), stored
+}
+
+func decryptLoginPassword(t *testing.T, data *model.UserData) *value.LoginPassword {
+	raw, err := aes.Decrypt([]byte("1234567890abcdef"), data.DataValue)
+	require.NoError(t, err)
+	val, err := value.FromBytes(raw)
+	require.NoError(t, err)
+
+	return val.(*value.LoginPassword)
+}
+
+func TestCredentialCommand_Get(t *testing.T) {
+	cmd, _ := newTestCredentialCommand(t)
+
+	tests := []struct {
+		name  string
+		input string
+		want  *CredentialResult
+	}{
+		{
+			name:  "host",
+			input: "protocol=https\nhost=github.com\n\n",
+			want:  &CredentialResult{Key: "github", Username: "octocat", Password: "ghp_personal"},
+		},
+		{
+			name:  "more specific path",
+			input: "protocol=https\nhost=github.com\npath=acme/api.git\n",
+			want:  &CredentialResult{Key: "github-work", Username: "octocat-work", Password: "ghp_work"},
+		},
+		{
+			name:  "other path",
+			input: "protocol=https\nhost=github.com\npath=acmeinc/api.git\n",
+			want:  &CredentialResult{Key: "github", Username: "octocat", Password: "ghp_personal"},
+		},
+		{
+			name:  "username",
+			input: "url=https://octocat-work@github.com\n",
+			want:  &CredentialResult{Key: "github-work", Username: "octocat-work", Password: "ghp_work"},
+		},
+		{
+			name:  "url without scheme",
+			input: "protocol=https\nhost=github.com\npath=acme\n",
+			want:  &CredentialResult{Key: "github-work", Username: "octocat-work", Password: "ghp_work"},
+		},
+		{
+			name:  "explicit http",
+			input: "protocol=http\nhost=intranet.local\n",
+			want:  &CredentialResult{Key: "intranet", Username: "admin", Password: "intranet"},
+		},
+		{name: "url without scheme is not http", input: "protocol=http\nhost=github.com\n", want: &CredentialResult{}},
+		{name: "url without scheme is not ssh", input: "protocol=ssh\nhost=github.com\n", want: &CredentialResult{}},
+		{name: "other protocol", input: "protocol=http\nhost=gitlab.com\n", want: &CredentialResult{}},
+		{name: "not login_password", input: "protocol=https\nhost=gitlab.com\n", want: &CredentialResult{}},
+		{name: "no host", input: "protocol=https\n", want: &CredentialResult{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd.stdin = strings.NewReader(tt.input)
+			got, err := cmd.Execute(context.Background(), []string{"get"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCredentialCommand_GetText(t *testing.T) {
+	cmd, _ := newTestCredentialCommand(t)
+
+	got, err := cmd.Execute(context.Background(), []string{"get", "protocol=https", "host=github.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "username=octocat\npassword=ghp_personal", got.Text())
+
+	got, err = cmd.Execute(context.Background(), []string{"get", "protocol=https", "host=example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "", got.Text())
+}
+
+func TestCredentialCommand_Store(t *testing.T) {
+	cmd, stored := newTestCredentialCommand(t)
+
+	got, err := cmd.Execute(context.Background(), []string{"store", "protocol=https", "host=github.com", "username=octocat", "password=ghp_rotated"})
+	require.NoError(t, err)
+	assert.Equal(t, &CredentialResult{Key: "github"}, got)
+	assert.Equal(t, "", got.Text())
+	assert.Equal(t, &value.LoginPassword{Login: "octocat", Password: "ghp_rotated"}, decryptLoginPassword(t, stored["github"]))
+
+	_, err = cmd.Execute(context.Background(), []string{"store", "protocol=https", "host=gitlab.com", "path=acme/api.git", "username=bot", "password=glpat"})
+	require.NoError(t, err)
+	assert.Equal(t, &value.LoginPassword{Login: "bot", Password: "glpat"}, decryptLoginPassword(t, stored["gitlab.com"]))
+	meta, err := decryptMetadata([]byte("1234567890abcdef"), stored["gitlab.com"])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://gitlab.com/acme/api.git"}, meta.URLs)
+
+	got, err = cmd.Execute(context.Background(), []string{"store", "protocol=https", "host=gitlab.com", "username=admin", "password=secret"})
+	require.NoError(t, err)
+	assert.Equal(t, &CredentialResult{Key: "admin@gitlab.com"}, got)
+
+	got, err = cmd.Execute(context.Background(), []string{"store", "protocol=https", "host=example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &CredentialResult{}, got)
+}
+
+func TestCredentialCommand_Erase(t *testing.T) {
+	cmd, stored := newTestCredentialCommand(t)
+
+	got, err := cmd.Execute(context.Background(), []string{"erase", "protocol=https", "host=github.com", "username=octocat", "password=ghp_old"})
+	require.NoError(t, err)
+	assert.Equal(t, &CredentialResult{}, got)
+	assert.Equal(t, int64(0), stored["github"].DeletedAt.Unix())
+
+	got, err = cmd.Execute(context.Background(), []string{"erase", "protocol=https", "host=github.com", "username=octocat", "password=ghp_personal"})
+	require.NoError(t, err)
+	assert.Equal(t, &CredentialResult{Key: "github"}, got)
+	assert.Positive(t, stored["github"].DeletedAt.Unix())
+}
+
+func TestCredentialCommand_Errors(t *testing.T) {
+	cmd, _ := newTestCredentialCommand(t)
+
+	_, err := cmd.Execute(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"approve", "host=github.com"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"get", "host"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"get", "protocol=https", "host=injected.example"})
+	assert.EqualError(t, err, "can`t return credential \"injected\": login and password must not contain a newline or NUL")
+
+	locked := NewCredentialCommand(nil, lockedKey{})
+	_, err = locked.Execute(context.Background(), []string{"get", "protocol=https", "host=github.com"})
+	assert.EqualError(t, err, "vault is locked")
+}
+
+func TestReadCredential(t *testing.T) {
+	got, err := ReadCredential(strings.NewReader("protocol=https\r\nhost=github.com\n\nignored=1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"protocol=https", "host=github.com"}, got)
+}
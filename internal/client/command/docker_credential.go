@@ -0,0 +1,305 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	DockerCredentialFolder = "docker"
+
+	dockerStore = "store"
+	dockerGet   = "get"
+	dockerErase = "erase"
+	dockerList  = "list"
+
+	dockerInputLimit = 1 << 20
+)
+
+// ErrCredentialsNotFound has the exact text docker expects from a credential helper
+var ErrCredentialsNotFound = errors.New("credentials not found in native keychain")
+
+// DockerCredential is the message of the docker credential helper protocol
+type DockerCredential struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func (c *DockerCredential) Text() string {
+	raw, _ := json.Marshal(c)
+	return string(raw)
+}
+
+// DockerCredentialList maps server urls to usernames
+type DockerCredentialList map[string]string
+
+func (l DockerCredentialList) Text() string {
+	raw, _ := json.Marshal(l)
+	return string(raw)
+}
+
+type DockerCredentialResult struct {
+	Key string `json:"key"`
+}
+
+// Text is empty, docker ignores the output of store and erase
+func (r *DockerCredentialResult) Text() string {
+	return ""
+}
+
+// DockerCredentialArgs appends the request read from r, list has no request
+func DockerCredentialArgs(args []string, r io.Reader) ([]string, error) {
+	if len(args) != 1 || args[0] == dockerList {
+		return args, nil
+	}
+
+	input, err := io.ReadAll(io.LimitReader(r, dockerInputLimit))
+	if err != nil {
+		return nil, fmt.Errorf("can`t read request: %w", err)
+	}
+
+	return append(args, strings.TrimSpace(string(input))), nil
+}
+
+type DockerCredentialCommand struct {
+	dataManager CredentialStorage
+	keys        KeyProvider
+	stdin       io.Reader
+}
+
+func NewDockerCredentialCommand(dataManager CredentialStorage, keys KeyProvider) *DockerCredentialCommand {
+	return &DockerCredentialCommand{
+		dataManager: dataManager,
+		keys:        keys,
+		stdin:       os.Stdin,
+	}
+}
+
+func (c *DockerCredentialCommand) Spec() Spec {
+	return Spec{
+		Name:     "docker-credential",
+		Synopsis: "docker credential helper, reads the request from stdin",
+		Args: []Arg{
+			{Name: "action", Kind: ArgChoice, Choices: []string{dockerStore, dockerGet, dockerErase, dockerList}},
+			{Name: "request", Optional: true},
+		},
+		Examples: []string{
+			"docker-credential get ghcr.io",
+			"docker-credential list",
+		},
+		Sensitive: true,
+	}
+}
+
+func (c *DockerCredentialCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("%w: <store|get|erase|list> [request]", ErrInvalidArgs)
+	}
+
+	action := args[0]
+	switch action {
+	case dockerStore, dockerGet, dockerErase, dockerList:
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q, expected store, get, erase or list", ErrInvalidArgs, action)
+	}
+
+	args, err := DockerCredentialArgs(args, c.stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	request := ""
+	if len(args) > 1 {
+		request = args[1]
+	}
+	if action != dockerList && request == "" {
+		return nil, fmt.Errorf("%w: empty request", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	switch action {
+	case dockerStore:
+		var credential DockerCredential
+		if err := json.Unmarshal([]byte(request), &credential); err != nil {
+			return nil, fmt.Errorf("%w: invalid request: %w", ErrInvalidArgs, err)
+		}
+		return c.store(ctx, &credential, masterPassword)
+	case dockerGet:
+		return c.get(ctx, request, masterPassword)
+	case dockerErase:
+		return c.erase(ctx, request, masterPassword)
+	default:
+		return c.list(ctx, masterPassword)
+	}
+}
+
+func (c *DockerCredentialCommand) get(ctx context.Context, serverURL string, masterPassword []byte) (Result, error) {
+	item, err := c.find(ctx, serverURL, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrCredentialsNotFound
+	}
+
+	return &DockerCredential{
+		ServerURL: serverURL,
+		Username:  item.value.Login,
+		Secret:    item.value.Password,
+	}, nil
+}
+
+func (c *DockerCredentialCommand) store(ctx context.Context, credential *DockerCredential, masterPassword []byte) (Result, error) {
+	if credential.ServerURL == "" {
+		return nil, fmt.Errorf("%w: ServerURL is required", ErrInvalidArgs)
+	}
+
+	item, err := c.find(ctx, credential.ServerURL, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	data, meta := &model.UserData{}, &metadata.Metadata{}
+	if item != nil {
+		data, meta = item.data, item.meta
+	} else {
+		data.DataKey = DockerCredentialFolder + "/" + normalizeServerURL(credential.ServerURL)
+		existing, err := c.dataManager.Get(ctx, data.DataKey)
+		switch {
+		case err == nil && existing.DeletedAt.Unix() <= 0:
+			return nil, fmt.Errorf("can`t store credential: item %q already exists", data.DataKey)
+		case err != nil && !errors.Is(err, manager.ErrNotFound):
+			return nil, err
+		}
+		meta.SetFolder(DockerCredentialFolder)
+		meta.AddURL(credential.ServerURL)
+	}
+
+	raw, err := (&value.LoginPassword{Login: credential.Username, Password: credential.Secret}).ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	if data.DataValue, err = aes.Encrypt(masterPassword, raw); err != nil {
+		return nil, err
+	}
+	if data.DataMeta, err = encryptMetadata(masterPassword, meta); err != nil {
+		return nil, err
+	}
+	data.UpdatedAt = time.Now()
+	data.DeletedAt = time.Unix(0, 0)
+
+	if err := c.dataManager.Upsert(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return &DockerCredentialResult{Key: data.DataKey}, nil
+}
+
+func (c *DockerCredentialCommand) erase(ctx context.Context, serverURL string, masterPassword []byte) (Result, error) {
+	item, err := c.find(ctx, serverURL, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrCredentialsNotFound
+	}
+
+	now := time.Now()
+	item.data.UpdatedAt = now
+	item.data.DeletedAt = now
+	if err := c.dataManager.Upsert(ctx, item.data); err != nil {
+		return nil, err
+	}
+
+	return &DockerCredentialResult{Key: item.data.DataKey}, nil
+}
+
+func (c *DockerCredentialCommand) list(ctx context.Context, masterPassword []byte) (Result, error) {
+	result := DockerCredentialList{}
+	err := c.walk(ctx, masterPassword, func(item *credentialItem) bool {
+		for _, serverURL := range item.meta.URLs {
+			result[serverURL] = item.value.Login
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *DockerCredentialCommand) find(ctx context.Context, serverURL string, masterPassword []byte) (*credentialItem, error) {
+	var found *credentialItem
+	normalized := normalizeServerURL(serverURL)
+	err := c.walk(ctx, masterPassword, func(item *credentialItem) bool {
+		for _, itemURL := range item.meta.URLs {
+			if normalizeServerURL(itemURL) == normalized {
+				found = item
+				return false
+			}
+		}
+		return true
+	})
+
+	return found, err
+}
+
+// walk calls fn for login_password items in the docker folder until it returns false
+func (c *DockerCredentialCommand) walk(ctx context.Context, masterPassword []byte, fn func(item *credentialItem) bool) error {
+	items, err := c.dataManager.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range items {
+		meta, err := decryptMetadata(masterPassword, data)
+		if err != nil {
+			return err
+		}
+		if !meta.InFolder(DockerCredentialFolder) {
+			continue
+		}
+
+		val, err := value.Decrypt(masterPassword, data.DataValue)
+		if err != nil {
+			return err
+		}
+		loginPassword, ok := val.(*value.LoginPassword)
+		if !ok {
+			continue
+		}
+
+		if !fn(&credentialItem{data: data, meta: meta, value: loginPassword}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// docker uses both "ghcr.io" and "https://ghcr.io/" for the same registry
+func normalizeServerURL(serverURL string) string {
+	serverURL = strings.TrimPrefix(serverURL, "https://")
+	serverURL = strings.TrimPrefix(serverURL, "http://")
+
+	return strings.TrimSuffix(serverURL, "/")
+}
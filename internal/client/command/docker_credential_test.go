@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDockerCredentialCommand(t *testing.T) (*DockerCredentialCommand, map[string]*model.UserData) {
+	password := []byte("1234567890abcdef")
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "ghcr", []byte("\x01{\"login\":\"octocat\",\"password\":\"ghp_registry\"}"),
+			&metadata.Metadata{Folder: "docker", URLs: []string{"ghcr.io"}}, updatedAt),
+		newEncryptedItem(t, password, "hub", []byte("\x01{\"login\":\"builder\",\"password\":\"dckr_pat\"}"),
+			&metadata.Metadata{Folder: "docker/public", URLs: []string{"https://index.docker.io/v1/"}}, updatedAt),
+		newEncryptedItem(t, password, "github", []byte("\x01{\"login\":\"octocat\",\"password\":\"ghp_personal\"}"),
+			&metadata.Metadata{URLs: []string{"https://registry.example.com"}}, updatedAt),
+	)
+
+	return NewDockerCredentialCommand(storage, staticKey(password)), stored
+}
+
+func TestDockerCredentialCommand_Get(t *testing.T) {
+	cmd, _ := newTestDockerCredentialCommand(t)
+
+	cmd.stdin = strings.NewReader("https://ghcr.io/\n")
+	got, err := cmd.Execute(context.Background(), []string{"get"})
+	require.NoError(t, err)
+	assert.Equal(t, &DockerCredential{ServerURL: "https://ghcr.io/", Username: "octocat", Secret: "ghp_registry"}, got)
+	assert.Equal(t, `{"ServerURL":"https://ghcr.io/","Username":"octocat","Secret":"ghp_registry"}`, got.Text())
+
+	got, err = cmd.Execute(context.Background(), []string{"get", "index.docker.io/v1"})
+	require.NoError(t, err)
+	assert.Equal(t, &DockerCredential{ServerURL: "index.docker.io/v1", Username: "builder", Secret: "dckr_pat"}, got)
+
+	// items outside of the docker folder are not used
+	_, err = cmd.Execute(context.Background(), []string{"get", "registry.example.com"})
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+	assert.EqualError(t, err, "credentials not found in native keychain")
+}
+
+func TestDockerCredentialCommand_Store(t *testing.T) {
+	cmd, stored := newTestDockerCredentialCommand(t)
+
+	cmd.stdin = strings.NewReader(`{"ServerURL":"https://ghcr.io","Username":"octocat","Secret":"ghp_rotated"}`)
+	got, err := cmd.Execute(context.Background(), []string{"store"})
+	require.NoError(t, err)
+	assert.Equal(t, &DockerCredentialResult{Key: "ghcr"}, got)
+	assert.Equal(t, "", got.Text())
+	assert.Equal(t, &value.LoginPassword{Login: "octocat", Password: "ghp_rotated"}, decryptLoginPassword(t, stored["ghcr"]))
+
+	got, err = cmd.Execute(context.Background(), []string{"store", `{"ServerURL":"registry.example.com","Username":"ci","Secret":"token"}`})
+	require.NoError(t, err)
+	assert.Equal(t, &DockerCredentialResult{Key: "docker/registry.example.com"}, got)
+	assert.Equal(t, &value.LoginPassword{Login: "ci", Password: "token"}, decryptLoginPassword(t, stored["docker/registry.example.com"]))
+	meta, err := decryptMetadata([]byte("1234567890abcdef"), stored["docker/registry.example.com"])
+	require.NoError(t, err)
+	assert.Equal(t, &metadata.Metadata{Folder: "docker", URLs: []string{"registry.example.com"}}, meta)
+
+	_, err = cmd.Execute(context.Background(), []string{"store", `{"Username":"ci"}`})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"store", `not json`})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+}
+
+func TestDockerCredentialCommand_Erase(t *testing.T) {
+	cmd, stored := newTestDockerCredentialCommand(t)
+
+	got, err := cmd.Execute(context.Background(), []string{"erase", "ghcr.io"})
+	require.NoError(t, err)
+	assert.Equal(t, &DockerCredentialResult{Key: "ghcr"}, got)
+	assert.Positive(t, stored["ghcr"].DeletedAt.Unix())
+
+	_, err = cmd.Execute(context.Background(), []string{"erase", "ghcr.io"})
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+}
+
+func TestDockerCredentialCommand_List(t *testing.T) {
+	cmd, _ := newTestDockerCredentialCommand(t)
+	cmd.stdin = strings.NewReader("unused")
+
+	got, err := cmd.Execute(context.Background(), []string{"list"})
+	require.NoError(t, err)
+	assert.Equal(t, DockerCredentialList{"ghcr.io": "octocat", "https://index.docker.io/v1/": "builder"}, got)
+	assert.Equal(t, `{"ghcr.io":"octocat","https://index.docker.io/v1/":"builder"}`, got.Text())
+}
+
+func TestDockerCredentialCommand_Errors(t *testing.T) {
+	cmd, _ := newTestDockerCredentialCommand(t)
+
+	_, err := cmd.Execute(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"version"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	cmd.stdin = strings.NewReader("")
+	_, err = cmd.Execute(context.Background(), []string{"get"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	locked := NewDockerCredentialCommand(nil, lockedKey{})
+	_, err = locked.Execute(context.Background(), []string{"list"})
+	assert.EqualError(t, err, "vault is locked")
+}
@@ -0,0 +1,220 @@
+package command
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/archive"
+	"github.com/m1khal3v/gophkeeper/internal/client/prompt"
+)
+
+const plainExportWarning = "WARNING: plain export writes ALL secrets UNENCRYPTED to %s.\n" +
+	"Anyone who can read this file gets full access to your vault, delete it as soon as possible."
+
+type ExportResult struct {
+	Path    string `json:"path"`
+	Items   int    `json:"items"`
+	Plain   bool   `json:"plain"`
+	Warning string `json:"warning,omitempty"`
+}
+
+func (r *ExportResult) Text() string {
+	text := fmt.Sprintf("exported %d items to %s", r.Items, r.Path)
+	if r.Warning != "" {
+		text = r.Warning + "\n" + text
+	}
+
+	return text
+}
+
+type PasswordPrompter interface {
+	Password(message string) ([]byte, error)
+}
+
+var passwordFDFlag = Flag{Name: "password-fd", Value: "fd", Usage: "read the archive password from a file descriptor instead of asking"}
+
+// readArchivePassword keeps the archive password out of arguments and history,
+// it is read from --password-fd or asked for, twice when confirm is set
+func readArchivePassword(prompter PasswordPrompter, flags *Flags, confirm bool) ([]byte, error) {
+	if fd := flags.String(passwordFDFlag.Name); fd != "" {
+		n, err := strconv.Atoi(fd)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid --password-fd %q", ErrInvalidArgs, fd)
+		}
+
+		return prompt.FromFD(n)
+	}
+	if prompter == nil {
+		return nil, errors.New("archive password requires an interactive terminal or --password-fd")
+	}
+
+	password, err := prompter.Password("Archive password: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, errors.New("archive password is empty")
+	}
+	if !confirm {
+		return password, nil
+	}
+
+	repeated, err := prompter.Password("Repeat archive password: ")
+	if err != nil {
+		clear(password)
+		return nil, err
+	}
+	defer clear(repeated)
+	if subtle.ConstantTimeCompare(password, repeated) != 1 {
+		clear(password)
+		return nil, errors.New("archive passwords don`t match")
+	}
+
+	return password, nil
+}
+
+type ExportCommand struct {
+	dataManager DataLister
+	prompter    PasswordPrompter
+	keys        KeyProvider
+}
+
+func NewExportCommand(dataManager DataLister, prompter PasswordPrompter, keys KeyProvider) *ExportCommand {
+	return &ExportCommand{
+		dataManager: dataManager,
+		prompter:    prompter,
+		keys:        keys,
+	}
+}
+
+func (c *ExportCommand) Spec() Spec {
+	return Spec{
+		Name:     "export",
+		Synopsis: "export the vault to an encrypted archive or a plaintext file",
+		Args:     []Arg{{Name: "path", Kind: ArgFile}},
+		Flags: []Flag{
+			{Name: "plain", Usage: "export unencrypted, asks for the master password again"},
+			{Name: "format", Choices: []string{plainFormatJSON, plainFormatCSV}, Default: plainFormatJSON, Usage: "plain export format"},
+			passwordFDFlag,
+		},
+		Examples: []string{"export backup.gkb", "export --password-fd 3 backup.gkb 3<archive-password.txt", "export --plain --format csv items.csv"},
+	}
+}
+
+func (c *ExportCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	plain := flags.Bool("plain")
+	if plain && flags.String(passwordFDFlag.Name) != "" {
+		return nil, fmt.Errorf("%w: --password-fd is not used with --plain", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	if plain {
+		return c.exportPlain(ctx, flags.Arg(0), flags.String("format"), masterPassword)
+	}
+
+	password, err := readArchivePassword(c.prompter, flags, true)
+	if err != nil {
+		return nil, fmt.Errorf("can`t read archive password: %w", err)
+	}
+	defer clear(password)
+
+	return c.exportArchive(ctx, flags.Arg(0), password, masterPassword)
+}
+
+func (c *ExportCommand) exportArchive(ctx context.Context, path string, password, masterPassword []byte) (Result, error) {
+	items, err := c.decryptItems(ctx, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writePrivateFile(path, func(w io.Writer) error {
+		return archive.Write(w, password, items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Path: path, Items: len(items)}, nil
+}
+
+func (c *ExportCommand) exportPlain(ctx context.Context, path, format string, masterPassword []byte) (Result, error) {
+	write, err := plainWriter(format)
+	if err != nil {
+		return nil, err
+	}
+
+	warning := fmt.Sprintf(plainExportWarning, path)
+	if c.prompter == nil {
+		return nil, errors.New("plain export requires an interactive terminal")
+	}
+	password, err := c.prompter.Password(warning + "\nRe-enter master password to continue: ")
+	if err != nil {
+		return nil, err
+	}
+	defer clear(password)
+	if subtle.ConstantTimeCompare(password, masterPassword) != 1 {
+		return nil, errors.New("invalid master password")
+	}
+
+	items, err := c.decryptItems(ctx, masterPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	plainItems, err := newPlainItems(items)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writePrivateFile(path, func(w io.Writer) error {
+		return write(w, plainItems)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Path: path, Items: len(plainItems), Plain: true, Warning: warning}, nil
+}
+
+func (c *ExportCommand) decryptItems(ctx context.Context, masterPassword []byte) ([]*archive.Item, error) {
+	data, err := c.dataManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*archive.Item, 0, len(data))
+	for _, d := range data {
+		raw, err := aes.Decrypt(masterPassword, d.DataValue)
+		if err != nil {
+			return nil, fmt.Errorf("can`t decrypt %s: %w", d.DataKey, err)
+		}
+
+		meta, err := decryptMetadata(masterPassword, d)
+		if err != nil {
+			return nil, fmt.Errorf("can`t decrypt %s metadata: %w", d.DataKey, err)
+		}
+
+		items = append(items, &archive.Item{
+			Key:       d.DataKey,
+			Value:     raw,
+			Meta:      meta,
+			UpdatedAt: d.UpdatedAt.UTC(),
+		})
+	}
+
+	return items, nil
+}
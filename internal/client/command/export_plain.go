@@ -0,0 +1,117 @@
+package command
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/archive"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	plainFormatJSON = "json"
+	plainFormatCSV  = "csv"
+)
+
+var plainCSVHeader = []string{
+	"key", "type", "login", "password", "text", "data", "number", "holder",
+	"expire_month", "expire_year", "cvc", "folder", "tags", "favorite", "urls", "note", "updated_at",
+}
+
+func newPlainItems(items []*archive.Item) ([]*Item, error) {
+	result := make([]*Item, 0, len(items))
+	for _, item := range items {
+		val, err := value.FromBytes(item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("can`t decode %s: %w", item.Key, err)
+		}
+
+		meta := item.Meta
+		if meta == nil {
+			meta = &metadata.Metadata{}
+		}
+
+		result = append(result, &Item{
+			Key:       item.Key,
+			Type:      value.TypeName(val),
+			Value:     val,
+			Meta:      meta,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func plainWriter(format string) (func(io.Writer, []*Item) error, error) {
+	switch format {
+	case plainFormatJSON:
+		return writePlainJSON, nil
+	case plainFormatCSV:
+		return writePlainCSV, nil
+	default:
+		return nil, fmt.Errorf("unknown plain export format: %s", format)
+	}
+}
+
+func writePlainJSON(w io.Writer, items []*Item) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(items)
+}
+
+func writePlainCSV(w io.Writer, items []*Item) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(plainCSVHeader); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		row := map[string]string{
+			"key":        item.Key,
+			"type":       item.Type,
+			"folder":     item.Meta.Folder,
+			"tags":       strings.Join(item.Meta.Tags, ","),
+			"favorite":   strconv.FormatBool(item.Meta.Favorite),
+			"urls":       strings.Join(item.Meta.URLs, " "),
+			"note":       item.Meta.Note,
+			"updated_at": item.UpdatedAt.Format(time.RFC3339),
+		}
+
+		switch v := item.Value.(type) {
+		case *value.LoginPassword:
+			row["login"] = v.Login
+			row["password"] = v.Password
+		case *value.TextValue:
+			row["text"] = v.Text
+		case *value.BinaryValue:
+			row["data"] = base64.StdEncoding.EncodeToString(v.Data)
+		case *value.CardValue:
+			row["number"] = v.Number
+			row["holder"] = v.Holder
+			row["expire_month"] = strconv.Itoa(v.ExpireMonth)
+			row["expire_year"] = strconv.Itoa(v.ExpireYear)
+			row["cvc"] = v.CVC
+		}
+
+		record := make([]string, len(plainCSVHeader))
+		for i, column := range plainCSVHeader {
+			record[i] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
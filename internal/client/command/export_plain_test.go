@@ -0,0 +1,141 @@
+package command
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPrompter struct {
+	password []byte
+	err      error
+	message  string
+}
+
+func (m *mockPrompter) Password(message string) ([]byte, error) {
+	m.message = message
+	return m.password, m.err
+}
+
+func newPlainExportStorage(t *testing.T, password []byte) *mockDataStorage {
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	storage, _ := newMemoryStorage(
+		newEncryptedItem(t, password, "binary", []byte{3, 0, 1, 2}, nil, updatedAt),
+		newEncryptedItem(t, password, "card", []byte("\x04"+`{"number":"4111111111111111","holder":"JOHN DOE","expire_month":5,"expire_year":2027,"cvc":"123"}`), nil, updatedAt),
+		newEncryptedItem(t, password, "site", []byte("\x01{\"login\":\"user\",\"password\":\"pass\"}"), &metadata.Metadata{
+			Tags:     []string{"a", "b"},
+			Folder:   "work",
+			Favorite: true,
+			URLs:     []string{"https://example.com"},
+			Note:     "note",
+		}, updatedAt),
+	)
+
+	return storage
+}
+
+func TestExportCommand_Execute_PlainJSON(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage := newPlainExportStorage(t, password)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	prompter := &mockPrompter{password: []byte("1234567890abcdef")}
+
+	got, err := NewExportCommand(storage, prompter, staticKey(password)).Execute(context.Background(), []string{"--plain", path})
+	require.NoError(t, err)
+	// the re-entered password is cleared after the check
+	assert.Equal(t, make([]byte, len(password)), prompter.password)
+	assert.Contains(t, got.Text(), "WARNING")
+	assert.Contains(t, got.Text(), "exported 3 items to "+path)
+	assert.Contains(t, prompter.message, "UNENCRYPTED")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(content, &items))
+	require.Len(t, items, 3)
+	assert.Equal(t, "binary", items[0]["key"])
+	assert.Equal(t, "binary", items[0]["type"])
+	assert.Equal(t, map[string]any{"data": "AAEC"}, items[0]["value"])
+	assert.Equal(t, "card", items[1]["type"])
+	assert.Equal(t, "4111111111111111", items[1]["value"].(map[string]any)["number"])
+	assert.Equal(t, "login_password", items[2]["type"])
+	assert.Equal(t, map[string]any{"login": "user", "password": "pass"}, items[2]["value"])
+	assert.Equal(t, "work", items[2]["meta"].(map[string]any)["folder"])
+	assert.Equal(t, "2025-05-01T10:00:00Z", items[2]["updated_at"])
+}
+
+func TestExportCommand_Execute_PlainCSV(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage := newPlainExportStorage(t, password)
+	path := filepath.Join(t.TempDir(), "vault.csv")
+
+	_, err := NewExportCommand(storage, &mockPrompter{password: password}, staticKey(password)).Execute(context.Background(), []string{"--plain", "--format", "csv", path})
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		plainCSVHeader,
+		{"binary", "binary", "", "", "", "AAEC", "", "", "", "", "", "", "", "false", "", "", "2025-05-01T10:00:00Z"},
+		{"card", "card", "", "", "", "", "4111111111111111", "JOHN DOE", "5", "2027", "123", "", "", "false", "", "", "2025-05-01T10:00:00Z"},
+		{"site", "login_password", "user", "pass", "", "", "", "", "", "", "", "work", "a,b", "true", "https://example.com", "note", "2025-05-01T10:00:00Z"},
+	}, records)
+}
+
+func TestExportCommand_Execute_PlainErrors(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage := newPlainExportStorage(t, password)
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	tests := []struct {
+		name     string
+		prompter PasswordPrompter
+		args     []string
+	}{
+		{name: "missing path", prompter: &mockPrompter{password: password}, args: []string{"--plain"}},
+		{name: "unknown format", prompter: &mockPrompter{password: password}, args: []string{"--plain", "--format", "xml", path}},
+		{name: "no terminal", prompter: nil, args: []string{"--plain", path}},
+		{name: "prompt error", prompter: &mockPrompter{err: errors.New("no tty")}, args: []string{"--plain", path}},
+		{name: "wrong password", prompter: &mockPrompter{password: []byte("wrong")}, args: []string{"--plain", path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExportCommand(storage, tt.prompter, staticKey(password)).Execute(context.Background(), tt.args)
+			assert.Error(t, err)
+			assert.Nil(t, got)
+			_, statErr := os.Stat(path)
+			assert.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
+
+func TestExportCommand_Execute_PlainUndecodableValue(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage, _ := newMemoryStorage(newEncryptedItem(t, password, "broken", []byte("\x09garbage"), nil, time.Now()))
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	got, err := NewExportCommand(storage, &mockPrompter{password: password}, staticKey(password)).Execute(context.Background(), []string{"--plain", path})
+	assert.Error(t, err)
+	assert.Nil(t, got)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr))
+}
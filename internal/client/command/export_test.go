@@ -0,0 +1,144 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/archive"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEncryptedItem(t *testing.T, password []byte, key string, raw []byte, meta *metadata.Metadata, updatedAt time.Time) *model.UserData {
+	encValue, err := aes.Encrypt(password, raw)
+	require.NoError(t, err)
+
+	item := &model.UserData{
+		DataKey:   key,
+		DataValue: encValue,
+		UpdatedAt: updatedAt,
+		DeletedAt: time.Unix(0, 0),
+	}
+	if meta != nil {
+		item.DataMeta, err = encryptMetadata(password, meta)
+		require.NoError(t, err)
+	}
+
+	return item
+}
+
+// sequencePrompter answers prompts in order, the last answer repeats
+type sequencePrompter struct {
+	answers [][]byte
+	calls   int
+}
+
+func (p *sequencePrompter) Password(message string) ([]byte, error) {
+	answer := p.answers[min(p.calls, len(p.answers)-1)]
+	p.calls++
+
+	return bytes.Clone(answer), nil
+}
+
+func archivePrompter() *sequencePrompter {
+	return &sequencePrompter{answers: [][]byte{[]byte("archive-pass")}}
+}
+
+func TestExportCommand_Execute(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	storage, _ := newMemoryStorage(
+		newEncryptedItem(t, password, "binary", []byte{3, 0, 1, 2}, nil, updatedAt),
+		newEncryptedItem(t, password, "text", []byte("\x02{\"text\":\"value\"}"), &metadata.Metadata{Tags: []string{"tag"}}, updatedAt),
+	)
+	path := filepath.Join(t.TempDir(), "vault.gkx")
+
+	cmd := NewExportCommand(storage, archivePrompter(), staticKey(password))
+	got, err := cmd.Execute(context.Background(), []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "exported 2 items to "+path, got.Text())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	items, err := archive.Read(file, []byte("archive-pass"))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "binary", items[0].Key)
+	assert.Equal(t, []byte{3, 0, 1, 2}, items[0].Value)
+	assert.Equal(t, &metadata.Metadata{}, items[0].Meta)
+	assert.Equal(t, "text", items[1].Key)
+	assert.Equal(t, []string{"tag"}, items[1].Meta.Tags)
+	assert.Equal(t, updatedAt, items[1].UpdatedAt)
+}
+
+func TestExportCommand_Execute_MissingArgs(t *testing.T) {
+	cmd := NewExportCommand(nil, nil, nil)
+	got, err := cmd.Execute(context.Background(), []string{})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExportCommand_Execute_ListError(t *testing.T) {
+	storage := &mockDataStorage{
+		listFunc: func(ctx context.Context) ([]*model.UserData, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	cmd := NewExportCommand(storage, archivePrompter(), staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{filepath.Join(t.TempDir(), "vault.gkx")})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExportCommand_Execute_DecryptError(t *testing.T) {
+	storage, _ := newMemoryStorage(&model.UserData{DataKey: "key", DataValue: []byte("corrupted")})
+
+	cmd := NewExportCommand(storage, archivePrompter(), staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{filepath.Join(t.TempDir(), "vault.gkx")})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExportCommand_Execute_ArchivePassword(t *testing.T) {
+	storage, _ := newMemoryStorage()
+
+	tests := []struct {
+		name     string
+		prompter PasswordPrompter
+		args     []string
+		err      string
+	}{
+		{name: "no terminal", prompter: nil, args: []string{"vault.gkx"}, err: "can`t read archive password: archive password requires an interactive terminal or --password-fd"},
+		{name: "empty", prompter: &sequencePrompter{answers: [][]byte{{}}}, args: []string{"vault.gkx"}, err: "can`t read archive password: archive password is empty"},
+		{name: "mismatch", prompter: &sequencePrompter{answers: [][]byte{[]byte("one"), []byte("two")}}, args: []string{"vault.gkx"}, err: "can`t read archive password: archive passwords don`t match"},
+		{name: "positional password", prompter: archivePrompter(), args: []string{"vault.gkx", "archive-pass"}, err: `args: unexpected argument "archive-pass"`},
+		{name: "password fd with plain", prompter: archivePrompter(), args: []string{"--plain", "--password-fd", "3", "items.json"}, err: "args: --password-fd is not used with --plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// every case fails before the file is written
+			got, err := NewExportCommand(storage, tt.prompter, staticKey("1234567890abcdef")).Execute(context.Background(), tt.args)
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, got)
+		})
+	}
+}
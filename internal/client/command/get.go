@@ -3,8 +3,10 @@ package command
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
-	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/client/value"
 )
@@ -13,37 +15,130 @@ type UserDataGetter interface {
 	Get(ctx context.Context, key string) (*model.UserData, error)
 }
 
+type Clipboard interface {
+	Copy(text string) error
+	ClearAfter() time.Duration
+}
+
 type GetCommand struct {
-	dataManager    UserDataGetter
-	masterPassword []byte
+	dataManager UserDataGetter
+	keys        KeyProvider
+	clipboard   Clipboard
 }
 
-func NewGetCommand(dataManager UserDataGetter, masterPassword []byte) *GetCommand {
+func NewGetCommand(dataManager UserDataGetter, keys KeyProvider, clipboard Clipboard) *GetCommand {
 	return &GetCommand{
-		dataManager:    dataManager,
-		masterPassword: masterPassword,
+		dataManager: dataManager,
+		keys:        keys,
+		clipboard:   clipboard,
 	}
 }
 
-func (c *GetCommand) Execute(ctx context.Context, args []string) (string, error) {
-	if len(args) < 1 {
-		return "", errors.New("args: <key>")
+type CopyResult struct {
+	Key        string `json:"key"`
+	Field      string `json:"field"`
+	ClearAfter string `json:"clear_after,omitempty"`
+}
+
+func (r *CopyResult) Text() string {
+	text := fmt.Sprintf("%s of %s copied to clipboard", r.Field, r.Key)
+	if r.ClearAfter != "" {
+		text += ", it will be cleared in " + r.ClearAfter
 	}
 
-	data, err := c.dataManager.Get(ctx, args[0])
+	return text
+}
+
+func (c *GetCommand) Spec() Spec {
+	return Spec{
+		Name:     "get",
+		Synopsis: "show a decrypted item",
+		Args: []Arg{
+			{Name: "key", Kind: ArgKey},
+			{Name: "field", Kind: ArgChoice, Choices: value.FieldNames(), Optional: true},
+		},
+		Flags: []Flag{
+			{Name: "copy", Usage: "copy a field to the clipboard instead of printing, password, card number or text by default"},
+			{Name: "mask", Usage: "hide passwords, card number and CVC"},
+		},
+		Examples: []string{"get github", "get github --copy", "get github --copy login", "get --mask visa"},
+	}
+}
+
+func (c *GetCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	copyField, mask := flags.Bool("copy"), flags.Bool("mask")
+	if flags.NArg() == 2 && !copyField {
+		return nil, fmt.Errorf("%w: field is only used with --copy", ErrInvalidArgs)
+	}
+	if copyField && c.clipboard == nil {
+		return nil, errors.New("clipboard requires an interactive terminal")
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
 
-	raw, err := aes.Decrypt(c.masterPassword, data.DataValue)
+	data, err := manager.GetLive(ctx, c.dataManager, flags.Arg(0))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	val, err := value.FromBytes(raw)
+	val, err := value.Decrypt(masterPassword, data.DataValue)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if copyField {
+		return c.copy(data.DataKey, val, flags.Args()[1:])
+	}
+	if mask {
+		val = value.Masked(val)
+	}
+
+	meta, err := decryptMetadata(masterPassword, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Item{
+		Key:       data.DataKey,
+		Type:      value.TypeName(val),
+		Value:     val,
+		Meta:      meta,
+		UpdatedAt: data.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (c *GetCommand) copy(key string, val value.Value, args []string) (Result, error) {
+	field := value.DefaultField(val)
+	if len(args) > 0 {
+		field = args[0]
+	}
+
+	text, err := value.Field(val, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return CopyField(c.clipboard, key, field, text)
+}
+
+// CopyField copies a resolved field, the agent client copies fields resolved by the agent with it
+func CopyField(clipboard Clipboard, key, field, text string) (Result, error) {
+	if err := clipboard.Copy(text); err != nil {
+		return nil, err
+	}
+
+	result := &CopyResult{Key: key, Field: field}
+	if timeout := clipboard.ClearAfter(); timeout > 0 {
+		result.ClearAfter = timeout.String()
 	}
 
-	return val.String(), nil
+	return result, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/client/value"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,34 @@ func (m *mockDataManager) Get(ctx context.Context, key string) (*model.UserData,
 	return m.getFunc(ctx, key)
 }
 
+type mockClipboard struct {
+	text string
+	err  error
+}
+
+func (m *mockClipboard) Copy(text string) error {
+	m.text = text
+	return m.err
+}
+
+func (m *mockClipboard) ClearAfter() time.Duration {
+	return 30 * time.Second
+}
+
+func newLoginPasswordGetter(t *testing.T, password []byte) *mockDataManager {
+	cipherBytes, err := aes.Encrypt(password, []byte("\x01{\"login\":\"user\",\"password\":\"secret\"}"))
+	assert.NoError(t, err)
+
+	return &mockDataManager{
+		getFunc: func(ctx context.Context, key string) (*model.UserData, error) {
+			return &model.UserData{
+				DataKey:   key,
+				DataValue: cipherBytes,
+			}, nil
+		},
+	}
+}
+
 func TestGetCommand_Execute_Success(t *testing.T) {
 	password := []byte("1234567890abcdef")
 	want := "some-value"
@@ -39,17 +69,30 @@ func TestGetCommand_Execute_Success(t *testing.T) {
 		},
 	}
 
-	cmd := NewGetCommand(dataManager, password)
+	cmd := NewGetCommand(dataManager, staticKey(password), nil)
 	got, err := cmd.Execute(context.Background(), []string{"some-key"})
 	assert.NoError(t, err)
-	assert.Equal(t, want, got)
+	assert.Equal(t, want, got.Text())
+}
+
+func TestGetCommand_Execute_Deleted(t *testing.T) {
+	dataManager := &mockDataManager{
+		getFunc: func(ctx context.Context, key string) (*model.UserData, error) {
+			return &model.UserData{DataKey: key, DeletedAt: time.Now()}, nil
+		},
+	}
+
+	cmd := NewGetCommand(dataManager, staticKey([]byte("1234567890abcdef")), nil)
+	got, err := cmd.Execute(context.Background(), []string{"erased"})
+	assert.ErrorIs(t, err, manager.ErrNotFound)
+	assert.Nil(t, got)
 }
 
 func TestGetCommand_Execute_MissingArgs(t *testing.T) {
-	cmd := NewGetCommand(nil, nil)
+	cmd := NewGetCommand(nil, nil, nil)
 	got, err := cmd.Execute(context.Background(), []string{})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
 
 func TestGetCommand_Execute_GetError(t *testing.T) {
@@ -58,10 +101,17 @@ func TestGetCommand_Execute_GetError(t *testing.T) {
 			return nil, errors.New("not found")
 		},
 	}
-	cmd := NewGetCommand(dataManager, []byte("1234567890abcdef"))
+	cmd := NewGetCommand(dataManager, staticKey("1234567890abcdef"), nil)
 	got, err := cmd.Execute(context.Background(), []string{"some-key"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
+}
+
+func TestGetCommand_Execute_Locked(t *testing.T) {
+	cmd := NewGetCommand(nil, lockedKey{}, nil)
+	got, err := cmd.Execute(context.Background(), []string{"some-key"})
+	assert.EqualError(t, err, "vault is locked")
+	assert.Nil(t, got)
 }
 
 func TestGetCommand_Execute_DecryptError(t *testing.T) {
@@ -73,8 +123,53 @@ func TestGetCommand_Execute_DecryptError(t *testing.T) {
 			}, nil
 		},
 	}
-	cmd := NewGetCommand(dataManager, []byte("1234567890abcdef"))
+	cmd := NewGetCommand(dataManager, staticKey("1234567890abcdef"), nil)
 	got, err := cmd.Execute(context.Background(), []string{"test"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
+}
+
+func TestGetCommand_Execute_Mask(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	cmd := NewGetCommand(newLoginPasswordGetter(t, password), staticKey(password), nil)
+
+	got, err := cmd.Execute(context.Background(), []string{"github", "--mask"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Login: user, Password: ********", got.Text())
+}
+
+func TestGetCommand_Execute_Copy(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	clipboard := &mockClipboard{}
+	cmd := NewGetCommand(newLoginPasswordGetter(t, password), staticKey(password), clipboard)
+
+	got, err := cmd.Execute(context.Background(), []string{"github", "--copy"})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyResult{Key: "github", Field: "password", ClearAfter: "30s"}, got)
+	assert.Equal(t, "password of github copied to clipboard, it will be cleared in 30s", got.Text())
+	assert.Equal(t, "secret", clipboard.text)
+
+	_, err = cmd.Execute(context.Background(), []string{"--copy", "github", "login"})
+	assert.NoError(t, err)
+	assert.Equal(t, "user", clipboard.text)
+
+	_, err = cmd.Execute(context.Background(), []string{"github", "--copy", "cvc"})
+	assert.ErrorIs(t, err, value.ErrUnknownField)
+
+	clipboard.err = errors.New("clipboard requires a terminal")
+	_, err = cmd.Execute(context.Background(), []string{"github", "--copy"})
+	assert.EqualError(t, err, "clipboard requires a terminal")
+}
+
+func TestGetCommand_Execute_CopyArgs(t *testing.T) {
+	cmd := NewGetCommand(nil, nil, nil)
+
+	_, err := cmd.Execute(context.Background(), []string{"github", "login"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"github", "--reveal"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"github", "--copy"})
+	assert.EqualError(t, err, "clipboard requires an interactive terminal")
 }
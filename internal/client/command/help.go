@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+type SpecProvider interface {
+	Specs() []Spec
+	Spec(name string) (Spec, bool)
+}
+
+type CommandSummary struct {
+	Name     string `json:"name"`
+	Synopsis string `json:"synopsis"`
+}
+
+type HelpResult []*CommandSummary
+
+func (r HelpResult) Text() string {
+	var builder strings.Builder
+	builder.WriteString("commands:\n")
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	for _, command := range r {
+		_, _ = io.WriteString(writer, "  "+command.Name+"\t"+command.Synopsis+"\n")
+	}
+	_ = writer.Flush()
+	builder.WriteString("\nrun \"help <command>\" for details")
+
+	return builder.String()
+}
+
+type FlagHelp struct {
+	Flag  string `json:"flag"`
+	Usage string `json:"usage"`
+}
+
+type CommandHelp struct {
+	Name     string      `json:"name"`
+	Synopsis string      `json:"synopsis"`
+	Usage    string      `json:"usage"`
+	Flags    []*FlagHelp `json:"flags"`
+	Examples []string    `json:"examples"`
+}
+
+func (h *CommandHelp) Text() string {
+	var builder strings.Builder
+	builder.WriteString("usage: " + h.Usage + "\n\n" + h.Synopsis + "\n")
+
+	if len(h.Flags) > 0 {
+		builder.WriteString("\nflags:\n")
+		writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+		for _, flag := range h.Flags {
+			_, _ = io.WriteString(writer, "  "+flag.Flag+"\t"+flag.Usage+"\n")
+		}
+		_ = writer.Flush()
+	}
+
+	if len(h.Examples) > 0 {
+		builder.WriteString("\nexamples:\n")
+		for _, example := range h.Examples {
+			builder.WriteString("  " + example + "\n")
+		}
+	}
+
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+type HelpCommand struct {
+	specs SpecProvider
+}
+
+func NewHelpCommand(specs SpecProvider) *HelpCommand {
+	return &HelpCommand{specs: specs}
+}
+
+func (c *HelpCommand) Spec() Spec {
+	return Spec{
+		Name:     "help",
+		Synopsis: "show available commands or command usage",
+		Args:     []Arg{{Name: "command", Kind: ArgCommand, Optional: true}},
+		Examples: []string{"help", "help set"},
+	}
+}
+
+func (c *HelpCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if len(args) == 0 {
+		specs := c.specs.Specs()
+		result := make(HelpResult, 0, len(specs))
+		for _, spec := range specs {
+			result = append(result, &CommandSummary{Name: spec.Name, Synopsis: spec.Synopsis})
+		}
+
+		return result, nil
+	}
+
+	spec, ok := c.specs.Spec(args[0])
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, args[0])
+	}
+
+	help := &CommandHelp{
+		Name:     spec.Name,
+		Synopsis: spec.Synopsis,
+		Usage:    spec.Usage(),
+		Flags:    make([]*FlagHelp, 0, len(spec.Flags)),
+		Examples: spec.Examples,
+	}
+	for _, flag := range spec.Flags {
+		help.Flags = append(help.Flags, &FlagHelp{Flag: flag.String(), Usage: flag.Usage})
+	}
+	if help.Examples == nil {
+		help.Examples = []string{}
+	}
+
+	return help, nil
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSpecProvider []Spec
+
+func (m mockSpecProvider) Specs() []Spec {
+	return m
+}
+
+func (m mockSpecProvider) Spec(name string) (Spec, bool) {
+	for _, spec := range m {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return Spec{}, false
+}
+
+func TestHelpCommand_Execute(t *testing.T) {
+	specs := mockSpecProvider{
+		NewGetCommand(nil, nil, nil).Spec(),
+		NewListCommand(nil, nil).Spec(),
+		{Name: "noop", Synopsis: "do nothing"},
+	}
+	cmd := NewHelpCommand(specs)
+
+	t.Run("commands", func(t *testing.T) {
+		got, err := cmd.Execute(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "commands:\n"+
+			"  get   show a decrypted item\n"+
+			"  list  list items\n"+
+			"  noop  do nothing\n"+
+			"\nrun \"help <command>\" for details", got.Text())
+
+		data, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"name": "get", "synopsis": "show a decrypted item"},
+			{"name": "list", "synopsis": "list items"},
+			{"name": "noop", "synopsis": "do nothing"}
+		]`, string(data))
+	})
+
+	t.Run("command", func(t *testing.T) {
+		got, err := cmd.Execute(context.Background(), []string{"list"})
+		require.NoError(t, err)
+		assert.Equal(t, "usage: list [--tag <tag>] [--folder <path>] [--favorite]\n\n"+
+			"list items\n\n"+
+			"flags:\n"+
+			"  --tag <tag>      show only items with tag\n"+
+			"  --folder <path>  show only items in folder and its subfolders\n"+
+			"  --favorite       show only favorite items\n\n"+
+			"examples:\n"+
+			"  list --tag work\n"+
+			"  list --folder work/servers\n"+
+			"  list --favorite", got.Text())
+	})
+
+	t.Run("command without flags and examples", func(t *testing.T) {
+		got, err := cmd.Execute(context.Background(), []string{"noop"})
+		require.NoError(t, err)
+		assert.Equal(t, "usage: noop\n\ndo nothing", got.Text())
+
+		data, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name": "noop", "synopsis": "do nothing", "usage": "noop", "flags": [], "examples": []}`, string(data))
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		got, err := cmd.Execute(context.Background(), []string{"put"})
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+		assert.EqualError(t, err, "unknown command: put")
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/archive"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+)
+
+const (
+	importModeMerge   = "merge"
+	importModeReplace = "replace"
+)
+
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+	Deleted  int `json:"deleted"`
+}
+
+func (r *ImportResult) Text() string {
+	return fmt.Sprintf("imported %d, skipped %d, deleted %d", r.Imported, r.Skipped, r.Deleted)
+}
+
+type DataImporter interface {
+	List(ctx context.Context) ([]*model.UserData, error)
+	UpsertAll(ctx context.Context, items []*model.UserData) error
+}
+
+type ImportCommand struct {
+	dataManager DataImporter
+	prompter    PasswordPrompter
+	keys        KeyProvider
+}
+
+func NewImportCommand(dataManager DataImporter, prompter PasswordPrompter, keys KeyProvider) *ImportCommand {
+	return &ImportCommand{
+		dataManager: dataManager,
+		prompter:    prompter,
+		keys:        keys,
+	}
+}
+
+func (c *ImportCommand) Spec() Spec {
+	return Spec{
+		Name:     "import",
+		Synopsis: "import an encrypted archive",
+		Args:     []Arg{{Name: "path", Kind: ArgFile}},
+		Flags: []Flag{
+			{Name: "mode", Choices: []string{importModeMerge, importModeReplace}, Default: importModeMerge, Usage: "merge keeps newer local items, replace mirrors the archive"},
+			passwordFDFlag,
+		},
+		Examples: []string{"import backup.gkb", "import --mode replace --password-fd 3 backup.gkb 3<archive-password.txt"},
+	}
+}
+
+func (c *ImportCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	mode := flags.String("mode")
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	password, err := readArchivePassword(c.prompter, flags, false)
+	if err != nil {
+		return nil, fmt.Errorf("can`t read archive password: %w", err)
+	}
+	defer clear(password)
+
+	items, err := archive.Read(file, password)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.dataManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]*model.UserData, len(existing))
+	for _, d := range existing {
+		local[d.DataKey] = d
+	}
+
+	// every item is checked and encrypted before the first write, a broken archive imports nothing
+	now := time.Now()
+	skipped, deleted := 0, 0
+	writes := make([]*model.UserData, 0, len(items))
+	archived := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		archived[item.Key] = struct{}{}
+
+		if d, ok := local[item.Key]; ok && mode == importModeMerge && d.UpdatedAt.After(item.UpdatedAt) {
+			skipped++
+			continue
+		}
+
+		data, err := c.encryptItem(item, masterPassword)
+		if err != nil {
+			return nil, err
+		}
+		data.UpdatedAt = now
+		data.DeletedAt = time.Unix(0, 0)
+		writes = append(writes, data)
+	}
+	imported := len(writes)
+
+	if mode == importModeReplace {
+		for key, d := range local {
+			if _, ok := archived[key]; ok {
+				continue
+			}
+
+			d.UpdatedAt = now
+			d.DeletedAt = now
+			writes = append(writes, d)
+			deleted++
+		}
+	}
+
+	if err := c.dataManager.UpsertAll(ctx, writes); err != nil {
+		return nil, err
+	}
+
+	return &ImportResult{Imported: imported, Skipped: skipped, Deleted: deleted}, nil
+}
+
+func (c *ImportCommand) encryptItem(item *archive.Item, masterPassword []byte) (*model.UserData, error) {
+	if item.Key == "" || len(item.Value) < 2 {
+		return nil, fmt.Errorf("invalid archive item: %q", item.Key)
+	}
+
+	encValue, err := aes.Encrypt(masterPassword, item.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &model.UserData{
+		DataKey:   item.Key,
+		DataValue: encValue,
+	}
+	if item.Meta != nil {
+		data.DataMeta, err = encryptMetadata(masterPassword, item.Meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
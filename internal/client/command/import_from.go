@@ -0,0 +1,179 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/importer"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+type ImportFromEntry struct {
+	Key    string `json:"key"`
+	Type   string `json:"type"`
+	Folder string `json:"folder"`
+}
+
+type ImportFromResult struct {
+	DryRun   bool               `json:"dry_run"`
+	Entries  []*ImportFromEntry `json:"entries"`
+	Imported int                `json:"imported"`
+	Skipped  []importer.Skipped `json:"skipped"`
+}
+
+func (r *ImportFromResult) Text() string {
+	lines := make([]string, 0, len(r.Entries)+len(r.Skipped)+1)
+	if r.DryRun {
+		for _, entry := range r.Entries {
+			line := fmt.Sprintf("%s (%s)", entry.Key, entry.Type)
+			if entry.Folder != "" {
+				line += " [" + entry.Folder + "]"
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, fmt.Sprintf("would import %d, skip %d", r.Imported, len(r.Skipped)))
+	} else {
+		lines = append(lines, fmt.Sprintf("imported %d, skipped %d", r.Imported, len(r.Skipped)))
+	}
+	for _, s := range r.Skipped {
+		lines = append(lines, fmt.Sprintf("skipped %q: %s", s.Name, s.Reason))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type ImportFromCommand struct {
+	dataManager DataImporter
+	keys        KeyProvider
+}
+
+func NewImportFromCommand(dataManager DataImporter, keys KeyProvider) *ImportFromCommand {
+	return &ImportFromCommand{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *ImportFromCommand) Spec() Spec {
+	return Spec{
+		Name:     "import-from",
+		Synopsis: "import items from another password manager",
+		Args: []Arg{
+			{Name: "format", Kind: ArgChoice, Choices: []string{"keepass", "bitwarden", "1password", "csv"}},
+			{Name: "path", Kind: ArgFile},
+		},
+		Flags: []Flag{
+			{Name: "dry-run", Usage: "show what would be imported"},
+			{Name: "map", Value: "field=Column,...", Usage: "csv column mapping"},
+		},
+		Examples: []string{"import-from keepass export.xml", "import-from --map key=Account,password=Secret csv items.csv"},
+	}
+}
+
+func (c *ImportFromCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	dryRun, mapping := flags.Bool("dry-run"), flags.String("map")
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	reader, err := importer.NewReader(flags.Arg(0), mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(flags.Arg(1))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result, err := reader.Read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.dataManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]struct{}, len(existing))
+	for _, d := range existing {
+		local[d.DataKey] = struct{}{}
+	}
+
+	importResult := &ImportFromResult{
+		DryRun:  dryRun,
+		Entries: []*ImportFromEntry{},
+		Skipped: append([]importer.Skipped{}, result.Skipped...),
+	}
+	// every entry is converted and encrypted before the first write, a failure imports nothing
+	now := time.Now()
+	writes := make([]*model.UserData, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if _, ok := local[entry.Key]; ok {
+			importResult.Skipped = append(importResult.Skipped, importer.Skipped{Name: entry.Key, Reason: "key already exists"})
+			continue
+		}
+
+		importResult.Entries = append(importResult.Entries, &ImportFromEntry{
+			Key:    entry.Key,
+			Type:   value.TypeName(entry.Value),
+			Folder: entry.Meta.Folder,
+		})
+		if dryRun {
+			continue
+		}
+
+		data, err := c.encryptEntry(entry, masterPassword)
+		if err != nil {
+			return nil, err
+		}
+		data.UpdatedAt = now
+		data.DeletedAt = time.Unix(0, 0)
+		writes = append(writes, data)
+	}
+
+	if !dryRun {
+		if err := c.dataManager.UpsertAll(ctx, writes); err != nil {
+			return nil, err
+		}
+	}
+	importResult.Imported = len(importResult.Entries)
+
+	return importResult, nil
+}
+
+func (c *ImportFromCommand) encryptEntry(entry *importer.Entry, masterPassword []byte) (*model.UserData, error) {
+	raw, err := entry.Value.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	encValue, err := aes.Encrypt(masterPassword, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	encMeta, err := encryptMetadata(masterPassword, entry.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserData{
+		DataKey:   entry.Key,
+		DataValue: encValue,
+		DataMeta:  encMeta,
+	}, nil
+}
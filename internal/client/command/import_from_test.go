@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importFromTestCSV = "Name,Username,Password,URL,Group,Tags\n" +
+	"mail,john,pass,https://mail.example.com,Home,personal\n" +
+	"existing,user,pass,,,\n" +
+	"broken,,,,,\n"
+
+func writeImportFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "export.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestImportFromCommand_Execute(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := writeImportFile(t, importFromTestCSV)
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "existing", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+	)
+
+	got, err := NewImportFromCommand(storage, staticKey(password)).Execute(context.Background(), []string{"csv", path})
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1, skipped 2\n"+
+		"skipped \"broken\": entry has no importable data\n"+
+		"skipped \"existing\": key already exists", got.Text())
+
+	raw, err := aes.Decrypt(password, stored["mail"].DataValue)
+	require.NoError(t, err)
+	val, err := value.FromBytes(raw)
+	require.NoError(t, err)
+	assert.Equal(t, &value.LoginPassword{Login: "john", Password: "pass"}, val)
+	assert.Equal(t, int64(0), stored["mail"].DeletedAt.Unix())
+
+	meta, err := decryptMetadata(password, stored["mail"])
+	require.NoError(t, err)
+	assert.Equal(t, "Home", meta.Folder)
+	assert.Equal(t, []string{"personal"}, meta.Tags)
+	assert.Equal(t, []string{"https://mail.example.com"}, meta.URLs)
+
+	assertItemText(t, password, stored["existing"], "local")
+}
+
+// batchImporter records batches, import-from must convert all entries before writing them at once
+type batchImporter struct {
+	batches [][]*model.UserData
+	err     error
+}
+
+func (b *batchImporter) List(ctx context.Context) ([]*model.UserData, error) {
+	return nil, nil
+}
+
+func (b *batchImporter) UpsertAll(ctx context.Context, items []*model.UserData) error {
+	b.batches = append(b.batches, items)
+	return b.err
+}
+
+func TestImportFromCommand_Execute_Batch(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := writeImportFile(t, importFromTestCSV)
+
+	storage := &batchImporter{}
+	got, err := NewImportFromCommand(storage, staticKey(password)).Execute(context.Background(), []string{"csv", path})
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.(*ImportFromResult).Imported)
+	require.Len(t, storage.batches, 1)
+	assert.Len(t, storage.batches[0], 2)
+
+	storage = &batchImporter{err: errors.New("disk full")}
+	_, err = NewImportFromCommand(storage, staticKey(password)).Execute(context.Background(), []string{"csv", path})
+	assert.EqualError(t, err, "disk full")
+}
+
+func TestImportFromCommand_Execute_DryRun(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := writeImportFile(t, importFromTestCSV)
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "existing", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+	)
+
+	got, err := NewImportFromCommand(storage, staticKey(password)).Execute(context.Background(), []string{"--dry-run", "csv", path})
+	require.NoError(t, err)
+	assert.Equal(t, "mail (login_password) [Home]\n"+
+		"would import 1, skip 2\n"+
+		"skipped \"broken\": entry has no importable data\n"+
+		"skipped \"existing\": key already exists", got.Text())
+	assert.Len(t, stored, 1)
+}
+
+func TestImportFromCommand_Execute_Mapping(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := writeImportFile(t, "Account,Secret text\nwifi,12345678\n")
+	storage, stored := newMemoryStorage()
+
+	got, err := NewImportFromCommand(storage, staticKey(password)).Execute(context.Background(), []string{"--map", "key=Account,text=Secret text", "csv", path})
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1, skipped 0", got.Text())
+	assertItemText(t, password, stored["wifi"], "12345678")
+}
+
+func TestImportFromCommand_Execute_Errors(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := writeImportFile(t, importFromTestCSV)
+	storage, _ := newMemoryStorage()
+	cmd := NewImportFromCommand(storage, staticKey(password))
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing args", args: []string{"csv"}},
+		{name: "unknown flag", args: []string{"--force", "csv", path}},
+		{name: "unknown format", args: []string{"lastpass", path}},
+		{name: "mapping for non csv", args: []string{"--map", "key=Name", "bitwarden", path}},
+		{name: "missing file", args: []string{"csv", filepath.Join(t.TempDir(), "missing")}},
+		{name: "invalid content", args: []string{"bitwarden", path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.Execute(context.Background(), tt.args)
+			assert.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/archive"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestArchive(t *testing.T, password []byte, items ...*model.UserData) string {
+	storage, _ := newMemoryStorage(items...)
+	path := filepath.Join(t.TempDir(), "vault.gkx")
+
+	_, err := NewExportCommand(storage, archivePrompter(), staticKey(password)).Execute(context.Background(), []string{path})
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestImportCommand_Execute_RoundTrip(t *testing.T) {
+	sourcePassword := []byte("source-master-password")
+	targetPassword := []byte("target-master-password")
+	updatedAt := time.Now().Add(-time.Hour)
+	path := exportTestArchive(t, sourcePassword,
+		newEncryptedItem(t, sourcePassword, "binary", []byte{3, 0, 1, 2}, nil, updatedAt),
+		newEncryptedItem(t, sourcePassword, "text", []byte("\x02{\"text\":\"value\"}"), &metadata.Metadata{Folder: "work"}, updatedAt),
+	)
+
+	storage, stored := newMemoryStorage()
+	cmd := NewImportCommand(storage, archivePrompter(), staticKey(targetPassword))
+	got, err := cmd.Execute(context.Background(), []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "imported 2, skipped 0, deleted 0", got.Text())
+
+	raw, err := aes.Decrypt(targetPassword, stored["binary"].DataValue)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 0, 1, 2}, raw)
+	assert.True(t, stored["binary"].UpdatedAt.After(updatedAt))
+	assert.Equal(t, int64(0), stored["binary"].DeletedAt.Unix())
+
+	meta, err := decryptMetadata(targetPassword, stored["text"])
+	require.NoError(t, err)
+	assert.Equal(t, "work", meta.Folder)
+}
+
+func TestImportCommand_Execute_Merge(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := exportTestArchive(t, password,
+		newEncryptedItem(t, password, "older-locally", []byte("\x02{\"text\":\"archive\"}"), nil, time.Now().Add(-time.Hour)),
+		newEncryptedItem(t, password, "newer-locally", []byte("\x02{\"text\":\"archive\"}"), nil, time.Now().Add(-time.Hour)),
+	)
+
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "older-locally", []byte("\x02{\"text\":\"local\"}"), nil, time.Now().Add(-2*time.Hour)),
+		newEncryptedItem(t, password, "newer-locally", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+		newEncryptedItem(t, password, "local-only", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+	)
+
+	got, err := NewImportCommand(storage, archivePrompter(), staticKey(password)).Execute(context.Background(), []string{"--mode", "merge", path})
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1, skipped 1, deleted 0", got.Text())
+
+	assertItemText(t, password, stored["older-locally"], "archive")
+	assertItemText(t, password, stored["newer-locally"], "local")
+	assertItemText(t, password, stored["local-only"], "local")
+}
+
+func TestImportCommand_Execute_Replace(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := exportTestArchive(t, password,
+		newEncryptedItem(t, password, "shared", []byte("\x02{\"text\":\"archive\"}"), nil, time.Now().Add(-time.Hour)),
+	)
+
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "shared", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+		newEncryptedItem(t, password, "local-only", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+	)
+
+	got, err := NewImportCommand(storage, archivePrompter(), staticKey(password)).Execute(context.Background(), []string{"--mode", "replace", path})
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1, skipped 0, deleted 1", got.Text())
+
+	assertItemText(t, password, stored["shared"], "archive")
+	assert.Greater(t, stored["local-only"].DeletedAt.Unix(), int64(0))
+}
+
+func TestImportCommand_Execute_Errors(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := exportTestArchive(t, password)
+	storage, _ := newMemoryStorage()
+
+	tests := []struct {
+		name     string
+		prompter PasswordPrompter
+		args     []string
+	}{
+		{name: "missing args", prompter: archivePrompter(), args: []string{}},
+		{name: "unknown mode", prompter: archivePrompter(), args: []string{"--mode", "overwrite", path}},
+		{name: "unknown flag", prompter: archivePrompter(), args: []string{"--force", path}},
+		{name: "unexpected password argument", prompter: archivePrompter(), args: []string{path, "archive-pass"}},
+		{name: "missing file", prompter: archivePrompter(), args: []string{filepath.Join(t.TempDir(), "missing")}},
+		{name: "wrong password", prompter: &mockPrompter{password: []byte("wrong-pass")}, args: []string{path}},
+		{name: "no terminal", prompter: nil, args: []string{path}},
+		{name: "invalid fd", prompter: nil, args: []string{"--password-fd", "stdin", path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewImportCommand(storage, tt.prompter, staticKey(password)).Execute(context.Background(), tt.args)
+			assert.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestImportCommand_Execute_InvalidItem(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := filepath.Join(t.TempDir(), "vault.gkx")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, archive.Write(file, []byte("archive-pass"), []*archive.Item{
+		{Key: "first", Value: []byte("\x02{\"text\":\"archive\"}"), UpdatedAt: time.Now()},
+		{Key: "broken", Value: []byte{2}, UpdatedAt: time.Now()},
+		{Key: "last", Value: []byte("\x02{\"text\":\"archive\"}"), UpdatedAt: time.Now()},
+	}))
+	require.NoError(t, file.Close())
+
+	storage, stored := newMemoryStorage(
+		newEncryptedItem(t, password, "first", []byte("\x02{\"text\":\"local\"}"), nil, time.Now().Add(-time.Hour)),
+		newEncryptedItem(t, password, "local-only", []byte("\x02{\"text\":\"local\"}"), nil, time.Now()),
+	)
+
+	// nothing is written, neither the items before the broken one nor the tombstones of replace
+	_, err = NewImportCommand(storage, archivePrompter(), staticKey(password)).Execute(context.Background(), []string{"--mode", "replace", path})
+	assert.EqualError(t, err, `invalid archive item: "broken"`)
+	assert.Len(t, stored, 2)
+	assertItemText(t, password, stored["first"], "local")
+	assert.Equal(t, int64(0), stored["local-only"].DeletedAt.Unix())
+}
+
+func TestImportCommand_Execute_PasswordFD(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	path := exportTestArchive(t, password, newEncryptedItem(t, password, "text", []byte("\x02{\"text\":\"value\"}"), nil, time.Now()))
+	storage, _ := newMemoryStorage()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = writer.WriteString("archive-pass\n")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	fd := strconv.Itoa(int(reader.Fd()))
+	got, err := NewImportCommand(storage, nil, staticKey(password)).Execute(context.Background(), []string{"--password-fd", fd, path})
+	require.NoError(t, err)
+	assert.Equal(t, &ImportResult{Imported: 1}, got)
+}
+
+func assertItemText(t *testing.T, password []byte, item *model.UserData, want string) {
+	raw, err := aes.Decrypt(password, item.DataValue)
+	require.NoError(t, err)
+	assert.Equal(t, "\x02{\"text\":\""+want+"\"}", string(raw))
+}
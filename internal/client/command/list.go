@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+)
+
+type DataLister interface {
+	List(ctx context.Context) ([]*model.UserData, error)
+}
+
+type ListItem struct {
+	Key       string    `json:"key"`
+	Folder    string    `json:"folder"`
+	Tags      []string  `json:"tags"`
+	Favorite  bool      `json:"favorite"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListResult []*ListItem
+
+func (r ListResult) Text() string {
+	if len(r) == 0 {
+		return "no items found"
+	}
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	for _, item := range r {
+		row := []string{item.Key, item.Folder, strings.Join(item.Tags, ",")}
+		if item.Favorite {
+			row = append(row, "*")
+		}
+		for len(row) > 1 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		_, _ = io.WriteString(writer, strings.Join(row, "\t")+"\n")
+	}
+	_ = writer.Flush()
+
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+type ListCommand struct {
+	dataManager DataLister
+	keys        KeyProvider
+}
+
+func NewListCommand(dataManager DataLister, keys KeyProvider) *ListCommand {
+	return &ListCommand{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *ListCommand) Spec() Spec {
+	return Spec{
+		Name:     "list",
+		Synopsis: "list items",
+		Flags: []Flag{
+			{Name: "tag", Value: "tag", Usage: "show only items with tag"},
+			{Name: "folder", Value: "path", Usage: "show only items in folder and its subfolders"},
+			{Name: "favorite", Usage: "show only favorite items"},
+		},
+		Examples: []string{"list --tag work", "list --folder work/servers", "list --favorite"},
+	}
+}
+
+func (c *ListCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	tag, folder, favorite := flags.String("tag"), flags.String("folder"), flags.Bool("favorite")
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	items, err := c.dataManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ListResult{}
+	for _, item := range items {
+		meta, err := decryptMetadata(masterPassword, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if tag != "" && !meta.HasTag(tag) {
+			continue
+		}
+		if !meta.InFolder(folder) {
+			continue
+		}
+		if favorite && !meta.Favorite {
+			continue
+		}
+
+		tags := meta.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		result = append(result, &ListItem{
+			Key:       item.DataKey,
+			Folder:    meta.Folder,
+			Tags:      tags,
+			Favorite:  meta.Favorite,
+			UpdatedAt: item.UpdatedAt.UTC(),
+		})
+	}
+
+	return result, nil
+}
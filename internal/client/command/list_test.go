@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDataLister struct {
+	listFunc func(ctx context.Context) ([]*model.UserData, error)
+}
+
+func (m *mockDataLister) List(ctx context.Context) ([]*model.UserData, error) {
+	return m.listFunc(ctx)
+}
+
+func newListItem(t *testing.T, password []byte, key string, meta *metadata.Metadata) *model.UserData {
+	item := &model.UserData{DataKey: key}
+	if meta != nil {
+		encMeta, err := encryptMetadata(password, meta)
+		require.NoError(t, err)
+		item.DataMeta = encMeta
+	}
+
+	return item
+}
+
+func TestListCommand_Execute(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	items := []*model.UserData{
+		newListItem(t, password, "db-prod", &metadata.Metadata{Tags: []string{"db", "prod"}, Folder: "work/servers", Favorite: true}),
+		newListItem(t, password, "db-test", &metadata.Metadata{Tags: []string{"db"}, Folder: "work/servers"}),
+		newListItem(t, password, "mail", &metadata.Metadata{Folder: "home"}),
+		newListItem(t, password, "plain", nil),
+	}
+	dataManager := &mockDataLister{
+		listFunc: func(ctx context.Context) ([]*model.UserData, error) {
+			return items, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "all",
+			args: []string{},
+			want: "db-prod  work/servers  db,prod  *\ndb-test  work/servers  db\nmail     home\nplain",
+		},
+		{
+			name: "by tag",
+			args: []string{"--tag", "prod"},
+			want: "db-prod  work/servers  db,prod  *",
+		},
+		{
+			name: "by folder",
+			args: []string{"--folder", "work"},
+			want: "db-prod  work/servers  db,prod  *\ndb-test  work/servers  db",
+		},
+		{
+			name: "favorite",
+			args: []string{"--favorite"},
+			want: "db-prod  work/servers  db,prod  *",
+		},
+		{
+			name: "nothing found",
+			args: []string{"--tag", "missing"},
+			want: "no items found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewListCommand(dataManager, staticKey(password))
+			got, err := cmd.Execute(context.Background(), tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Text())
+		})
+	}
+}
+
+func TestListCommand_Execute_InvalidFlag(t *testing.T) {
+	cmd := NewListCommand(nil, nil)
+	got, err := cmd.Execute(context.Background(), []string{"--unknown"})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListCommand_Execute_ListError(t *testing.T) {
+	dataManager := &mockDataLister{
+		listFunc: func(ctx context.Context) ([]*model.UserData, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	cmd := NewListCommand(dataManager, staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListCommand_Execute_DecryptError(t *testing.T) {
+	dataManager := &mockDataLister{
+		listFunc: func(ctx context.Context) ([]*model.UserData, error) {
+			return []*model.UserData{{DataKey: "key", DataMeta: []byte("corrupted-meta")}}, nil
+		},
+	}
+
+	cmd := NewListCommand(dataManager, staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{})
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+)
+
+type VaultLocker interface {
+	Lock()
+}
+
+type LockCommand struct {
+	vault VaultLocker
+}
+
+func NewLockCommand(vault VaultLocker) *LockCommand {
+	return &LockCommand{
+		vault: vault,
+	}
+}
+
+func (c *LockCommand) Spec() Spec {
+	return Spec{
+		Name:     "lock",
+		Synopsis: "lock the vault and wipe the master password from memory",
+	}
+}
+
+func (c *LockCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	c.vault.Lock()
+
+	return Message("vault locked"), nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticKey []byte
+
+func (k staticKey) MasterPassword() ([]byte, error) {
+	return bytes.Clone(k), nil
+}
+
+type lockedKey struct{}
+
+func (lockedKey) MasterPassword() ([]byte, error) {
+	return nil, errors.New("vault is locked")
+}
+
+type mockVault struct {
+	locked   bool
+	password []byte
+	err      error
+}
+
+func (m *mockVault) Lock() {
+	m.locked = true
+}
+
+func (m *mockVault) Unlock(ctx context.Context, password []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.locked = false
+	m.password = bytes.Clone(password)
+
+	return nil
+}
+
+func TestLockCommand_Execute(t *testing.T) {
+	vault := &mockVault{}
+
+	got, err := NewLockCommand(vault).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, Message("vault locked"), got)
+	assert.True(t, vault.locked)
+}
@@ -2,34 +2,60 @@ package command
 
 import (
 	"context"
-	"errors"
+	"fmt"
 )
 
 type UserAuthenticator interface {
 	Login(ctx context.Context, login, password string, masterPassword []byte) (string, error)
 }
 
+type AuthTokenSaver interface {
+	SetAuthToken(ctx context.Context, token string, masterPassword []byte) error
+}
+
 type LoginCommand struct {
-	client         UserAuthenticator
-	masterPassword []byte
+	client     UserAuthenticator
+	tokenSaver AuthTokenSaver
+	keys       KeyProvider
 }
 
-func NewLoginCommand(client UserAuthenticator, masterPassword []byte) *LoginCommand {
+func NewLoginCommand(client UserAuthenticator, tokenSaver AuthTokenSaver, keys KeyProvider) *LoginCommand {
 	return &LoginCommand{
-		client:         client,
-		masterPassword: masterPassword,
+		client:     client,
+		tokenSaver: tokenSaver,
+		keys:       keys,
+	}
+}
+
+func (c *LoginCommand) Spec() Spec {
+	return Spec{
+		Name:      "login",
+		Synopsis:  "log in to the sync server",
+		Args:      []Arg{{Name: "login"}, {Name: "password"}},
+		Examples:  []string{"login octocat secret"},
+		Sensitive: true,
 	}
 }
 
-func (c *LoginCommand) Execute(ctx context.Context, args []string) (string, error) {
+func (c *LoginCommand) Execute(ctx context.Context, args []string) (Result, error) {
 	if len(args) < 2 {
-		return "", errors.New("args: <login> <password>")
+		return nil, fmt.Errorf("%w: <login> <password>", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	token, err := c.client.Login(ctx, args[0], args[1], masterPassword)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := c.client.Login(ctx, args[0], args[1], c.masterPassword)
-	if err == nil {
-		return "login successful", nil
+	if err := c.tokenSaver.SetAuthToken(ctx, token, masterPassword); err != nil {
+		return nil, fmt.Errorf("can`t save auth token: %w", err)
 	}
 
-	return "", err
+	return Message("login successful"), nil
 }
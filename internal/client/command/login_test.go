@@ -16,6 +16,16 @@ func (m *mockAuthClient) Login(ctx context.Context, login, password string, mast
 	return m.loginFunc(ctx, login, password, masterPassword)
 }
 
+type mockTokenSaver struct {
+	token string
+	err   error
+}
+
+func (m *mockTokenSaver) SetAuthToken(ctx context.Context, token string, masterPassword []byte) error {
+	m.token = token
+	return m.err
+}
+
 func TestLoginCommand_Execute_Success(t *testing.T) {
 	client := &mockAuthClient{
 		loginFunc: func(ctx context.Context, login, password string, masterPassword []byte) (string, error) {
@@ -23,21 +33,21 @@ func TestLoginCommand_Execute_Success(t *testing.T) {
 		},
 	}
 
-	cmd := NewLoginCommand(client, []byte("1234567890abcdef"))
+	cmd := NewLoginCommand(client, &mockTokenSaver{}, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"user", "pass"})
 	assert.NoError(t, err)
-	assert.Equal(t, "login successful", got)
+	assert.Equal(t, "login successful", got.Text())
 }
 
 func TestLoginCommand_Execute_MissingArgs(t *testing.T) {
-	cmd := NewLoginCommand(nil, nil)
+	cmd := NewLoginCommand(nil, nil, nil)
 	got, err := cmd.Execute(context.Background(), []string{})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 
 	got, err = cmd.Execute(context.Background(), []string{"user"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
 
 func TestLoginCommand_Execute_LoginError(t *testing.T) {
@@ -46,8 +56,27 @@ func TestLoginCommand_Execute_LoginError(t *testing.T) {
 			return "", errors.New("invalid credentials")
 		},
 	}
-	cmd := NewLoginCommand(client, []byte("1234567890abcdef"))
+	cmd := NewLoginCommand(client, &mockTokenSaver{}, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"user", "pass"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
+}
+
+func TestLoginCommand_Execute_SavesToken(t *testing.T) {
+	client := &mockAuthClient{
+		loginFunc: func(ctx context.Context, login, password string, masterPassword []byte) (string, error) {
+			return "token", nil
+		},
+	}
+	tokenSaver := &mockTokenSaver{}
+
+	got, err := NewLoginCommand(client, tokenSaver, staticKey("1234567890abcdef")).Execute(context.Background(), []string{"user", "pass"})
+	assert.NoError(t, err)
+	assert.Equal(t, "login successful", got.Text())
+	assert.Equal(t, "token", tokenSaver.token)
+
+	tokenSaver.err = errors.New("db error")
+	got, err = NewLoginCommand(client, tokenSaver, staticKey("1234567890abcdef")).Execute(context.Background(), []string{"user", "pass"})
+	assert.Error(t, err)
+	assert.Nil(t, got)
 }
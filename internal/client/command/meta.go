@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+)
+
+type MetaResult struct {
+	Key  string             `json:"key"`
+	Meta *metadata.Metadata `json:"meta"`
+}
+
+func (r *MetaResult) Text() string {
+	formatted := r.Meta.String()
+	if formatted == "" {
+		return "no metadata"
+	}
+
+	return formatted
+}
+
+type MetaCommand struct {
+	dataManager DataStorage
+	keys        KeyProvider
+}
+
+func NewMetaCommand(dataManager DataStorage, keys KeyProvider) *MetaCommand {
+	return &MetaCommand{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *MetaCommand) Spec() Spec {
+	return Spec{
+		Name:     "meta",
+		Synopsis: "show or change item metadata",
+		Args: []Arg{
+			{Name: "key", Kind: ArgKey},
+			{Name: "field", Kind: ArgChoice, Choices: []string{"folder", "note", "favorite", "url"}, Optional: true},
+			{Name: "value", Optional: true, Variadic: true},
+		},
+		Examples: []string{
+			"meta github",
+			"meta github folder work/dev",
+			"meta github favorite true",
+			"meta github url add https://github.com",
+		},
+	}
+}
+
+func (c *MetaCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: <key> [folder|note|favorite|url] [values...]", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	if len(args) == 1 {
+		data, err := c.dataManager.Get(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := decryptMetadata(masterPassword, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return &MetaResult{Key: args[0], Meta: meta}, nil
+	}
+
+	update, err := c.parseUpdate(args[1], args[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := updateMetadata(ctx, c.dataManager, masterPassword, args[0], update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetaResult{Key: args[0], Meta: meta}, nil
+}
+
+func (c *MetaCommand) parseUpdate(field string, values []string) (func(meta *metadata.Metadata) error, error) {
+	switch field {
+	case "folder":
+		return func(meta *metadata.Metadata) error {
+			meta.SetFolder(strings.Join(values, " "))
+			return nil
+		}, nil
+	case "note":
+		return func(meta *metadata.Metadata) error {
+			meta.Note = strings.Join(values, " ")
+			return nil
+		}, nil
+	case "favorite":
+		if len(values) != 1 {
+			return nil, fmt.Errorf("%w: <key> favorite true|false", ErrInvalidArgs)
+		}
+		favorite, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("favorite should be bool: %w", err)
+		}
+		return func(meta *metadata.Metadata) error {
+			meta.Favorite = favorite
+			return nil
+		}, nil
+	case "url":
+		if len(values) != 2 {
+			return nil, fmt.Errorf("%w: <key> url add|remove <url>", ErrInvalidArgs)
+		}
+		switch values[0] {
+		case "add":
+			return func(meta *metadata.Metadata) error {
+				meta.AddURL(values[1])
+				return nil
+			}, nil
+		case "remove":
+			return func(meta *metadata.Metadata) error {
+				meta.RemoveURL(values[1])
+				return nil
+			}, nil
+		default:
+			return nil, fmt.Errorf("unknown url action: %s", values[0])
+		}
+	default:
+		return nil, fmt.Errorf("unknown metadata field: %s", field)
+	}
+}
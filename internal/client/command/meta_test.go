@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaCommand_Execute(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage, stored := newMemoryStorage(&model.UserData{DataKey: "key", DataValue: []byte("value")})
+	cmd := NewMetaCommand(storage, staticKey(password))
+
+	got, err := cmd.Execute(context.Background(), []string{"key"})
+	require.NoError(t, err)
+	assert.Equal(t, "no metadata", got.Text())
+
+	steps := [][]string{
+		{"key", "folder", "/work/servers/"},
+		{"key", "note", "rotate", "monthly"},
+		{"key", "favorite", "true"},
+		{"key", "url", "add", "https://example.com"},
+		{"key", "url", "add", "https://example.org"},
+		{"key", "url", "remove", "https://example.org"},
+	}
+	for _, args := range steps {
+		_, err := cmd.Execute(context.Background(), args)
+		require.NoError(t, err)
+	}
+
+	meta, err := decryptMetadata(password, stored["key"])
+	require.NoError(t, err)
+	assert.Equal(t, &metadata.Metadata{
+		Folder:   "work/servers",
+		Note:     "rotate monthly",
+		Favorite: true,
+		URLs:     []string{"https://example.com"},
+	}, meta)
+
+	got, err = cmd.Execute(context.Background(), []string{"key"})
+	require.NoError(t, err)
+	assert.Equal(t, "Folder: work/servers\nFavorite: yes\nURLs: https://example.com\nNote: rotate monthly", got.Text())
+}
+
+func TestMetaCommand_Execute_Errors(t *testing.T) {
+	storage, _ := newMemoryStorage(&model.UserData{DataKey: "key", DataValue: []byte("value")})
+	cmd := NewMetaCommand(storage, staticKey("1234567890abcdef"))
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing args", args: []string{}},
+		{name: "unknown key", args: []string{"missing"}},
+		{name: "unknown field", args: []string{"key", "color", "red"}},
+		{name: "invalid favorite", args: []string{"key", "favorite", "maybe"}},
+		{name: "missing favorite", args: []string{"key", "favorite"}},
+		{name: "invalid url args", args: []string{"key", "url", "add"}},
+		{name: "unknown url action", args: []string{"key", "url", "replace", "https://example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.Execute(context.Background(), tt.args)
+			assert.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
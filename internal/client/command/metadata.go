@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+)
+
+type DataStorage interface {
+	Get(ctx context.Context, key string) (*model.UserData, error)
+	Upsert(ctx context.Context, data *model.UserData) error
+}
+
+func decryptMetadata(masterPassword []byte, data *model.UserData) (*metadata.Metadata, error) {
+	if len(data.DataMeta) == 0 {
+		return &metadata.Metadata{}, nil
+	}
+
+	raw, err := aes.Decrypt(masterPassword, data.DataMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.FromBytes(raw)
+}
+
+func encryptMetadata(masterPassword []byte, meta *metadata.Metadata) ([]byte, error) {
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+
+	raw, err := meta.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return aes.Encrypt(masterPassword, raw)
+}
+
+func updateMetadata(
+	ctx context.Context,
+	storage DataStorage,
+	masterPassword []byte,
+	key string,
+	update func(meta *metadata.Metadata) error,
+) (*metadata.Metadata, error) {
+	data, err := storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := decryptMetadata(masterPassword, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := update(meta); err != nil {
+		return nil, err
+	}
+
+	data.DataMeta, err = encryptMetadata(masterPassword, meta)
+	if err != nil {
+		return nil, err
+	}
+	data.UpdatedAt = time.Now()
+
+	if err := storage.Upsert(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
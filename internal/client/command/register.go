@@ -2,7 +2,7 @@ package command
 
 import (
 	"context"
-	"errors"
+	"fmt"
 )
 
 type UserRegistrar interface {
@@ -10,26 +10,48 @@ type UserRegistrar interface {
 }
 
 type RegisterCommand struct {
-	client         UserRegistrar
-	masterPassword []byte
+	client     UserRegistrar
+	tokenSaver AuthTokenSaver
+	keys       KeyProvider
 }
 
-func NewRegisterCommand(client UserRegistrar, masterPassword []byte) *RegisterCommand {
+func NewRegisterCommand(client UserRegistrar, tokenSaver AuthTokenSaver, keys KeyProvider) *RegisterCommand {
 	return &RegisterCommand{
-		client:         client,
-		masterPassword: masterPassword,
+		client:     client,
+		tokenSaver: tokenSaver,
+		keys:       keys,
 	}
 }
 
-func (c *RegisterCommand) Execute(ctx context.Context, args []string) (string, error) {
+func (c *RegisterCommand) Spec() Spec {
+	return Spec{
+		Name:      "register",
+		Synopsis:  "create an account on the sync server",
+		Args:      []Arg{{Name: "login"}, {Name: "password"}},
+		Examples:  []string{"register octocat secret"},
+		Sensitive: true,
+	}
+}
+
+func (c *RegisterCommand) Execute(ctx context.Context, args []string) (Result, error) {
 	if len(args) < 2 {
-		return "", errors.New("args: <login> <password>")
+		return nil, fmt.Errorf("%w: <login> <password>", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	token, err := c.client.Register(ctx, args[0], args[1], masterPassword)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := c.client.Register(ctx, args[0], args[1], c.masterPassword)
-	if err == nil {
-		return "register successful", nil
+	if err := c.tokenSaver.SetAuthToken(ctx, token, masterPassword); err != nil {
+		return nil, fmt.Errorf("can`t save auth token: %w", err)
 	}
 
-	return "", err
+	return Message("register successful"), nil
 }
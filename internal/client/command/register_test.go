@@ -23,21 +23,21 @@ func TestRegisterCommand_Execute_Success(t *testing.T) {
 		},
 	}
 
-	cmd := NewRegisterCommand(client, []byte("1234567890abcdef"))
+	cmd := NewRegisterCommand(client, &mockTokenSaver{}, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"user", "pass"})
 	assert.NoError(t, err)
-	assert.Equal(t, "register successful", got)
+	assert.Equal(t, "register successful", got.Text())
 }
 
 func TestRegisterCommand_Execute_MissingArgs(t *testing.T) {
-	cmd := NewRegisterCommand(nil, nil)
+	cmd := NewRegisterCommand(nil, nil, nil)
 	got, err := cmd.Execute(context.Background(), []string{})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 
 	got, err = cmd.Execute(context.Background(), []string{"user"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
 
 func TestRegisterCommand_Execute_RegisterError(t *testing.T) {
@@ -46,8 +46,27 @@ func TestRegisterCommand_Execute_RegisterError(t *testing.T) {
 			return "", errors.New("user already exists")
 		},
 	}
-	cmd := NewRegisterCommand(client, []byte("1234567890abcdef"))
+	cmd := NewRegisterCommand(client, &mockTokenSaver{}, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"user", "pass"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
+}
+
+func TestRegisterCommand_Execute_SavesToken(t *testing.T) {
+	client := &mockRegistrarClient{
+		registerFunc: func(ctx context.Context, login, password string, masterPassword []byte) (string, error) {
+			return "token", nil
+		},
+	}
+	tokenSaver := &mockTokenSaver{}
+
+	got, err := NewRegisterCommand(client, tokenSaver, staticKey("1234567890abcdef")).Execute(context.Background(), []string{"user", "pass"})
+	assert.NoError(t, err)
+	assert.Equal(t, "register successful", got.Text())
+	assert.Equal(t, "token", tokenSaver.token)
+
+	tokenSaver.err = errors.New("db error")
+	got, err = NewRegisterCommand(client, tokenSaver, staticKey("1234567890abcdef")).Execute(context.Background(), []string{"user", "pass"})
+	assert.Error(t, err)
+	assert.Nil(t, got)
 }
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+type RenderResult struct {
+	Template   string `json:"template"`
+	Output     string `json:"output,omitempty"`
+	Content    string `json:"content,omitempty"`
+	References int    `json:"references"`
+	Checked    bool   `json:"checked,omitempty"`
+}
+
+func (r *RenderResult) Text() string {
+	switch {
+	case r.Checked:
+		return fmt.Sprintf("%s is valid, %d references resolved", r.Template, r.References)
+	case r.Output != "":
+		return fmt.Sprintf("%s rendered to %s", r.Template, r.Output)
+	default:
+		return strings.TrimSuffix(r.Content, "\n")
+	}
+}
+
+type RenderCommand struct {
+	dataManager UserDataGetter
+	keys        KeyProvider
+}
+
+func NewRenderCommand(dataManager UserDataGetter, keys KeyProvider) *RenderCommand {
+	return &RenderCommand{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *RenderCommand) Spec() Spec {
+	return Spec{
+		Name:     "render",
+		Synopsis: `render a text/template file with {{ secret "key" "field" }} references`,
+		Args:     []Arg{{Name: "template", Kind: ArgFile}},
+		Flags: []Flag{
+			{Name: "o", Value: "file", Kind: ArgFile, Usage: "write to file with 0600 permissions instead of printing"},
+			{Name: "check", Usage: "only check that all references resolve"},
+		},
+		Examples: []string{"render .env.tmpl -o .env", "render --check config.yaml.tmpl"},
+	}
+}
+
+func (c *RenderCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	path, output, check := flags.Arg(0), flags.String("o"), flags.Bool("check")
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	references := 0
+	tmpl, err := template.New(filepath.Base(path)).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"secret": func(key string, field ...string) (string, error) {
+				references++
+				return c.secret(ctx, masterPassword, key, field...)
+			},
+			"base64": func(s string) string {
+				return base64.StdEncoding.EncodeToString([]byte(s))
+			},
+		}).
+		Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("can`t parse template: %w", err)
+	}
+
+	// render to memory first, so a failed render never leaves a partial file
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("can`t render template: %w", err)
+	}
+
+	result := &RenderResult{Template: path, References: references}
+	switch {
+	case check:
+		result.Checked = true
+	case output != "":
+		err := writePrivateFile(output, func(w io.Writer) error {
+			_, err := buf.WriteTo(w)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Output = output
+	default:
+		result.Content = buf.String()
+	}
+
+	return result, nil
+}
+
+func (c *RenderCommand) secret(ctx context.Context, masterPassword []byte, key string, field ...string) (string, error) {
+	if len(field) > 1 {
+		return "", fmt.Errorf("secret %q: expected at most one field, got %d", key, len(field))
+	}
+
+	name := ""
+	if len(field) > 0 {
+		name = field[0]
+	}
+
+	secret, err := resolveSecret(ctx, c.dataManager, masterPassword, key, name)
+	if err != nil {
+		return "", fmt.Errorf("secret %q: %w", key, err)
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRenderCommand(t *testing.T) *RenderCommand {
+	password := []byte("1234567890abcdef")
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	erased := newEncryptedItem(t, password, "erased", []byte("\x02{\"text\":\"old\"}"), nil, updatedAt)
+	erased.DeletedAt = updatedAt
+	storage, _ := newMemoryStorage(
+		newEncryptedItem(t, password, "prod.db", []byte("\x01{\"login\":\"admin\",\"password\":\"s3cr3t\"}"), nil, updatedAt),
+		newEncryptedItem(t, password, "token", []byte("\x02{\"text\":\"tok\"}"), nil, updatedAt),
+		erased,
+	)
+
+	return NewRenderCommand(storage, staticKey(password))
+}
+
+func writeTemplate(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "app.env.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+const testTemplate = `DB_USER={{ secret "prod.db" "login" }}
+DB_PASS={{ secret "prod.db" "password" }}
+TOKEN={{ secret "token" | base64 }}
+`
+
+func TestRenderCommand_Execute(t *testing.T) {
+	cmd := newTestRenderCommand(t)
+	path := writeTemplate(t, testTemplate)
+
+	got, err := cmd.Execute(context.Background(), []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, &RenderResult{
+		Template:   path,
+		Content:    "DB_USER=admin\nDB_PASS=s3cr3t\nTOKEN=dG9r\n",
+		References: 3,
+	}, got)
+	assert.Equal(t, "DB_USER=admin\nDB_PASS=s3cr3t\nTOKEN=dG9r", got.Text())
+}
+
+func TestRenderCommand_Execute_Output(t *testing.T) {
+	cmd := newTestRenderCommand(t)
+	path := writeTemplate(t, testTemplate)
+	output := filepath.Join(t.TempDir(), ".env")
+
+	got, err := cmd.Execute(context.Background(), []string{"-o", output, path})
+	require.NoError(t, err)
+	assert.Equal(t, path+" rendered to "+output, got.Text())
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "DB_USER=admin\nDB_PASS=s3cr3t\nTOKEN=dG9r\n", string(content))
+
+	info, err := os.Stat(output)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRenderCommand_Execute_Check(t *testing.T) {
+	cmd := newTestRenderCommand(t)
+	path := writeTemplate(t, testTemplate)
+
+	got, err := cmd.Execute(context.Background(), []string{"--check", path})
+	require.NoError(t, err)
+	assert.Equal(t, &RenderResult{Template: path, References: 3, Checked: true}, got)
+	assert.Equal(t, path+" is valid, 3 references resolved", got.Text())
+}
+
+func TestRenderCommand_Execute_Errors(t *testing.T) {
+	cmd := newTestRenderCommand(t)
+
+	tests := []struct {
+		name     string
+		template string
+		wantErr  error
+	}{
+		{name: "missing item", template: `{{ secret "missing" }}`, wantErr: manager.ErrNotFound},
+		{name: "deleted item", template: `{{ secret "erased" }}`, wantErr: manager.ErrNotFound},
+		{name: "unknown field", template: `{{ secret "token" "cvc" }}`, wantErr: value.ErrUnknownField},
+		{name: "too many fields", template: `{{ secret "prod.db" "login" "password" }}`},
+		{name: "missing data key", template: `{{ .Missing }}`},
+		{name: "syntax", template: `{{ secret "token"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemplate(t, tt.template)
+			output := filepath.Join(t.TempDir(), "out")
+
+			got, err := cmd.Execute(context.Background(), []string{"-o", output, path})
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Nil(t, got)
+			assert.NoFileExists(t, output)
+		})
+	}
+}
+
+func TestRenderCommand_Execute_Args(t *testing.T) {
+	cmd := NewRenderCommand(nil, nil)
+
+	_, err := cmd.Execute(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+
+	_, err = cmd.Execute(context.Background(), []string{"--unknown", "a.tmpl"})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+}
+
+func TestRenderCommand_Execute_Locked(t *testing.T) {
+	cmd := NewRenderCommand(nil, lockedKey{})
+	path := writeTemplate(t, testTemplate)
+
+	_, err := cmd.Execute(context.Background(), []string{path})
+	assert.EqualError(t, err, "vault is locked")
+}
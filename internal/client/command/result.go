@@ -0,0 +1,37 @@
+package command
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+type Result interface {
+	Text() string
+}
+
+type Message string
+
+func (m Message) Text() string {
+	return string(m)
+}
+
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message string `json:"message"`
+	}{Message: string(m)})
+}
+
+type Item struct {
+	Key       string             `json:"key"`
+	Type      string             `json:"type"`
+	Value     value.Value        `json:"value"`
+	Meta      *metadata.Metadata `json:"meta"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func (i *Item) Text() string {
+	return i.Value.String()
+}
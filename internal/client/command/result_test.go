@@ -0,0 +1,96 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	message := Message("saved successful")
+	assert.Equal(t, "saved successful", message.Text())
+
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message":"saved successful"}`, string(data))
+}
+
+func TestItem_JSONSchema(t *testing.T) {
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value value.Value
+		want  string
+	}{
+		{
+			name:  "login_password",
+			value: &value.LoginPassword{Login: "user", Password: "pass"},
+			want:  `{"login":"user","password":"pass"}`,
+		},
+		{
+			name:  "text",
+			value: &value.TextValue{Text: "hello world"},
+			want:  `{"text":"hello world"}`,
+		},
+		{
+			name:  "binary",
+			value: &value.BinaryValue{Data: []byte{0, 1, 2}},
+			want:  `{"data":"AAEC"}`,
+		},
+		{
+			name:  "card",
+			value: &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"},
+			want:  `{"number":"4111111111111111","holder":"JOHN DOE","expire_month":5,"expire_year":2027,"cvc":"123"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{
+				Key:       "key",
+				Type:      value.TypeName(tt.value),
+				Value:     tt.value,
+				Meta:      &metadata.Metadata{Folder: "work"},
+				UpdatedAt: updatedAt,
+			}
+
+			data, err := json.Marshal(item)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{
+				"key": "key",
+				"type": "`+tt.name+`",
+				"value": `+tt.want+`,
+				"meta": {"folder": "work"},
+				"updated_at": "2025-05-01T10:00:00Z"
+			}`, string(data))
+			assert.Equal(t, tt.value.String(), item.Text())
+		})
+	}
+}
+
+func TestListResult(t *testing.T) {
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	result := ListResult{
+		{Key: "db", Folder: "work", Tags: []string{"prod"}, Favorite: true, UpdatedAt: updatedAt},
+		{Key: "mail", Tags: []string{}, UpdatedAt: updatedAt},
+	}
+
+	assert.Equal(t, "db  work  prod  *\nmail", result.Text())
+	assert.Equal(t, "no items found", ListResult{}.Text())
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"key": "db", "folder": "work", "tags": ["prod"], "favorite": true, "updated_at": "2025-05-01T10:00:00Z"},
+		{"key": "mail", "folder": "", "tags": [], "favorite": false, "updated_at": "2025-05-01T10:00:00Z"}
+	]`, string(data))
+
+	data, err = json.Marshal(ListResult{})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+}
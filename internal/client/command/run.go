@@ -0,0 +1,200 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	runMask      = "********"
+	runWaitDelay = 5 * time.Second
+)
+
+type RunResult struct {
+	Command  string `json:"command"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// Text is empty, the output of the child is the output of the command
+func (r *RunResult) Text() string {
+	return ""
+}
+
+// RunCommand always starts the program in the calling process,
+// with an agent the secrets are resolved by the agent
+type RunCommand struct {
+	secrets SecretResolver
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func NewRunCommand(secrets SecretResolver) *RunCommand {
+	return &RunCommand{
+		secrets: secrets,
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+	}
+}
+
+func (c *RunCommand) Spec() Spec {
+	return Spec{
+		Name:     "run",
+		Synopsis: "run a program with secrets in environment variables",
+		Args:     []Arg{{Name: "command", Variadic: true}},
+		Flags: []Flag{
+			{Name: "env", Value: "NAME=key[.field]", Usage: "set variable to an item field, the default field if omitted", Repeated: true},
+			{Name: "mask", Usage: "replace secret values in the program output"},
+		},
+		Examples: []string{
+			"run --env DB_PASS=prod-db.password -- psql -h db.local",
+			"run --mask --env TOKEN=github-token.text -- ./deploy.sh",
+		},
+		Terminal: true,
+	}
+}
+
+func (c *RunCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	flags, err := c.Spec().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	env := flags.Strings("env")
+
+	vars := make([]string, 0, len(env))
+	secrets := make([]string, 0, len(env))
+	for _, definition := range env {
+		name, ref, ok := strings.Cut(definition, "=")
+		if !ok || name == "" || ref == "" {
+			return nil, fmt.Errorf("%w: invalid --env %q, expected NAME=key[.field]", ErrInvalidArgs, definition)
+		}
+
+		secret, err := c.resolve(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("can`t resolve %s: %w", name, err)
+		}
+		vars = append(vars, name+"="+secret)
+		secrets = append(secrets, secret)
+	}
+
+	stdout, stderr := c.stdout, c.stderr
+	if flags.Bool("mask") {
+		maskedStdout, maskedStderr := newMaskWriter(stdout, secrets), newMaskWriter(stderr, secrets)
+		defer maskedStdout.Flush()
+		defer maskedStderr.Flush()
+		stdout, stderr = maskedStdout, maskedStderr
+	}
+
+	cmd := exec.CommandContext(ctx, flags.Arg(0), flags.Args()[1:]...)
+	cmd.Env = append(os.Environ(), vars...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = c.stdin, stdout, stderr
+	// give the program a chance to exit gracefully on Ctrl+C
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = runWaitDelay
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+
+	return &RunResult{Command: flags.Arg(0), ExitCode: cmd.ProcessState.ExitCode()}, nil
+}
+
+// resolve returns a field of the item, the reference is key.field or just key for the default field.
+// Keys may contain dots too, so a reference without a known field, or whose key.field item
+// doesn't exist, is the whole key
+func (c *RunCommand) resolve(ctx context.Context, ref string) (string, error) {
+	idx := strings.LastIndex(ref, ".")
+	if idx <= 0 || !slices.Contains(value.FieldNames(), ref[idx+1:]) {
+		return c.secrets.ResolveSecret(ctx, ref, "")
+	}
+
+	secret, err := c.secrets.ResolveSecret(ctx, ref[:idx], ref[idx+1:])
+	if errors.Is(err, manager.ErrNotFound) {
+		return c.secrets.ResolveSecret(ctx, ref, "")
+	}
+
+	return secret, err
+}
+
+// maskWriter replaces secrets in a stream, a tail shorter than the longest secret
+// is held back until the next write, so secrets split between writes are masked too
+type maskWriter struct {
+	w       io.Writer
+	secrets [][]byte
+	keep    int
+	buf     []byte
+}
+
+func newMaskWriter(w io.Writer, secrets []string) *maskWriter {
+	writer := &maskWriter{w: w}
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		writer.secrets = append(writer.secrets, []byte(secret))
+		writer.keep = max(writer.keep, len(secret)-1)
+	}
+	// longer secrets first, so a secret containing another one is masked whole
+	sort.Slice(writer.secrets, func(i, j int) bool {
+		return len(writer.secrets[i]) > len(writer.secrets[j])
+	})
+
+	return writer
+}
+
+func (m *maskWriter) Write(p []byte) (int, error) {
+	m.buf = append(m.buf, p...)
+	if err := m.flush(len(m.buf) - m.keep); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (m *maskWriter) Flush() error {
+	return m.flush(len(m.buf))
+}
+
+// flush writes the buffer up to limit, a secret starting before limit is masked whole
+func (m *maskWriter) flush(limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	out := make([]byte, 0, limit)
+	i := 0
+	for i < limit {
+		matched := false
+		for _, secret := range m.secrets {
+			if bytes.HasPrefix(m.buf[i:], secret) {
+				out = append(out, runMask...)
+				i += len(secret)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out = append(out, m.buf[i])
+			i++
+		}
+	}
+	m.buf = append(m.buf[:0], m.buf[i:]...)
+
+	_, err := m.w.Write(out)
+	return err
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRunCommand(t *testing.T) (*RunCommand, *bytes.Buffer, *bytes.Buffer) {
+	password := []byte("1234567890abcdef")
+	updatedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	erased := newEncryptedItem(t, password, "erased", []byte("\x02{\"text\":\"old\"}"), nil, updatedAt)
+	erased.DeletedAt = updatedAt
+	storage, _ := newMemoryStorage(
+		newEncryptedItem(t, password, "prod.db", []byte("\x01{\"login\":\"admin\",\"password\":\"s3cr3t\"}"), nil, updatedAt),
+		newEncryptedItem(t, password, "token", []byte("\x02{\"text\":\"tok\"}"), nil, updatedAt),
+		newEncryptedItem(t, password, "api.example.com", []byte("\x02{\"text\":\"api-key\"}"), nil, updatedAt),
+		newEncryptedItem(t, password, "smtp.login", []byte("\x01{\"login\":\"mailer\",\"password\":\"smtp-pass\"}"), nil, updatedAt),
+		erased,
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewRunCommand(NewVaultSecrets(storage, staticKey(password)))
+	cmd.stdin, cmd.stdout, cmd.stderr = strings.NewReader(""), &stdout, &stderr
+
+	return cmd, &stdout, &stderr
+}
+
+func TestRunCommand_Execute(t *testing.T) {
+	cmd, stdout, stderr := newTestRunCommand(t)
+
+	got, err := cmd.Execute(context.Background(), []string{
+		"--env", "DB_USER=prod.db.login", "--env", "DB_PASS=prod.db.password", "--env", "TOKEN=token",
+		"--", "sh", "-c", `echo "$DB_USER:$DB_PASS"; echo "$TOKEN" >&2`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &RunResult{Command: "sh", ExitCode: 0}, got)
+	assert.Equal(t, "", got.Text())
+	assert.Equal(t, "admin:s3cr3t\n", stdout.String())
+	assert.Equal(t, "tok\n", stderr.String())
+}
+
+func TestRunCommand_Execute_DottedKeys(t *testing.T) {
+	cmd, stdout, _ := newTestRunCommand(t)
+
+	// api.example.com has no field suffix, smtp.login is a key since there is no smtp item
+	_, err := cmd.Execute(context.Background(), []string{
+		"--env", "API=api.example.com", "--env", "SMTP=smtp.login", "--env", "SMTP_USER=smtp.login.login",
+		"--", "sh", "-c", `echo "$API $SMTP $SMTP_USER"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "api-key smtp-pass mailer\n", stdout.String())
+}
+
+func TestRunCommand_Execute_Mask(t *testing.T) {
+	cmd, stdout, stderr := newTestRunCommand(t)
+
+	_, err := cmd.Execute(context.Background(), []string{
+		"--mask", "--env", "DB_PASS=prod.db.password",
+		"--", "sh", "-c", `printf "pass=s3c"; printf "r3t\n"; echo "$DB_PASS" >&2`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pass=********\n", stdout.String())
+	assert.Equal(t, "********\n", stderr.String())
+}
+
+func TestRunCommand_Execute_ExitCode(t *testing.T) {
+	cmd, _, _ := newTestRunCommand(t)
+
+	_, err := cmd.Execute(context.Background(), []string{"sh", "-c", "exit 3"})
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.ExitCode())
+}
+
+func TestRunCommand_Execute_Errors(t *testing.T) {
+	cmd, _, _ := newTestRunCommand(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "no command", args: []string{"--env", "A=token"}, wantErr: ErrInvalidArgs},
+		{name: "invalid env", args: []string{"--env", "token", "--", "true"}, wantErr: ErrInvalidArgs},
+		{name: "missing item", args: []string{"--env", "A=missing.password", "--", "true"}, wantErr: manager.ErrNotFound},
+		{name: "deleted item", args: []string{"--env", "A=erased", "--", "true"}, wantErr: manager.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cmd.Execute(context.Background(), tt.args)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := cmd.Execute(context.Background(), []string{"--env", "A=token.cvc", "--", "true"})
+	assert.EqualError(t, err, "can`t resolve A: unknown field \"cvc\" for text")
+}
+
+func TestMaskWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := newMaskWriter(&buf, []string{"secret", "sec", ""})
+
+	for _, chunk := range []string{"a se", "cr", "et b s", "ec c se"} {
+		_, err := writer.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, "a ******** b ******** c se", buf.String())
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+// SecretResolver returns a field of a live item, an empty field is the default one
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, key, field string) (string, error)
+}
+
+// FieldResolver also returns the name of the resolved field, the default one is not named by the caller
+type FieldResolver interface {
+	ResolveField(ctx context.Context, key, field string) (name, text string, err error)
+}
+
+// VaultSecrets resolves secrets from the local database
+type VaultSecrets struct {
+	dataManager UserDataGetter
+	keys        KeyProvider
+}
+
+func NewVaultSecrets(dataManager UserDataGetter, keys KeyProvider) *VaultSecrets {
+	return &VaultSecrets{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (s *VaultSecrets) ResolveSecret(ctx context.Context, key, field string) (string, error) {
+	_, text, err := s.ResolveField(ctx, key, field)
+
+	return text, err
+}
+
+func (s *VaultSecrets) ResolveField(ctx context.Context, key, field string) (string, string, error) {
+	masterPassword, err := s.keys.MasterPassword()
+	if err != nil {
+		return "", "", err
+	}
+	defer clear(masterPassword)
+
+	return resolveField(ctx, s.dataManager, masterPassword, key, field)
+}
+
+// resolveSecret returns a field of a live item, an empty field is the default one
+func resolveSecret(ctx context.Context, dataManager UserDataGetter, masterPassword []byte, key, field string) (string, error) {
+	_, text, err := resolveField(ctx, dataManager, masterPassword, key, field)
+
+	return text, err
+}
+
+// resolveField also returns the name of the resolved field
+func resolveField(ctx context.Context, dataManager UserDataGetter, masterPassword []byte, key, field string) (string, string, error) {
+	data, err := manager.GetLive(ctx, dataManager, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	val, err := value.Decrypt(masterPassword, data.DataValue)
+	if err != nil {
+		return "", "", err
+	}
+	if field == "" {
+		field = value.DefaultField(val)
+	}
+
+	text, err := value.Field(val, field)
+	if err != nil {
+		return "", "", err
+	}
+
+	return field, text, nil
+}
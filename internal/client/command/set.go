@@ -3,59 +3,106 @@ package command
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/client/value"
 )
 
-type DataUpserter interface {
-	Upsert(ctx context.Context, data *model.UserData) error
-}
-
 type SetCommand struct {
-	dataManager    DataUpserter
-	masterPassword []byte
+	dataManager DataStorage
+	keys        KeyProvider
 }
 
-func NewSetCommand(dataManager DataUpserter, masterPassword []byte) *SetCommand {
+func NewSetCommand(dataManager DataStorage, keys KeyProvider) *SetCommand {
 	return &SetCommand{
-		dataManager:    dataManager,
-		masterPassword: masterPassword,
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *SetCommand) Spec() Spec {
+	return Spec{
+		Name:     "set",
+		Synopsis: "add or replace an item",
+		Args: []Arg{
+			{Name: "key", Kind: ArgKey},
+			{Name: "type", Kind: ArgType},
+			{Name: "value", Variadic: true},
+		},
+		Examples: []string{
+			"set github login_password octocat secret",
+			`set note text "multi word text"`,
+			"set photo binary ./photo.jpg",
+			"set visa card 4111111111111111 \"JOHN DOE\" 12 2030 123",
+		},
+		Sensitive: true,
 	}
 }
 
-func (c *SetCommand) Execute(ctx context.Context, args []string) (string, error) {
+// SetArgs makes the file path of a binary value absolute, so the agent reads the same file
+func SetArgs(args []string) ([]string, error) {
+	if len(args) < 3 || args[1] != "binary" {
+		return args, nil
+	}
+
+	path, err := absPath(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{args[0], args[1], path}, args[3:]...), nil
+}
+
+func (c *SetCommand) Execute(ctx context.Context, args []string) (Result, error) {
 	if len(args) < 3 {
-		return "", errors.New("args: <key> <type> <args...>")
+		return nil, fmt.Errorf("%w: <key> <type> <args...>", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
 	}
+	defer clear(masterPassword)
 
 	val, err := value.FromUserInput(args[1], args[2:])
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	raw, err := val.ToBytes()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	encRaw, err := aes.Encrypt(c.masterPassword, raw)
+	encRaw, err := aes.Encrypt(masterPassword, raw)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var encMeta []byte
+	existing, err := c.dataManager.Get(ctx, args[0])
+	switch {
+	case err == nil:
+		encMeta = existing.DataMeta
+	case !errors.Is(err, manager.ErrNotFound):
+		return nil, err
 	}
 
 	err = c.dataManager.Upsert(ctx, &model.UserData{
 		DataKey:   args[0],
 		DataValue: encRaw,
+		DataMeta:  encMeta,
 		UpdatedAt: time.Now(),
 		DeletedAt: time.Unix(0, 0),
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return "saved successful", nil
+	return Message("saved successful"), nil
 }
@@ -6,20 +6,44 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/stretchr/testify/assert"
 )
 
-type mockDataUpserter struct {
+type mockDataStorage struct {
+	getFunc    func(ctx context.Context, key string) (*model.UserData, error)
 	upsertFunc func(ctx context.Context, data *model.UserData) error
+	listFunc   func(ctx context.Context) ([]*model.UserData, error)
 }
 
-func (m *mockDataUpserter) Upsert(ctx context.Context, data *model.UserData) error {
+func (m *mockDataStorage) List(ctx context.Context) ([]*model.UserData, error) {
+	return m.listFunc(ctx)
+}
+
+func (m *mockDataStorage) Get(ctx context.Context, key string) (*model.UserData, error) {
+	if m.getFunc == nil {
+		return nil, manager.ErrNotFound
+	}
+	return m.getFunc(ctx, key)
+}
+
+func (m *mockDataStorage) Upsert(ctx context.Context, data *model.UserData) error {
 	return m.upsertFunc(ctx, data)
 }
 
+func (m *mockDataStorage) UpsertAll(ctx context.Context, items []*model.UserData) error {
+	for _, data := range items {
+		if err := m.upsertFunc(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func TestSetCommand_Execute_Success(t *testing.T) {
-	dataManager := &mockDataUpserter{
+	dataManager := &mockDataStorage{
 		upsertFunc: func(ctx context.Context, data *model.UserData) error {
 			assert.Equal(t, "test-key", data.DataKey)
 			assert.NotNil(t, data.DataValue)
@@ -29,42 +53,78 @@ func TestSetCommand_Execute_Success(t *testing.T) {
 		},
 	}
 
-	cmd := NewSetCommand(dataManager, []byte("1234567890abcdef"))
+	cmd := NewSetCommand(dataManager, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"test-key", "text", "test-value"})
 	assert.NoError(t, err)
-	assert.Equal(t, "saved successful", got)
+	assert.Equal(t, "saved successful", got.Text())
 }
 
 func TestSetCommand_Execute_MissingArgs(t *testing.T) {
 	cmd := NewSetCommand(nil, nil)
 	got, err := cmd.Execute(context.Background(), []string{})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 
 	got, err = cmd.Execute(context.Background(), []string{"key"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 
 	got, err = cmd.Execute(context.Background(), []string{"key", "type"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
 
 func TestSetCommand_Execute_InvalidType(t *testing.T) {
-	cmd := NewSetCommand(nil, []byte("1234567890abcdef"))
+	cmd := NewSetCommand(nil, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"key", "invalid-type", "value"})
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
 
 func TestSetCommand_Execute_UpsertError(t *testing.T) {
-	dataManager := &mockDataUpserter{
+	dataManager := &mockDataStorage{
 		upsertFunc: func(ctx context.Context, data *model.UserData) error {
 			return errors.New("upsert error")
 		},
 	}
-	cmd := NewSetCommand(dataManager, []byte("1234567890abcdef"))
+	cmd := NewSetCommand(dataManager, staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{"key", "text", "value"})
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetCommand_Execute_PreservesMetadata(t *testing.T) {
+	dataManager := &mockDataStorage{
+		getFunc: func(ctx context.Context, key string) (*model.UserData, error) {
+			return &model.UserData{
+				DataKey:   key,
+				DataValue: []byte("old-value"),
+				DataMeta:  []byte("encrypted-meta"),
+			}, nil
+		},
+		upsertFunc: func(ctx context.Context, data *model.UserData) error {
+			assert.Equal(t, []byte("encrypted-meta"), data.DataMeta)
+			return nil
+		},
+	}
+
+	cmd := NewSetCommand(dataManager, staticKey("1234567890abcdef"))
+	got, err := cmd.Execute(context.Background(), []string{"key", "text", "value"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "saved successful", got.Text())
+}
+
+func TestSetCommand_Execute_GetError(t *testing.T) {
+	dataManager := &mockDataStorage{
+		getFunc: func(ctx context.Context, key string) (*model.UserData, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	cmd := NewSetCommand(dataManager, staticKey("1234567890abcdef"))
 	got, err := cmd.Execute(context.Background(), []string{"key", "text", "value"})
+
 	assert.Error(t, err)
-	assert.Equal(t, "", got)
+	assert.Nil(t, got)
 }
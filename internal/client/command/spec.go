@@ -0,0 +1,309 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type ArgKind int
+
+const (
+	ArgText ArgKind = iota
+	ArgKey
+	ArgType
+	ArgFile
+	ArgChoice
+	ArgCommand
+)
+
+type Arg struct {
+	Name     string
+	Kind     ArgKind
+	Choices  []string
+	Optional bool
+	Variadic bool
+}
+
+// Flag is a boolean switch unless it has a Value or Choices
+type Flag struct {
+	Name     string
+	Value    string
+	Kind     ArgKind
+	Choices  []string
+	Default  string
+	Usage    string
+	Repeated bool
+}
+
+type Spec struct {
+	Name     string
+	Synopsis string
+	Args     []Arg
+	Flags    []Flag
+	Examples []string
+	// Sensitive commands receive secrets in arguments and are never written to history
+	Sensitive bool
+	// Terminal commands use stdio of the calling process and can`t be executed by the agent
+	Terminal bool
+}
+
+func (s Spec) Usage() string {
+	parts := []string{s.Name}
+	for _, flag := range s.Flags {
+		part := "[" + flag.String() + "]"
+		if flag.Repeated {
+			part += "..."
+		}
+		parts = append(parts, part)
+	}
+	for _, arg := range s.Args {
+		parts = append(parts, arg.String())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Arg returns the positional argument at the given index, the last variadic one repeats
+func (s Spec) Arg(position int) (Arg, bool) {
+	if position < len(s.Args) {
+		return s.Args[position], true
+	}
+	if len(s.Args) > 0 && s.Args[len(s.Args)-1].Variadic {
+		return s.Args[len(s.Args)-1], true
+	}
+
+	return Arg{}, false
+}
+
+func (s Spec) Flag(name string) (Flag, bool) {
+	name = strings.TrimLeft(name, "-")
+	for _, flag := range s.Flags {
+		if flag.Name == name {
+			return flag, true
+		}
+	}
+
+	return Flag{}, false
+}
+
+// Parse parses the flags declared in the spec and checks the number of positional arguments.
+// Flags may follow positional arguments until "--" or a variadic argument, which takes the rest as is.
+func (s Spec) Parse(args []string) (*Flags, error) {
+	set := flag.NewFlagSet(s.Name, flag.ContinueOnError)
+	set.SetOutput(io.Discard)
+	for _, f := range s.Flags {
+		switch {
+		case f.Repeated:
+			set.Var(&repeatedValue{}, f.Name, f.Usage)
+		case f.HasValue():
+			set.String(f.Name, f.Default, f.Usage)
+		default:
+			set.Bool(f.Name, false, f.Usage)
+		}
+	}
+
+	parsed := &Flags{set: set}
+	for {
+		if err := set.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+		}
+		rest := set.Args()
+		terminated := len(rest) < len(args) && args[len(args)-len(rest)-1] == "--"
+		if arg, ok := s.Arg(len(parsed.args)); terminated || len(rest) == 0 || (ok && arg.Variadic) {
+			parsed.args = append(parsed.args, rest...)
+			break
+		}
+		parsed.args = append(parsed.args, rest[0])
+		args = rest[1:]
+	}
+
+	if err := s.check(parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func (s Spec) check(parsed *Flags) error {
+	for _, f := range s.Flags {
+		if len(f.Choices) > 0 && !slices.Contains(f.Choices, parsed.String(f.Name)) {
+			return fmt.Errorf("%w: %s must be %s", ErrInvalidArgs, f.Option(), strings.Join(f.Choices, " or "))
+		}
+	}
+
+	for i, arg := range s.Args {
+		if i >= len(parsed.args) && !arg.Optional {
+			return fmt.Errorf("%w: %s is required", ErrInvalidArgs, arg)
+		}
+	}
+	if len(parsed.args) > len(s.Args) && (len(s.Args) == 0 || !s.Args[len(s.Args)-1].Variadic) {
+		return fmt.Errorf("%w: unexpected argument %q", ErrInvalidArgs, parsed.args[len(s.Args)])
+	}
+
+	return nil
+}
+
+// AbsPaths makes file arguments and file flag values absolute,
+// so a process with another working directory, like the agent, opens the same files
+func (s Spec) AbsPaths(args []string) ([]string, error) {
+	if len(s.Flags) == 0 {
+		return s.absArgs(args)
+	}
+
+	parsed, err := s.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(args)+1)
+	parsed.set.Visit(func(f *flag.Flag) {
+		values := []string{f.Value.String()}
+		if repeated, ok := f.Value.(*repeatedValue); ok {
+			values = *repeated
+		}
+		spec, _ := s.Flag(f.Name)
+		for _, value := range values {
+			if spec.Kind == ArgFile {
+				abs, absErr := absPath(value)
+				if absErr != nil {
+					err = absErr
+				}
+				value = abs
+			}
+			result = append(result, "--"+f.Name+"="+value)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	positional, err := s.absArgs(parsed.args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(result, "--"), positional...), nil
+}
+
+func (s Spec) absArgs(args []string) ([]string, error) {
+	result := slices.Clone(args)
+	for i := range result {
+		if arg, ok := s.Arg(i); !ok || arg.Kind != ArgFile {
+			continue
+		}
+
+		var err error
+		if result[i], err = absPath(result[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func absPath(path string) (string, error) {
+	if path == "" {
+		return path, nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("can`t resolve path %s: %w", path, err)
+	}
+
+	return abs, nil
+}
+
+// Flags holds the flags and positional arguments parsed by Spec.Parse
+type Flags struct {
+	set  *flag.FlagSet
+	args []string
+}
+
+func (f *Flags) Bool(name string) bool {
+	return f.set.Lookup(name).Value.(flag.Getter).Get().(bool)
+}
+
+func (f *Flags) String(name string) string {
+	return f.set.Lookup(name).Value.String()
+}
+
+// Strings returns all values of a repeated flag
+func (f *Flags) Strings(name string) []string {
+	return *f.set.Lookup(name).Value.(*repeatedValue)
+}
+
+func (f *Flags) Args() []string {
+	return f.args
+}
+
+// Arg returns the positional argument at the given index or an empty string
+func (f *Flags) Arg(i int) string {
+	if i >= len(f.args) {
+		return ""
+	}
+
+	return f.args[i]
+}
+
+func (f *Flags) NArg() int {
+	return len(f.args)
+}
+
+type repeatedValue []string
+
+func (v *repeatedValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return strings.Join(*v, ",")
+}
+
+func (v *repeatedValue) Set(value string) error {
+	*v = append(*v, value)
+	return nil
+}
+
+func (a Arg) String() string {
+	name := "<" + a.Name + ">"
+	if a.Kind == ArgChoice {
+		name = strings.Join(a.Choices, "|")
+	}
+	if a.Variadic {
+		name = strings.TrimSuffix(name, ">") + "...>"
+	}
+	if a.Optional {
+		name = "[" + name + "]"
+	}
+
+	return name
+}
+
+func (f Flag) String() string {
+	switch {
+	case len(f.Choices) > 0:
+		return f.Option() + " " + strings.Join(f.Choices, "|")
+	case f.Value != "":
+		return f.Option() + " <" + f.Value + ">"
+	default:
+		return f.Option()
+	}
+}
+
+// Option returns the flag as it is typed, one-letter flags use a single dash
+func (f Flag) Option() string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+
+	return "--" + f.Name
+}
+
+func (f Flag) HasValue() bool {
+	return f.Value != "" || len(f.Choices) > 0
+}
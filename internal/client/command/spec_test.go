@@ -0,0 +1,136 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_Usage(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{name: "get", spec: NewGetCommand(nil, nil, nil).Spec(), want: "get [--copy] [--mask] <key> [login|password|number|holder|expire|cvc|text]"},
+		{name: "set", spec: NewSetCommand(nil, nil).Spec(), want: "set <key> <type> <value...>"},
+		{name: "meta", spec: NewMetaCommand(nil, nil).Spec(), want: "meta <key> [folder|note|favorite|url] [<value...>]"},
+		{name: "tag", spec: NewTagCommand(nil, nil).Spec(), want: "tag add|remove <key> <tag...>"},
+		{name: "list", spec: NewListCommand(nil, nil).Spec(), want: "list [--tag <tag>] [--folder <path>] [--favorite]"},
+		{name: "import", spec: NewImportCommand(nil, nil, nil).Spec(), want: "import [--mode merge|replace] [--password-fd <fd>] <path>"},
+		{name: "export", spec: NewExportCommand(nil, nil, nil).Spec(), want: "export [--plain] [--format json|csv] [--password-fd <fd>] <path>"},
+		{name: "run", spec: NewRunCommand(nil).Spec(), want: "run [--env <NAME=key[.field]>]... [--mask] <command...>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.spec.Usage())
+		})
+	}
+}
+
+func TestSpec_Arg(t *testing.T) {
+	spec := NewTagCommand(nil, nil).Spec()
+
+	arg, ok := spec.Arg(1)
+	assert.True(t, ok)
+	assert.Equal(t, ArgKey, arg.Kind)
+
+	arg, ok = spec.Arg(5)
+	assert.True(t, ok)
+	assert.Equal(t, "tag", arg.Name)
+
+	_, ok = NewGetCommand(nil, nil, nil).Spec().Arg(2)
+	assert.False(t, ok)
+}
+
+func TestSpec_Flag(t *testing.T) {
+	spec := NewListCommand(nil, nil).Spec()
+
+	flag, ok := spec.Flag("--tag")
+	assert.True(t, ok)
+	assert.True(t, flag.HasValue())
+
+	flag, ok = spec.Flag("favorite")
+	assert.True(t, ok)
+	assert.False(t, flag.HasValue())
+
+	_, ok = spec.Flag("--mode")
+	assert.False(t, ok)
+}
+
+func TestSpec_Parse(t *testing.T) {
+	get := NewGetCommand(nil, nil, nil).Spec()
+	run := NewRunCommand(nil).Spec()
+	imp := NewImportCommand(nil, nil, nil).Spec()
+
+	flags, err := get.Parse([]string{"github", "--copy=true", "login"})
+	require.NoError(t, err)
+	assert.True(t, flags.Bool("copy"))
+	assert.False(t, flags.Bool("mask"))
+	assert.Equal(t, []string{"github", "login"}, flags.Args())
+	assert.Equal(t, "", flags.Arg(2))
+
+	// everything after "--" is positional
+	flags, err = get.Parse([]string{"--mask", "--", "-key"})
+	require.NoError(t, err)
+	assert.True(t, flags.Bool("mask"))
+	assert.Equal(t, []string{"-key"}, flags.Args())
+
+	// a variadic argument takes the rest with its own flags
+	flags, err = run.Parse([]string{"--env", "A=a", "--env=B=b.password", "ls", "-la", "--mask"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A=a", "B=b.password"}, flags.Strings("env"))
+	assert.False(t, flags.Bool("mask"))
+	assert.Equal(t, []string{"ls", "-la", "--mask"}, flags.Args())
+
+	flags, err = imp.Parse([]string{"backup.gkb"})
+	require.NoError(t, err)
+	assert.Equal(t, importModeMerge, flags.String("mode"))
+
+	tests := []struct {
+		name string
+		spec Spec
+		args []string
+		err  string
+	}{
+		{name: "unknown flag", spec: get, args: []string{"--paste", "github"}, err: "args: flag provided but not defined: -paste"},
+		{name: "missing argument", spec: get, args: []string{"--mask"}, err: "args: <key> is required"},
+		{name: "unexpected argument", spec: get, args: []string{"a", "login", "b"}, err: `args: unexpected argument "b"`},
+		{name: "missing variadic argument", spec: run, args: []string{"--mask"}, err: "args: <command...> is required"},
+		{name: "invalid choice", spec: imp, args: []string{"--mode", "append", "a"}, err: "args: --mode must be merge or replace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.spec.Parse(tt.args)
+			assert.ErrorIs(t, err, ErrInvalidArgs)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestSpec_AbsPaths(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	got, err := NewImportFromCommand(nil, nil).Spec().AbsPaths([]string{"csv", "--map", "key=Name", "items.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--map=key=Name", "--", "csv", filepath.Join(wd, "items.csv")}, got)
+
+	got, err = NewRunCommand(nil).Spec().AbsPaths([]string{"--env", "A=a", "--env", "B=b", "./deploy.sh", "-v"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--env=A=a", "--env=B=b", "--", "./deploy.sh", "-v"}, got)
+
+	// commands without flags keep their args as is
+	got, err = NewSetCommand(nil, nil).Spec().AbsPaths([]string{"note", "text", "-not-a-flag"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"note", "text", "-not-a-flag"}, got)
+
+	got, err = SetArgs([]string{"note", "text", "photo.jpg"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"note", "text", "photo.jpg"}, got)
+}
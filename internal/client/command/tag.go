@@ -0,0 +1,83 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+)
+
+type TagsResult struct {
+	Key  string   `json:"key"`
+	Tags []string `json:"tags"`
+}
+
+func (r *TagsResult) Text() string {
+	return "tags: " + strings.Join(r.Tags, ", ")
+}
+
+type TagCommand struct {
+	dataManager DataStorage
+	keys        KeyProvider
+}
+
+func NewTagCommand(dataManager DataStorage, keys KeyProvider) *TagCommand {
+	return &TagCommand{
+		dataManager: dataManager,
+		keys:        keys,
+	}
+}
+
+func (c *TagCommand) Spec() Spec {
+	return Spec{
+		Name:     "tag",
+		Synopsis: "add or remove item tags",
+		Args: []Arg{
+			{Name: "action", Kind: ArgChoice, Choices: []string{"add", "remove"}},
+			{Name: "key", Kind: ArgKey},
+			{Name: "tag", Variadic: true},
+		},
+		Examples: []string{"tag add github work dev", "tag remove github dev"},
+	}
+}
+
+func (c *TagCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("%w: add|remove <key> <tag...>", ErrInvalidArgs)
+	}
+
+	masterPassword, err := c.keys.MasterPassword()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(masterPassword)
+
+	var update func(meta *metadata.Metadata) error
+	switch args[0] {
+	case "add":
+		update = func(meta *metadata.Metadata) error {
+			meta.AddTags(args[2:]...)
+			return nil
+		}
+	case "remove":
+		update = func(meta *metadata.Metadata) error {
+			meta.RemoveTags(args[2:]...)
+			return nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown tag action: %s", args[0])
+	}
+
+	meta, err := updateMetadata(ctx, c.dataManager, masterPassword, args[1], update)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := meta.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &TagsResult{Key: args[1], Tags: tags}, nil
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryStorage(items ...*model.UserData) (*mockDataStorage, map[string]*model.UserData) {
+	stored := make(map[string]*model.UserData)
+	for _, item := range items {
+		stored[item.DataKey] = item
+	}
+
+	return &mockDataStorage{
+		getFunc: func(ctx context.Context, key string) (*model.UserData, error) {
+			item, ok := stored[key]
+			if !ok {
+				return nil, manager.ErrNotFound
+			}
+			copied := *item
+			return &copied, nil
+		},
+		upsertFunc: func(ctx context.Context, data *model.UserData) error {
+			stored[data.DataKey] = data
+			return nil
+		},
+		listFunc: func(ctx context.Context) ([]*model.UserData, error) {
+			keys := make([]string, 0, len(stored))
+			for key, item := range stored {
+				if item.DeletedAt.Unix() <= 0 {
+					keys = append(keys, key)
+				}
+			}
+			slices.Sort(keys)
+
+			items := make([]*model.UserData, 0, len(keys))
+			for _, key := range keys {
+				copied := *stored[key]
+				items = append(items, &copied)
+			}
+			return items, nil
+		},
+	}, stored
+}
+
+func TestTagCommand_Execute(t *testing.T) {
+	password := []byte("1234567890abcdef")
+	storage, stored := newMemoryStorage(&model.UserData{DataKey: "key", DataValue: []byte("value")})
+	cmd := NewTagCommand(storage, staticKey(password))
+
+	got, err := cmd.Execute(context.Background(), []string{"add", "key", "prod", "db"})
+	require.NoError(t, err)
+	assert.Equal(t, "tags: db, prod", got.Text())
+	assert.Equal(t, []byte("value"), stored["key"].DataValue)
+	assert.False(t, stored["key"].UpdatedAt.IsZero())
+
+	got, err = cmd.Execute(context.Background(), []string{"remove", "key", "db"})
+	require.NoError(t, err)
+	assert.Equal(t, "tags: prod", got.Text())
+
+	meta, err := decryptMetadata(password, stored["key"])
+	require.NoError(t, err)
+	assert.Equal(t, &metadata.Metadata{Tags: []string{"prod"}}, meta)
+}
+
+func TestTagCommand_Execute_Errors(t *testing.T) {
+	storage, _ := newMemoryStorage()
+	cmd := NewTagCommand(storage, staticKey("1234567890abcdef"))
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing args", args: []string{"add", "key"}},
+		{name: "unknown action", args: []string{"rename", "key", "tag"}},
+		{name: "unknown key", args: []string{"add", "missing", "tag"}},
+		{name: "invalid tag", args: []string{"add", "missing", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.Execute(context.Background(), tt.args)
+			assert.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"errors"
+)
+
+type VaultUnlocker interface {
+	Unlock(ctx context.Context, password []byte) error
+}
+
+type UnlockCommand struct {
+	vault    VaultUnlocker
+	prompter PasswordPrompter
+}
+
+func NewUnlockCommand(vault VaultUnlocker, prompter PasswordPrompter) *UnlockCommand {
+	return &UnlockCommand{
+		vault:    vault,
+		prompter: prompter,
+	}
+}
+
+func (c *UnlockCommand) Spec() Spec {
+	return Spec{
+		Name:     "unlock",
+		Synopsis: "unlock the vault, asks for the master password",
+	}
+}
+
+func (c *UnlockCommand) Execute(ctx context.Context, args []string) (Result, error) {
+	if c.prompter == nil {
+		return nil, errors.New("unlock requires an interactive terminal")
+	}
+
+	password, err := c.prompter.Password("Master password: ")
+	if err != nil {
+		return nil, err
+	}
+	defer clear(password)
+
+	if err := c.vault.Unlock(ctx, password); err != nil {
+		return nil, err
+	}
+
+	return Message("vault unlocked"), nil
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlockCommand_Execute(t *testing.T) {
+	vault := &mockVault{locked: true}
+	prompter := &mockPrompter{password: []byte("master")}
+
+	got, err := NewUnlockCommand(vault, prompter).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, Message("vault unlocked"), got)
+	assert.False(t, vault.locked)
+	assert.Equal(t, []byte("master"), vault.password)
+	assert.Equal(t, "Master password: ", prompter.message)
+	assert.Equal(t, make([]byte, 6), prompter.password)
+}
+
+func TestUnlockCommand_Execute_Errors(t *testing.T) {
+	_, err := NewUnlockCommand(&mockVault{}, nil).Execute(context.Background(), nil)
+	assert.EqualError(t, err, "unlock requires an interactive terminal")
+
+	_, err = NewUnlockCommand(&mockVault{}, &mockPrompter{err: errors.New("no tty")}).Execute(context.Background(), nil)
+	assert.EqualError(t, err, "no tty")
+
+	vault := &mockVault{locked: true, err: errors.New("invalid master password")}
+	_, err = NewUnlockCommand(vault, &mockPrompter{password: []byte("wrong")}).Execute(context.Background(), nil)
+	assert.EqualError(t, err, "invalid master password")
+	assert.True(t, vault.locked)
+}
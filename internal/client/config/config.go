@@ -1,40 +1,274 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
+)
+
+const (
+	MasterPasswordEnv = "GOPHKEEPER_MASTER_PASSWORD"
+	AgentSocketEnv    = "GOPHKEEPER_AGENT_SOCK"
+
+	// DBPathEnv and PasswordCommandEnv configure the docker credential helper, docker passes it no options
+	DBPathEnv          = "GOPHKEEPER_DB"
+	PasswordCommandEnv = "GOPHKEEPER_PASSWORD_COMMAND"
+
+	DockerCredentialHelper = "docker-credential-gophkeeper"
 )
 
 type Config struct {
-	DBPath          string
-	MasterPassword  string
-	ServerAddr      string
-	SyncIntervalSec int
+	DBPath            string
+	MasterPassword    string
+	AllowArgvPassword bool
+	PasswordFD        int
+	PasswordEnv       string
+	PasswordCommand   string
+	ServerAddr        string
+	TLS               bool
+	TLSCAFile         string
+	TLSServerName     string
+	TLSCertFile       string
+	TLSKeyFile        string
+	TLSPins           []string
+	SyncIntervalSec   int
+	IdleLock          time.Duration
+	ClipboardTimeout  time.Duration
+	Sync              bool
+	Output            string
+	Completion        string
+	Agent             bool
+	AgentSocket       string
+	HTTPPort          int
+	HTTPOrigins       []string
+	MetricsAddr       string
+	TracesExporter    string
+	DockerCredential  bool
+	Command           []string
+}
+
+type Flag struct {
+	Name     string
+	HasValue bool
+	Choices  []string
+	Usage    string
+}
+
+var flagChoices = map[string][]string{
+	"output":     {"table", "json", "yaml"},
+	"completion": {"bash", "zsh", "fish"},
 }
 
 func ParseArgs() (*Config, error) {
+	// the binary is symlinked as docker-credential-gophkeeper to act as a docker credential helper
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") == DockerCredentialHelper {
+		return parseDockerCredential(os.Args[1:])
+	}
+
 	var cfg Config
 
-	flag.StringVar(&cfg.ServerAddr, "addr", "localhost:50501", "server address (host:port)")
-	flag.IntVar(&cfg.SyncIntervalSec, "interval", 60, "synchronization interval in seconds")
+	defineFlags(flag.CommandLine, &cfg)
 
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [options] <db_path> <master_password>\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [options] <db_path> [command [args...]]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s -agent [options] <db_path>\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s -agent-socket <path> [command [args...]]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s -completion bash|zsh|fish\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "without a command an interactive shell is started")
+		fmt.Fprintf(flag.CommandLine.Output(), "master password is read from -password-fd, -password-command, $%s or terminal\n", MasterPasswordEnv)
 		flag.PrintDefaults()
 	}
 
 	flag.Parse()
 
+	if cfg.Completion != "" {
+		return &cfg, nil
+	}
+
 	args := flag.Args()
-	if len(args) != 2 {
+	// the agent exports its socket to the whole shell, a db path still selects the local database
+	if cfg.AgentSocket == "" && (cfg.Agent || len(args) == 0 || !isDBPath(args[0])) {
+		cfg.AgentSocket = os.Getenv(AgentSocketEnv)
+	}
+	// with a running agent the whole argument list is a command
+	if !cfg.Agent && cfg.AgentSocket != "" {
+		cfg.Command = args
+
+		return &cfg, nil
+	}
+
+	if len(args) < 1 {
 		flag.Usage()
 
 		return nil, fmt.Errorf("invalid arguments")
 	}
 
+	if err := cfg.validatePasswordSource(); err != nil {
+		return nil, err
+	}
+
 	cfg.DBPath = args[0]
-	cfg.MasterPassword = args[1]
+	cfg.Command = args[1:]
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, errors.New("-tls-cert and -tls-key must be used together")
+	}
+
+	if cfg.HTTPPort < 0 || cfg.HTTPPort > 65535 {
+		return nil, fmt.Errorf("invalid HTTP API port: %d", cfg.HTTPPort)
+	}
+	if cfg.HTTPPort > 0 && len(cfg.Command) > 0 {
+		return nil, errors.New("HTTP API is only available in the interactive shell or agent")
+	}
+
+	if err := tracing.ValidateExporter(cfg.TracesExporter); err != nil {
+		return nil, fmt.Errorf("-traces: %w", err)
+	}
+
+	if cfg.MetricsAddr != "" && len(cfg.Command) > 0 {
+		return nil, errors.New("metrics are only available in the interactive shell or agent")
+	}
+
+	if cfg.Agent {
+		if len(cfg.Command) > 0 {
+			return nil, errors.New("agent mode does not accept a command")
+		}
+		if cfg.AgentSocket == "" {
+			cfg.AgentSocket = DefaultAgentSocket()
+		}
+	}
 
 	return &cfg, nil
 }
+
+func parseDockerCredential(args []string) (*Config, error) {
+	var cfg Config
+	defineFlags(flag.NewFlagSet(DockerCredentialHelper, flag.ContinueOnError), &cfg)
+	cfg.DockerCredential = true
+	cfg.Command = append([]string{"docker-credential"}, args...)
+
+	cfg.AgentSocket = os.Getenv(AgentSocketEnv)
+	if cfg.AgentSocket != "" {
+		return &cfg, nil
+	}
+
+	cfg.DBPath = os.Getenv(DBPathEnv)
+	if cfg.DBPath == "" {
+		return nil, fmt.Errorf("%s requires $%s or $%s", DockerCredentialHelper, AgentSocketEnv, DBPathEnv)
+	}
+	cfg.PasswordCommand = os.Getenv(PasswordCommandEnv)
+
+	return &cfg, nil
+}
+
+func (c *Config) TLSEnabled() bool {
+	return c.TLS || c.TLSCAFile != "" || c.TLSServerName != "" || c.TLSCertFile != "" || len(c.TLSPins) > 0
+}
+
+func (c *Config) validatePasswordSource() error {
+	if c.MasterPassword != "" && !c.AllowArgvPassword {
+		return errors.New("master password in arguments is insecure, use -password-fd, -password-command, " +
+			"$" + MasterPasswordEnv + " or terminal prompt (or -insecure-password-argv)")
+	}
+
+	sources := 0
+	for _, defined := range []bool{c.MasterPassword != "", c.PasswordFD >= 0, c.PasswordCommand != ""} {
+		if defined {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.New("only one of -password, -password-fd, -password-command can be used")
+	}
+
+	return nil
+}
+
+func defineFlags(flags *flag.FlagSet, cfg *Config) {
+	flags.StringVar(&cfg.ServerAddr, "addr", "localhost:50501", "server address (host:port)")
+	flags.BoolVar(&cfg.TLS, "tls", false, "connect to server with TLS, implied by the other -tls-* options")
+	flags.StringVar(&cfg.TLSCAFile, "tls-ca", "", "CA bundle to verify the server certificate instead of system roots")
+	flags.StringVar(&cfg.TLSServerName, "tls-server-name", "", "server name to verify the certificate against, host of -addr by default")
+	flags.StringVar(&cfg.TLSCertFile, "tls-cert", "", "client certificate for mutual TLS")
+	flags.StringVar(&cfg.TLSKeyFile, "tls-key", "", "client certificate key for mutual TLS")
+	flags.Func("tls-pin", "comma-separated base64 SHA-256 hashes of the server public key (SPKI), without -tls-ca only the pin is checked", func(s string) error {
+		for _, pin := range strings.Split(s, ",") {
+			if pin = strings.TrimSpace(pin); pin != "" {
+				cfg.TLSPins = append(cfg.TLSPins, pin)
+			}
+		}
+		return nil
+	})
+	flags.IntVar(&cfg.SyncIntervalSec, "interval", 60, "synchronization interval in seconds")
+	flags.StringVar(&cfg.Output, "output", "table", "output format: table, json or yaml")
+	flags.DurationVar(&cfg.IdleLock, "idle-lock", 15*time.Minute, "lock the vault after inactivity, 0 disables auto-lock")
+	flags.DurationVar(&cfg.ClipboardTimeout, "clipboard-timeout", 30*time.Second, "clear the clipboard after get --copy, 0 keeps the value")
+	flags.BoolVar(&cfg.Sync, "sync", false, "synchronize with server before and after a one-shot command")
+	flags.IntVar(&cfg.PasswordFD, "password-fd", -1, "read master password from file descriptor")
+	flags.StringVar(&cfg.PasswordEnv, "password-env", MasterPasswordEnv, "read master password from environment variable")
+	flags.StringVar(&cfg.PasswordCommand, "password-command", "", "read master password from command output")
+	flags.StringVar(&cfg.MasterPassword, "password", "", "master password, requires -insecure-password-argv")
+	flags.BoolVar(&cfg.AllowArgvPassword, "insecure-password-argv", false, "allow -password, it leaks to shell history and process list")
+
+	flags.BoolVar(&cfg.Agent, "agent", false, "run a background agent that keeps the vault unlocked and synchronized")
+	flags.StringVar(&cfg.AgentSocket, "agent-socket", "", "agent unix socket, commands are sent to the agent when set, $"+AgentSocketEnv+" is used without a db path")
+
+	flags.IntVar(&cfg.HTTPPort, "http-port", 0, "serve the local HTTP API on 127.0.0.1:port in the interactive shell or agent, 0 disables it")
+	flags.Func("http-origins", "comma-separated origins allowed to call the HTTP API, e.g. chrome-extension://<id>", func(s string) error {
+		for _, origin := range strings.Split(s, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.HTTPOrigins = append(cfg.HTTPOrigins, origin)
+			}
+		}
+		return nil
+	})
+
+	flags.StringVar(&cfg.MetricsAddr, "metrics-addr", "", "serve Prometheus metrics on host:port in the interactive shell or agent, e.g. 127.0.0.1:9464")
+
+	flags.StringVar(&cfg.TracesExporter, "traces", "", "export sync traces: otlp (configured by OTEL_EXPORTER_OTLP_* variables), stdout or file:<path>")
+
+	flags.StringVar(&cfg.Completion, "completion", "", "print shell completion script: bash, zsh or fish")
+}
+
+// isDBPath tells a database from a command name, command names have neither extensions nor directories
+func isDBPath(arg string) bool {
+	if filepath.Ext(arg) != "" || strings.ContainsRune(arg, filepath.Separator) || strings.ContainsRune(arg, '/') {
+		return true
+	}
+	_, err := os.Stat(arg)
+
+	return err == nil
+}
+
+func DefaultAgentSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "gophkeeper", "agent.sock")
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gophkeeper-%d", os.Getuid()), "agent.sock")
+}
+
+// Flags describes the global options for shell completion scripts
+func Flags() []Flag {
+	flags := flag.NewFlagSet("", flag.ContinueOnError)
+	defineFlags(flags, &Config{})
+
+	var result []Flag
+	flags.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		result = append(result, Flag{
+			Name:     f.Name,
+			HasValue: !ok || !boolFlag.IsBoolFlag(),
+			Choices:  flagChoices[f.Name],
+			Usage:    f.Usage,
+		})
+	})
+
+	return result
+}
@@ -2,10 +2,14 @@ package config
 
 import (
 	"flag"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseArgs_Success(t *testing.T) {
@@ -18,14 +22,22 @@ func TestParseArgs_Success(t *testing.T) {
 
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
-	os.Args = []string{"app", "test.db", "secret123"}
+	os.Args = []string{"app", "test.db"}
 	cfg, err := ParseArgs()
 
 	assert.NoError(t, err)
 	assert.Equal(t, "test.db", cfg.DBPath)
-	assert.Equal(t, "secret123", cfg.MasterPassword)
+	assert.Equal(t, "", cfg.MasterPassword)
+	assert.Equal(t, -1, cfg.PasswordFD)
+	assert.Equal(t, MasterPasswordEnv, cfg.PasswordEnv)
+	assert.Equal(t, "", cfg.PasswordCommand)
 	assert.Equal(t, "localhost:50501", cfg.ServerAddr)
 	assert.Equal(t, 60, cfg.SyncIntervalSec)
+	assert.Equal(t, 15*time.Minute, cfg.IdleLock)
+	assert.Equal(t, 30*time.Second, cfg.ClipboardTimeout)
+	assert.Equal(t, "table", cfg.Output)
+	assert.False(t, cfg.TLSEnabled())
+	assert.Empty(t, cfg.Command)
 }
 
 func TestParseArgs_WithFlags(t *testing.T) {
@@ -38,14 +50,54 @@ func TestParseArgs_WithFlags(t *testing.T) {
 
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
-	os.Args = []string{"app", "-addr", "127.0.0.1:8080", "-interval", "30", "custom.db", "pass456"}
+	os.Args = []string{"app", "-addr", "127.0.0.1:8080", "-interval", "30", "-idle-lock", "90s", "-password-command", "pass show vault", "custom.db"}
 	cfg, err := ParseArgs()
 
 	assert.NoError(t, err)
 	assert.Equal(t, "custom.db", cfg.DBPath)
-	assert.Equal(t, "pass456", cfg.MasterPassword)
+	assert.Equal(t, "pass show vault", cfg.PasswordCommand)
 	assert.Equal(t, "127.0.0.1:8080", cfg.ServerAddr)
 	assert.Equal(t, 30, cfg.SyncIntervalSec)
+	assert.Equal(t, 90*time.Second, cfg.IdleLock)
+}
+
+func TestParseArgs_Command(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-sync", "-output", "json", "-password-fd", "3", "test.db", "list", "--tag", "work"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.Sync)
+	assert.Equal(t, "json", cfg.Output)
+	assert.Equal(t, 3, cfg.PasswordFD)
+	assert.Equal(t, "test.db", cfg.DBPath)
+	assert.Equal(t, []string{"list", "--tag", "work"}, cfg.Command)
+}
+
+func TestParseArgs_ArgvPassword(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-insecure-password-argv", "-password", "secret123", "test.db"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "secret123", cfg.MasterPassword)
+	assert.True(t, cfg.AllowArgvPassword)
 }
 
 func TestParseArgs_InvalidArguments(t *testing.T) {
@@ -58,12 +110,36 @@ func TestParseArgs_InvalidArguments(t *testing.T) {
 			args: []string{"app"},
 		},
 		{
-			name: "one argument",
-			args: []string{"app", "test.db"},
+			name: "argv password without opt-in",
+			args: []string{"app", "-password", "secret123", "test.db"},
+		},
+		{
+			name: "client certificate without key",
+			args: []string{"app", "-tls-cert", "client.crt", "test.db"},
+		},
+		{
+			name: "http api with command",
+			args: []string{"app", "-http-port", "7277", "test.db", "list"},
+		},
+		{
+			name: "invalid http port",
+			args: []string{"app", "-http-port", "70000", "test.db"},
+		},
+		{
+			name: "metrics with command",
+			args: []string{"app", "-metrics-addr", "127.0.0.1:9464", "test.db", "list"},
+		},
+		{
+			name: "unknown traces exporter",
+			args: []string{"app", "-traces", "jaeger", "test.db"},
+		},
+		{
+			name: "agent with command",
+			args: []string{"app", "-agent", "test.db", "list"},
 		},
 		{
-			name: "too many arguments",
-			args: []string{"app", "test.db", "secret123", "extra_arg"},
+			name: "several password sources",
+			args: []string{"app", "-password-fd", "3", "-password-command", "pass show vault", "test.db"},
 		},
 	}
 
@@ -77,6 +153,7 @@ func TestParseArgs_InvalidArguments(t *testing.T) {
 			}()
 
 			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+			flag.CommandLine.SetOutput(io.Discard)
 
 			os.Args = tc.args
 			cfg, err := ParseArgs()
@@ -86,3 +163,214 @@ func TestParseArgs_InvalidArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgs_Completion(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-completion", "zsh"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "zsh", cfg.Completion)
+	assert.Equal(t, "", cfg.DBPath)
+}
+
+func TestParseArgs_Agent(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	t.Setenv(AgentSocketEnv, "")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-agent", "test.db"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.Agent)
+	assert.Equal(t, "test.db", cfg.DBPath)
+	assert.Equal(t, "/run/user/1000/gophkeeper/agent.sock", cfg.AgentSocket)
+}
+
+func TestParseArgs_AgentClient(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	t.Setenv(AgentSocketEnv, "/tmp/agent.sock")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "get", "github"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.False(t, cfg.Agent)
+	assert.Equal(t, "/tmp/agent.sock", cfg.AgentSocket)
+	assert.Equal(t, "", cfg.DBPath)
+	assert.Equal(t, []string{"get", "github"}, cfg.Command)
+}
+
+func TestParseArgs_AgentClientWithDBPath(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	// the socket exported by the agent doesn't hide a local database
+	t.Setenv(AgentSocketEnv, "/tmp/agent.sock")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "vault.db", "get", "github"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", cfg.AgentSocket)
+	assert.Equal(t, "vault.db", cfg.DBPath)
+	assert.Equal(t, []string{"get", "github"}, cfg.Command)
+
+	// an explicit socket always wins
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	os.Args = []string{"app", "-agent-socket", "/tmp/other.sock", "get", "vault.db"}
+	cfg, err = ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/other.sock", cfg.AgentSocket)
+	assert.Equal(t, []string{"get", "vault.db"}, cfg.Command)
+}
+
+func TestIsDBPath(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "vault")
+	require.NoError(t, os.WriteFile(existing, nil, 0600))
+
+	assert.True(t, isDBPath("vault.db"))
+	assert.True(t, isDBPath("data/vault"))
+	assert.True(t, isDBPath(existing))
+	assert.False(t, isDBPath("get"))
+	assert.False(t, isDBPath("import-from"))
+}
+
+func TestParseArgs_TLS(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	t.Setenv(AgentSocketEnv, "")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-tls-ca", "ca.crt", "-tls-server-name", "keeper.local", "-tls-cert", "client.crt", "-tls-key", "client.key", "-tls-pin", "AAAA,BBBB", "test.db"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.TLSEnabled())
+	assert.False(t, cfg.TLS)
+	assert.Equal(t, "ca.crt", cfg.TLSCAFile)
+	assert.Equal(t, "keeper.local", cfg.TLSServerName)
+	assert.Equal(t, "client.crt", cfg.TLSCertFile)
+	assert.Equal(t, "client.key", cfg.TLSKeyFile)
+	assert.Equal(t, []string{"AAAA", "BBBB"}, cfg.TLSPins)
+}
+
+func TestParseArgs_HTTP(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	t.Setenv(AgentSocketEnv, "")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-http-port", "7277", "-http-origins", "chrome-extension://abc, moz-extension://def", "test.db"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7277, cfg.HTTPPort)
+	assert.Equal(t, []string{"chrome-extension://abc", "moz-extension://def"}, cfg.HTTPOrigins)
+}
+
+func TestParseArgs_Telemetry(t *testing.T) {
+	oldArgs := os.Args
+	oldFlagCommandLine := flag.CommandLine
+	defer func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldFlagCommandLine
+	}()
+
+	t.Setenv(AgentSocketEnv, "")
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	os.Args = []string{"app", "-agent", "-metrics-addr", "127.0.0.1:9464", "-traces", "file:/tmp/traces.json", "test.db"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9464", cfg.MetricsAddr)
+	assert.Equal(t, "file:/tmp/traces.json", cfg.TracesExporter)
+}
+
+func TestParseArgs_DockerCredential(t *testing.T) {
+	oldArgs := os.Args
+	defer func() {
+		os.Args = oldArgs
+	}()
+
+	t.Setenv(AgentSocketEnv, "")
+	t.Setenv(DBPathEnv, "/var/lib/gophkeeper/vault.db")
+	t.Setenv(PasswordCommandEnv, "cat /run/secrets/vault")
+
+	os.Args = []string{"/usr/local/bin/docker-credential-gophkeeper", "get"}
+	cfg, err := ParseArgs()
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.DockerCredential)
+	assert.Equal(t, "/var/lib/gophkeeper/vault.db", cfg.DBPath)
+	assert.Equal(t, "cat /run/secrets/vault", cfg.PasswordCommand)
+	assert.Equal(t, "table", cfg.Output)
+	assert.Equal(t, []string{"docker-credential", "get"}, cfg.Command)
+
+	t.Setenv(AgentSocketEnv, "/tmp/agent.sock")
+	cfg, err = ParseArgs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/agent.sock", cfg.AgentSocket)
+	assert.Equal(t, "", cfg.DBPath)
+
+	t.Setenv(AgentSocketEnv, "")
+	t.Setenv(DBPathEnv, "")
+	_, err = ParseArgs()
+
+	assert.EqualError(t, err, "docker-credential-gophkeeper requires $GOPHKEEPER_AGENT_SOCK or $GOPHKEEPER_DB")
+}
+
+func TestFlags(t *testing.T) {
+	flags := make(map[string]Flag)
+	for _, flag := range Flags() {
+		flags[flag.Name] = flag
+	}
+
+	assert.True(t, flags["addr"].HasValue)
+	assert.False(t, flags["sync"].HasValue)
+	assert.False(t, flags["insecure-password-argv"].HasValue)
+	assert.Equal(t, []string{"table", "json", "yaml"}, flags["output"].Choices)
+	assert.Equal(t, "server address (host:port)", flags["addr"].Usage)
+}
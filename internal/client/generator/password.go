@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	MinLength     = 8
+	MaxLength     = 128
+	DefaultLength = 20
+
+	lower   = "abcdefghijklmnopqrstuvwxyz"
+	upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits  = "0123456789"
+	symbols = "!@#$%^&*()-_=+[]{};:,.?"
+)
+
+var ErrInvalidLength = errors.New("invalid password length")
+
+type Options struct {
+	Length  int
+	Digits  bool
+	Symbols bool
+}
+
+// Password generates a password with at least one character of every enabled class
+func Password(opts Options) (string, error) {
+	if opts.Length < MinLength || opts.Length > MaxLength {
+		return "", fmt.Errorf("%w: %d, expected %d-%d", ErrInvalidLength, opts.Length, MinLength, MaxLength)
+	}
+
+	classes := []string{lower, upper}
+	if opts.Digits {
+		classes = append(classes, digits)
+	}
+	if opts.Symbols {
+		classes = append(classes, symbols)
+	}
+	alphabet := strings.Join(classes, "")
+
+	password := make([]byte, 0, opts.Length)
+	for _, class := range classes {
+		c, err := pick(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for len(password) < opts.Length {
+		c, err := pick(alphabet)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// the required characters must not always be at the beginning
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func pick(alphabet string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+	if err != nil {
+		return 0, err
+	}
+
+	return alphabet[i.Int64()], nil
+}
@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassword(t *testing.T) {
+	for range 100 {
+		password, err := Password(Options{Length: MinLength, Digits: true, Symbols: true})
+		require.NoError(t, err)
+		assert.Len(t, password, MinLength)
+		assert.True(t, strings.ContainsAny(password, lower))
+		assert.True(t, strings.ContainsAny(password, upper))
+		assert.True(t, strings.ContainsAny(password, digits))
+		assert.True(t, strings.ContainsAny(password, symbols))
+	}
+}
+
+func TestPassword_Letters(t *testing.T) {
+	password, err := Password(Options{Length: DefaultLength})
+	require.NoError(t, err)
+	assert.Len(t, password, DefaultLength)
+	assert.False(t, strings.ContainsAny(password, digits+symbols))
+}
+
+func TestPassword_InvalidLength(t *testing.T) {
+	_, err := Password(Options{Length: MinLength - 1})
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = Password(Options{Length: MaxLength + 1})
+	assert.ErrorIs(t, err, ErrInvalidLength)
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -16,13 +18,26 @@ type Client struct {
 	conn       *grpc.ClientConn
 	AuthClient proto.AuthServiceClient
 	DataClient proto.DataServiceClient
+	InfoClient proto.InfoServiceClient
 	authToken  string
 }
 
-func NewClient(serverAddr string) (*Client, error) {
+// NewClient connects with TLS when tlsConfig is set and in cleartext otherwise
+func NewClient(serverAddr string, tlsConfig *TLSConfig) (*Client, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		config, err := tlsConfig.Config()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(config)
+	}
+
 	conn, err := grpc.NewClient(
 		serverAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		// propagates the trace context of the sync to the server
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, err
@@ -32,6 +47,7 @@ func NewClient(serverAddr string) (*Client, error) {
 		conn:       conn,
 		AuthClient: proto.NewAuthServiceClient(conn),
 		DataClient: proto.NewDataServiceClient(conn),
+		InfoClient: proto.NewInfoServiceClient(conn),
 	}, nil
 }
 
@@ -39,6 +55,10 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
+func (c *Client) SetAuthToken(token string) {
+	c.authToken = token
+}
+
 func (c *Client) Login(ctx context.Context, login, password string, masterPassword []byte) (string, error) {
 	resp, err := c.AuthClient.Login(ctx, &proto.LoginRequest{
 		Login:          login,
@@ -70,6 +90,7 @@ func (c *Client) Upsert(ctx context.Context, data *model.UserData) (*proto.DataR
 	return c.DataClient.Upsert(ctx, &proto.UpsertRequest{
 		DataKey:   data.DataKey,
 		DataValue: data.DataValue,
+		DataMeta:  data.DataMeta,
 		UpdatedAt: timestamppb.New(data.UpdatedAt),
 		DeletedAt: timestamppb.New(data.DeletedAt),
 	})
@@ -82,6 +103,11 @@ func (c *Client) GetUpdates(ctx context.Context, updatedAfter time.Time) (*proto
 	})
 }
 
+// ServerInfo is public, so it works before login
+func (c *Client) ServerInfo(ctx context.Context) (*proto.ServerInfoResponse, error) {
+	return c.InfoClient.ServerInfo(ctx, &proto.ServerInfoRequest{})
+}
+
 func (c *Client) withAuth(ctx context.Context) context.Context {
 	if c.authToken != "" {
 		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.authToken)
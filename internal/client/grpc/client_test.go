@@ -44,6 +44,14 @@ func (m *mockDataServiceClient) GetUpdates(ctx context.Context, in *proto.GetUpd
 	return m.getUpdatesFunc(ctx, in, opts...)
 }
 
+type mockInfoServiceClient struct {
+	serverInfoFunc func(ctx context.Context, in *proto.ServerInfoRequest, opts ...grpc.CallOption) (*proto.ServerInfoResponse, error)
+}
+
+func (m *mockInfoServiceClient) ServerInfo(ctx context.Context, in *proto.ServerInfoRequest, opts ...grpc.CallOption) (*proto.ServerInfoResponse, error) {
+	return m.serverInfoFunc(ctx, in, opts...)
+}
+
 func TestNewClient(t *testing.T) {
 	lis := bufconn.Listen(1024 * 1024)
 	s := grpc.NewServer()
@@ -126,6 +134,26 @@ func TestClient_Login_Error(t *testing.T) {
 	assert.Empty(t, client.authToken)
 }
 
+func TestClient_ServerInfo(t *testing.T) {
+	expected := &proto.ServerInfoResponse{Version: "v1.2.0", ProtocolVersion: 1}
+	mockInfo := &mockInfoServiceClient{
+		serverInfoFunc: func(ctx context.Context, in *proto.ServerInfoRequest, opts ...grpc.CallOption) (*proto.ServerInfoResponse, error) {
+			_, ok := metadata.FromOutgoingContext(ctx)
+			assert.False(t, ok, "server info must not send the token")
+			return expected, nil
+		},
+	}
+
+	client := &Client{
+		InfoClient: mockInfo,
+		authToken:  "test-token",
+	}
+
+	info, err := client.ServerInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, info)
+}
+
 func TestClient_Register(t *testing.T) {
 	expectedToken := "test-token"
 	mockAuth := &mockAuthServiceClient{
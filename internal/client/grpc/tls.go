@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+var ErrPinMismatch = errors.New("server public key doesn`t match any pin")
+
+type TLSConfig struct {
+	CAFile     string
+	ServerName string
+	CertFile   string
+	KeyFile    string
+	// Pins are base64 SHA-256 hashes of the SubjectPublicKeyInfo, optionally prefixed with sha256/
+	Pins []string
+}
+
+// Config builds a client TLS config. With pins and without a CA bundle the chain is not verified,
+// the pin alone authenticates the server, so self-signed certificates can be used.
+func (c *TLSConfig) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" {
+		raw, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can`t read CA bundle: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(raw) {
+			return nil, errors.New("CA bundle contains no certificates")
+		}
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can`t load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(c.Pins) == 0 {
+		return config, nil
+	}
+
+	pins := make([][]byte, 0, len(c.Pins))
+	for _, pin := range c.Pins {
+		pin = strings.TrimPrefix(strings.TrimPrefix(pin, "sha256/"), "/")
+		hash, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid pin %q, expected base64 SHA-256", pin)
+		}
+		pins = append(pins, hash)
+	}
+
+	pinOnly := config.RootCAs == nil
+	config.InsecureSkipVerify = pinOnly
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		// an unverified chain can contain any certificate, so only the leaf is trusted
+		candidates := state.PeerCertificates[:1]
+		if !pinOnly {
+			candidates = slices.Concat(state.VerifiedChains...)
+		}
+
+		for _, cert := range candidates {
+			hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			for _, pin := range pins {
+				if subtle.ConstantTimeCompare(hash[:], pin) == 1 {
+					return nil
+				}
+			}
+		}
+
+		return ErrPinMismatch
+	}
+
+	return config, nil
+}
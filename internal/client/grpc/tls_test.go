@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, name string, isCA bool, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) pin() string {
+	hash := sha256.Sum256(c.cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func (c *testCert) write(t *testing.T, name string) (string, string) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	rawKey, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}), 0600))
+
+	return certFile, keyFile
+}
+
+// handshake connects with the client config to a server presenting the chain
+func handshake(t *testing.T, clientConfig *TLSConfig, chain []*testCert, clientCAs *x509.CertPool) error {
+	config, err := clientConfig.Config()
+	require.NoError(t, err)
+
+	serverCert := tls.Certificate{PrivateKey: chain[0].key}
+	for _, cert := range chain {
+		serverCert.Certificate = append(serverCert.Certificate, cert.cert.Raw)
+	}
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	if clientCAs != nil {
+		serverConfig.ClientCAs = clientCAs
+		serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+	if err != nil {
+		<-serverErr
+		return err
+	}
+	defer conn.Close()
+
+	return <-serverErr
+}
+
+func TestTLSConfig(t *testing.T) {
+	ca := newTestCert(t, "ca", true, nil)
+	server := newTestCert(t, "keeper.local", false, ca)
+	selfSigned := newTestCert(t, "keeper.local", false, nil)
+	caFile, _ := ca.write(t, "ca")
+
+	tests := []struct {
+		name    string
+		config  *TLSConfig
+		chain   []*testCert
+		wantErr error
+	}{
+		{name: "ca", config: &TLSConfig{CAFile: caFile, ServerName: "keeper.local"}, chain: []*testCert{server}},
+		{name: "ca and leaf pin", config: &TLSConfig{CAFile: caFile, ServerName: "keeper.local", Pins: []string{server.pin()}}, chain: []*testCert{server}},
+		{name: "ca and ca pin", config: &TLSConfig{CAFile: caFile, ServerName: "keeper.local", Pins: []string{"sha256/" + ca.pin()}}, chain: []*testCert{server}},
+		{name: "pin of self-signed", config: &TLSConfig{Pins: []string{"sha256//" + selfSigned.pin()}}, chain: []*testCert{selfSigned}},
+		{name: "wrong pin", config: &TLSConfig{CAFile: caFile, ServerName: "keeper.local", Pins: []string{selfSigned.pin()}}, chain: []*testCert{server}, wantErr: ErrPinMismatch},
+		{name: "pinned ca in unverified chain", config: &TLSConfig{Pins: []string{ca.pin()}}, chain: []*testCert{selfSigned, ca}, wantErr: ErrPinMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handshake(t, tt.config, tt.chain, nil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.Error(t, handshake(t, &TLSConfig{CAFile: caFile, ServerName: "other.local"}, []*testCert{server}, nil))
+	assert.Error(t, handshake(t, &TLSConfig{CAFile: caFile, ServerName: "keeper.local"}, []*testCert{selfSigned}, nil))
+}
+
+func TestTLSConfig_ClientCertificate(t *testing.T) {
+	ca := newTestCert(t, "ca", true, nil)
+	server := newTestCert(t, "keeper.local", false, ca)
+	caFile, _ := ca.write(t, "ca")
+	certFile, keyFile := newTestCert(t, "client", false, ca).write(t, "client")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	config := &TLSConfig{CAFile: caFile, ServerName: "keeper.local", CertFile: certFile, KeyFile: keyFile}
+	assert.NoError(t, handshake(t, config, []*testCert{server}, clientCAs))
+
+	config = &TLSConfig{CAFile: caFile, ServerName: "keeper.local"}
+	assert.Error(t, handshake(t, config, []*testCert{server}, clientCAs))
+}
+
+func TestNewClient_TLS(t *testing.T) {
+	ca := newTestCert(t, "ca", true, nil)
+	server := newTestCert(t, "keeper.local", false, ca)
+	caFile, _ := ca.write(t, "ca")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serverCert := tls.Certificate{Certificate: [][]byte{server.cert.Raw}, PrivateKey: server.key}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}})))
+	proto.RegisterAuthServiceServer(s, proto.UnimplementedAuthServiceServer{})
+	go s.Serve(listener)
+	defer s.Stop()
+
+	login := func(config *TLSConfig) codes.Code {
+		client, err := NewClient(listener.Addr().String(), config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = client.Login(ctx, "user", "pass", []byte("master"))
+
+		return status.Code(err)
+	}
+
+	// the request reached the server, so the handshake succeeded
+	assert.Equal(t, codes.Unimplemented, login(&TLSConfig{CAFile: caFile, ServerName: "keeper.local"}))
+	assert.Equal(t, codes.Unavailable, login(&TLSConfig{Pins: []string{ca.pin()}}))
+	assert.Equal(t, codes.Unavailable, login(nil))
+}
+
+func TestTLSConfig_Invalid(t *testing.T) {
+	_, err := (&TLSConfig{Pins: []string{"not base64"}}).Config()
+	assert.Error(t, err)
+
+	_, err = (&TLSConfig{Pins: []string{base64.StdEncoding.EncodeToString([]byte("short"))}}).Config()
+	assert.Error(t, err)
+
+	_, err = (&TLSConfig{CAFile: "missing.crt"}).Config()
+	assert.Error(t, err)
+
+	_, err = (&TLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"}).Config()
+	assert.Error(t, err)
+}
@@ -0,0 +1,351 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/generator"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+	maxBodySize       = 1 << 10
+)
+
+type DataGetter interface {
+	Get(ctx context.Context, key string) (*model.UserData, error)
+	List(ctx context.Context) ([]*model.UserData, error)
+}
+
+type KeyProvider interface {
+	MasterPassword() ([]byte, error)
+}
+
+type SearchItem struct {
+	Key      string   `json:"key"`
+	Type     string   `json:"type"`
+	Folder   string   `json:"folder,omitempty"`
+	Favorite bool     `json:"favorite,omitempty"`
+	URLs     []string `json:"urls"`
+}
+
+type Item struct {
+	Key       string             `json:"key"`
+	Type      string             `json:"type"`
+	Value     value.Value        `json:"value"`
+	Meta      *metadata.Metadata `json:"meta"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type PasswordRequest struct {
+	Length  int   `json:"length"`
+	Digits  *bool `json:"digits"`
+	Symbols *bool `json:"symbols"`
+}
+
+type PasswordResponse struct {
+	Password string `json:"password"`
+}
+
+// Server is a read-only loopback API for browser extensions and local tools
+type Server struct {
+	data    DataGetter
+	keys    KeyProvider
+	token   []byte
+	origins []string
+}
+
+func NewServer(data DataGetter, keys KeyProvider, token string, origins []string) *Server {
+	return &Server{
+		data:    data,
+		keys:    keys,
+		token:   []byte(token),
+		origins: origins,
+	}
+}
+
+// NewToken generates a per-session bearer token
+func NewToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("can`t generate token: %w", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// Listen binds the port on the loopback interface only
+func Listen(port int) (net.Listener, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", fmt.Sprint(port)))
+	if err != nil {
+		return nil, fmt.Errorf("can`t listen on port %d: %w", port, err)
+	}
+
+	return listener, nil
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/items", s.search)
+	mux.HandleFunc("GET /v1/items/{key...}", s.item)
+	mux.HandleFunc("POST /v1/password", s.password)
+
+	return s.middleware(mux)
+}
+
+// Serve blocks until ctx is done or the listener fails
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// middleware checks the origin before the token, so a page can`t probe the token
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if !slices.Contains(s.origins, origin) {
+				writeError(w, http.StatusForbidden, errors.New("origin is not allowed"))
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid token"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	host, err := hostname(r.URL.Query().Get("url"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	masterPassword, err := s.keys.MasterPassword()
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+	defer clear(masterPassword)
+
+	items, err := s.data.List(r.Context())
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+
+	result := []*SearchItem{}
+	for _, data := range items {
+		meta, err := decryptMetadata(masterPassword, data)
+		if err != nil {
+			writeVaultError(w, err)
+			return
+		}
+		if !matchHost(meta.URLs, host) {
+			continue
+		}
+
+		val, err := value.Decrypt(masterPassword, data.DataValue)
+		if err != nil {
+			writeVaultError(w, err)
+			return
+		}
+		result = append(result, &SearchItem{
+			Key:      data.DataKey,
+			Type:     value.TypeName(val),
+			Folder:   meta.Folder,
+			Favorite: meta.Favorite,
+			URLs:     meta.URLs,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) item(w http.ResponseWriter, r *http.Request) {
+	masterPassword, err := s.keys.MasterPassword()
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+	defer clear(masterPassword)
+
+	data, err := manager.GetLive(r.Context(), s.data, r.PathValue("key"))
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+
+	val, err := value.Decrypt(masterPassword, data.DataValue)
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+	meta, err := decryptMetadata(masterPassword, data)
+	if err != nil {
+		writeVaultError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &Item{
+		Key:       data.DataKey,
+		Type:      value.TypeName(val),
+		Value:     val,
+		Meta:      meta,
+		UpdatedAt: data.UpdatedAt.UTC(),
+	})
+}
+
+func (s *Server) password(w http.ResponseWriter, r *http.Request) {
+	request := PasswordRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+			return
+		}
+	}
+
+	opts := generator.Options{Length: request.Length, Digits: true, Symbols: true}
+	if opts.Length == 0 {
+		opts.Length = generator.DefaultLength
+	}
+	if request.Digits != nil {
+		opts.Digits = *request.Digits
+	}
+	if request.Symbols != nil {
+		opts.Symbols = *request.Symbols
+	}
+
+	password, err := generator.Password(opts)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, generator.ErrInvalidLength) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &PasswordResponse{Password: password})
+}
+
+func hostname(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", errors.New("url parameter is required")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid url: %q", rawURL)
+	}
+
+	return strings.ToLower(parsed.Hostname()), nil
+}
+
+// matchHost matches the host itself and its subdomains, e.g. accounts.example.com for example.com
+func matchHost(urls []string, host string) bool {
+	for _, itemURL := range urls {
+		itemHost, err := hostname(itemURL)
+		if err != nil {
+			continue
+		}
+		if host == itemHost || strings.HasSuffix(host, "."+itemHost) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decryptMetadata(masterPassword []byte, data *model.UserData) (*metadata.Metadata, error) {
+	if len(data.DataMeta) == 0 {
+		return &metadata.Metadata{}, nil
+	}
+
+	raw, err := aes.Decrypt(masterPassword, data.DataMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.FromBytes(raw)
+}
+
+func writeVaultError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, vault.ErrLocked):
+		writeError(w, http.StatusLocked, err)
+	case errors.Is(err, manager.ErrNotFound):
+		writeError(w, http.StatusNotFound, err)
+	default:
+		logger.Logger.Error("HTTP API request failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(v)
+}
@@ -0,0 +1,246 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/manager"
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/model"
+	"github.com/m1khal3v/gophkeeper/internal/client/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken  = "test-token"
+	testOrigin = "chrome-extension://abcdef"
+)
+
+var testPassword = []byte("1234567890abcdef")
+
+type mockData struct {
+	items map[string]*model.UserData
+}
+
+func (m *mockData) Get(ctx context.Context, key string) (*model.UserData, error) {
+	item, ok := m.items[key]
+	if !ok {
+		return nil, manager.ErrNotFound
+	}
+
+	return item, nil
+}
+
+func (m *mockData) List(ctx context.Context) ([]*model.UserData, error) {
+	var items []*model.UserData
+	for _, key := range []string{"github", "google", "note"} {
+		items = append(items, m.items[key])
+	}
+
+	return items, nil
+}
+
+type staticKey []byte
+
+func (k staticKey) MasterPassword() ([]byte, error) {
+	return []byte(string(k)), nil
+}
+
+type lockedKey struct{}
+
+func (lockedKey) MasterPassword() ([]byte, error) {
+	return nil, vault.ErrLocked
+}
+
+func newItem(t *testing.T, key string, raw []byte, meta *metadata.Metadata) *model.UserData {
+	encValue, err := aes.Encrypt(testPassword, raw)
+	require.NoError(t, err)
+	rawMeta, err := meta.ToBytes()
+	require.NoError(t, err)
+	encMeta, err := aes.Encrypt(testPassword, rawMeta)
+	require.NoError(t, err)
+
+	return &model.UserData{
+		DataKey:   key,
+		DataValue: encValue,
+		DataMeta:  encMeta,
+		UpdatedAt: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+		DeletedAt: time.Unix(0, 0),
+	}
+}
+
+func newTestServer(t *testing.T, keys KeyProvider) http.Handler {
+	data := &mockData{items: map[string]*model.UserData{
+		"github": newItem(t, "github", []byte("\x01{\"login\":\"octocat\",\"password\":\"secret\"}"),
+			&metadata.Metadata{Folder: "dev", URLs: []string{"https://github.com/login"}}),
+		"google": newItem(t, "google", []byte("\x01{\"login\":\"me\",\"password\":\"pass\"}"),
+			&metadata.Metadata{URLs: []string{"google.com"}}),
+		"note": newItem(t, "note", []byte("\x02{\"text\":\"hello\"}"), &metadata.Metadata{}),
+	}}
+
+	return NewServer(data, keys, testToken, []string{testOrigin}).Handler()
+}
+
+func do(handler http.Handler, method, target, token, origin, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	request := httptest.NewRequest(method, target, reader)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	if origin != "" {
+		request.Header.Set("Origin", origin)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestServer_Search(t *testing.T) {
+	handler := newTestServer(t, staticKey(testPassword))
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "host",
+			target:     "/v1/items?url=https://github.com/m1khal3v",
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"key":"github","type":"login_password","folder":"dev","urls":["https://github.com/login"]}]`,
+		},
+		{
+			name:       "subdomain",
+			target:     "/v1/items?url=https://accounts.google.com/signin",
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"key":"google","type":"login_password","urls":["google.com"]}]`,
+		},
+		{name: "other host", target: "/v1/items?url=https://notgoogle.com", wantStatus: http.StatusOK, wantBody: `[]`},
+		{name: "no url", target: "/v1/items", wantStatus: http.StatusBadRequest, wantBody: `{"error":"url parameter is required"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := do(handler, http.MethodGet, tt.target, testToken, "", "")
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.JSONEq(t, tt.wantBody, response.Body.String())
+		})
+	}
+}
+
+func TestServer_Item(t *testing.T) {
+	handler := newTestServer(t, staticKey(testPassword))
+
+	response := do(handler, http.MethodGet, "/v1/items/github", testToken, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "no-store", response.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{
+		"key": "github",
+		"type": "login_password",
+		"value": {"login": "octocat", "password": "secret"},
+		"meta": {"folder": "dev", "urls": ["https://github.com/login"]},
+		"updated_at": "2025-05-01T10:00:00Z"
+	}`, response.Body.String())
+
+	response = do(handler, http.MethodGet, "/v1/items/missing", testToken, "", "")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestServer_Locked(t *testing.T) {
+	handler := newTestServer(t, lockedKey{})
+
+	response := do(handler, http.MethodGet, "/v1/items/github", testToken, "", "")
+	assert.Equal(t, http.StatusLocked, response.Code)
+	assert.JSONEq(t, `{"error":"vault is locked, run unlock"}`, response.Body.String())
+
+	// generation doesn`t need the vault
+	response = do(handler, http.MethodPost, "/v1/password", testToken, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestServer_Password(t *testing.T) {
+	handler := newTestServer(t, staticKey(testPassword))
+
+	response := do(handler, http.MethodPost, "/v1/password", testToken, "", `{"length":32,"symbols":false}`)
+	require.Equal(t, http.StatusOK, response.Code)
+	var password PasswordResponse
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &password))
+	assert.Len(t, password.Password, 32)
+
+	response = do(handler, http.MethodPost, "/v1/password", testToken, "", `{"length":4}`)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	response = do(handler, http.MethodPost, "/v1/password", testToken, "", `{`)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestServer_Auth(t *testing.T) {
+	handler := newTestServer(t, staticKey(testPassword))
+
+	response := do(handler, http.MethodGet, "/v1/items/github", "", "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	response = do(handler, http.MethodGet, "/v1/items/github", "wrong", "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	response = do(handler, http.MethodGet, "/v1/items/github", testToken, "https://evil.example", "")
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Empty(t, response.Header().Get("Access-Control-Allow-Origin"))
+
+	response = do(handler, http.MethodGet, "/v1/items/github", testToken, testOrigin, "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, testOrigin, response.Header().Get("Access-Control-Allow-Origin"))
+
+	// preflight requests carry no credentials
+	response = do(handler, http.MethodOptions, "/v1/items/github", "", testOrigin, "")
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "Authorization, Content-Type", response.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestServer_Serve(t *testing.T) {
+	listener, err := Listen(0)
+	require.NoError(t, err)
+	assert.Contains(t, listener.Addr().String(), "127.0.0.1:")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	server := NewServer(&mockData{}, staticKey(testPassword), testToken, nil)
+	go func() {
+		done <- server.Serve(ctx, listener)
+	}()
+
+	request, err := http.NewRequest(http.MethodPost, "http://"+listener.Addr().String()+"/v1/password", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+testToken)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestNewToken(t *testing.T) {
+	first, err := NewToken()
+	require.NoError(t, err)
+	second, err := NewToken()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 64)
+	assert.NotEqual(t, first, second)
+}
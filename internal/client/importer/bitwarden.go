@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	bitwardenTypeLogin      = 1
+	bitwardenTypeSecureNote = 2
+	bitwardenTypeCard       = 3
+	bitwardenTypeIdentity   = 4
+)
+
+type BitwardenReader struct{}
+
+type bitwardenExport struct {
+	Encrypted bool              `json:"encrypted"`
+	Folders   []bitwardenFolder `json:"folders"`
+	Items     []bitwardenItem   `json:"items"`
+}
+
+type bitwardenFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bitwardenItem struct {
+	Type     int              `json:"type"`
+	Name     string           `json:"name"`
+	Notes    string           `json:"notes"`
+	FolderID string           `json:"folderId"`
+	Favorite bool             `json:"favorite"`
+	Fields   []bitwardenField `json:"fields"`
+	Login    *struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		URIs     []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+	Identity map[string]any `json:"identity"`
+}
+
+type bitwardenField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (r *BitwardenReader) Read(reader io.Reader) (*Result, error) {
+	var export bitwardenExport
+	if err := json.NewDecoder(reader).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid Bitwarden JSON: %w", err)
+	}
+	if export.Encrypted {
+		return nil, errors.New("encrypted Bitwarden exports are not supported, export as unencrypted JSON")
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, folder := range export.Folders {
+		folders[folder.ID] = folder.Name
+	}
+
+	result := &Result{}
+	for _, item := range export.Items {
+		f := fields{
+			"key":    item.Name,
+			"note":   item.Notes,
+			"folder": folders[item.FolderID],
+		}
+		if item.Favorite {
+			f["favorite"] = "true"
+		}
+
+		var custom []string
+		for _, field := range item.Fields {
+			custom = append(custom, field.Name+": "+field.Value)
+		}
+
+		switch item.Type {
+		case bitwardenTypeLogin:
+			if item.Login != nil {
+				f["login"] = item.Login.Username
+				f["password"] = item.Login.Password
+				if len(item.Login.URIs) > 0 {
+					f["url"] = item.Login.URIs[0].URI
+				}
+				for _, uri := range item.Login.URIs[min(1, len(item.Login.URIs)):] {
+					custom = append(custom, "URL: "+uri.URI)
+				}
+			}
+		case bitwardenTypeCard:
+			if item.Card != nil {
+				f["number"] = item.Card.Number
+				f["holder"] = item.Card.CardholderName
+				f["expire_month"] = item.Card.ExpMonth
+				f["expire_year"] = item.Card.ExpYear
+				f["cvc"] = item.Card.Code
+			}
+		case bitwardenTypeSecureNote:
+			f["text"] = item.Notes
+			f["note"] = ""
+		case bitwardenTypeIdentity:
+			f["text"] = formatIdentity(item.Identity)
+		default:
+			result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: fmt.Sprintf("unsupported item type: %d", item.Type)})
+			continue
+		}
+
+		if len(custom) > 0 {
+			f["note"] = strings.TrimSpace(f["note"] + "\n" + strings.Join(custom, "\n"))
+		}
+
+		result.add(f)
+	}
+
+	return result, nil
+}
+
+func formatIdentity(identity map[string]any) string {
+	lines := make([]string, 0, len(identity))
+	for _, key := range slices.Sorted(maps.Keys(identity)) {
+		if val, ok := identity[key].(string); ok && val != "" {
+			lines = append(lines, key+": "+val)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
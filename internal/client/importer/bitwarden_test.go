@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitwardenReader_Read(t *testing.T) {
+	export := `{
+		"encrypted": false,
+		"folders": [{"id": "f1", "name": "Work"}],
+		"items": [
+			{
+				"type": 1,
+				"name": "GitHub",
+				"folderId": "f1",
+				"favorite": true,
+				"notes": "main account",
+				"fields": [{"name": "recovery", "value": "abc"}],
+				"login": {
+					"username": "octocat",
+					"password": "s3cr3t",
+					"uris": [{"uri": "https://github.com"}, {"uri": "https://gist.github.com"}]
+				}
+			},
+			{"type": 2, "name": "Wifi", "notes": "password: 12345678"},
+			{
+				"type": 3,
+				"name": "Visa",
+				"card": {"cardholderName": "JOHN DOE", "number": "4111111111111111", "expMonth": "5", "expYear": "2027", "code": "123"}
+			},
+			{"type": 4, "name": "Me", "identity": {"firstName": "John", "lastName": "Doe", "phone": null}},
+			{"type": 5, "name": "SSH key"}
+		]
+	}`
+
+	result, err := (&BitwardenReader{}).Read(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 4)
+
+	github := result.Entries[0]
+	assert.Equal(t, "GitHub", github.Key)
+	assert.Equal(t, &value.LoginPassword{Login: "octocat", Password: "s3cr3t"}, github.Value)
+	assert.Equal(t, "Work", github.Meta.Folder)
+	assert.True(t, github.Meta.Favorite)
+	assert.Equal(t, []string{"https://github.com"}, github.Meta.URLs)
+	assert.Equal(t, "main account\nrecovery: abc\nURL: https://gist.github.com", github.Meta.Note)
+
+	assert.Equal(t, &value.TextValue{Text: "password: 12345678"}, result.Entries[1].Value)
+	assert.Equal(t, "", result.Entries[1].Meta.Note)
+	assert.Equal(t, &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}, result.Entries[2].Value)
+	assert.Equal(t, &value.TextValue{Text: "firstName: John\nlastName: Doe"}, result.Entries[3].Value)
+
+	assert.Equal(t, []Skipped{{Name: "SSH key", Reason: "unsupported item type: 5"}}, result.Skipped)
+}
+
+func TestBitwardenReader_Read_Errors(t *testing.T) {
+	for _, input := range []string{"not json", `{"encrypted": true}`} {
+		result, err := (&BitwardenReader{}).Read(strings.NewReader(input))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	}
+}
@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+var csvFields = []string{
+	"key", "login", "password", "url", "note", "folder", "tags", "favorite", "text",
+	"number", "holder", "expire_month", "expire_year", "expiry", "cvc",
+}
+
+var csvAliases = map[string][]string{
+	"key":      {"key", "name", "title"},
+	"login":    {"login", "username", "user"},
+	"password": {"password", "pass"},
+	"url":      {"url", "uri", "website"},
+	"note":     {"note", "notes"},
+	"folder":   {"folder", "group"},
+	"tags":     {"tags", "tag"},
+	"favorite": {"favorite"},
+	"text":     {"text"},
+	"number":   {"number", "card number"},
+	"holder":   {"holder", "cardholder"},
+	"cvc":      {"cvc", "cvv"},
+}
+
+var onePasswordAliases = map[string][]string{
+	"key":      {"title", "name"},
+	"login":    {"username"},
+	"password": {"password"},
+	"url":      {"url", "urls", "website"},
+	"note":     {"notes", "notesplain"},
+	"folder":   {"vault"},
+	"tags":     {"tags"},
+	"favorite": {"favorite"},
+	"number":   {"number", "card number"},
+	"holder":   {"cardholder name", "cardholder"},
+	"expiry":   {"expiry date", "expiry"},
+	"cvc":      {"verification number", "cvv"},
+}
+
+type CSVReader struct {
+	mapping map[string]string
+	aliases map[string][]string
+}
+
+func NewCSVReader(mapping string) (*CSVReader, error) {
+	parsed, err := parseMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVReader{mapping: parsed, aliases: csvAliases}, nil
+}
+
+func NewOnePasswordReader() *CSVReader {
+	return &CSVReader{mapping: map[string]string{}, aliases: onePasswordAliases}
+}
+
+func (r *CSVReader) Read(reader io.Reader) (*Result, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns, err := r.columns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		f := fields{}
+		for field, index := range columns {
+			if index < len(record) {
+				f[field] = record[index]
+			}
+		}
+		if f["expiry"] != "" && f["expire_month"] == "" {
+			f["expire_month"], f["expire_year"] = splitExpiry(f["expiry"])
+		}
+
+		result.add(f)
+	}
+
+	return result, nil
+}
+
+func (r *CSVReader) columns(header []string) (map[string]int, error) {
+	normalized := make([]string, len(header))
+	for i, column := range header {
+		normalized[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+
+	columns := make(map[string]int)
+	for field, column := range r.mapping {
+		index := slices.Index(normalized, strings.ToLower(column))
+		if index < 0 {
+			return nil, fmt.Errorf("column %q for field %s not found", column, field)
+		}
+		columns[field] = index
+	}
+
+	for field, aliases := range r.aliases {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, alias := range aliases {
+			if index := slices.Index(normalized, alias); index >= 0 {
+				columns[field] = index
+				break
+			}
+		}
+	}
+
+	if _, ok := columns["key"]; !ok {
+		if _, ok := columns["url"]; !ok {
+			return nil, errors.New("CSV has no name column, use mapping key=<column>")
+		}
+	}
+
+	return columns, nil
+}
+
+func parseMapping(mapping string) (map[string]string, error) {
+	result := make(map[string]string)
+	if mapping == "" {
+		return result, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected field=column", pair)
+		}
+		if !slices.Contains(csvFields, field) {
+			return nil, fmt.Errorf("unknown mapping field %q, expected one of: %s", field, strings.Join(csvFields, ", "))
+		}
+		result[field] = column
+	}
+
+	return result, nil
+}
+
+func splitExpiry(expiry string) (string, string) {
+	expiry = strings.TrimSpace(expiry)
+	if month, year, ok := strings.Cut(expiry, "/"); ok {
+		return strings.TrimSpace(month), strings.TrimSpace(year)
+	}
+	// 1Password stores expiry as YYYYMM
+	if len(expiry) == 6 {
+		return expiry[4:], expiry[:4]
+	}
+
+	return "", ""
+}
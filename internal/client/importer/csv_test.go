@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVReader_Read_DefaultColumns(t *testing.T) {
+	data := "\ufeffName,Username,Password,URL,Notes,Group\n" +
+		"mail,john,pass,https://mail.example.com,personal,Home\n" +
+		"secret note,,,,just text,\n"
+
+	reader, err := NewCSVReader("")
+	require.NoError(t, err)
+
+	result, err := reader.Read(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 2)
+	assert.Equal(t, "mail", result.Entries[0].Key)
+	assert.Equal(t, &value.LoginPassword{Login: "john", Password: "pass"}, result.Entries[0].Value)
+	assert.Equal(t, "Home", result.Entries[0].Meta.Folder)
+	assert.Equal(t, "personal", result.Entries[0].Meta.Note)
+	assert.Equal(t, &value.TextValue{Text: "just text"}, result.Entries[1].Value)
+}
+
+func TestCSVReader_Read_Mapping(t *testing.T) {
+	data := "Account,Who,Secret,Card,Owner,Expires,Code\n" +
+		"site,me,pw,,,,\n" +
+		"bank,,,4111111111111111,JOHN DOE,05/2027,123\n"
+
+	reader, err := NewCSVReader("key=Account,login=Who,password=Secret,number=Card,holder=Owner,expiry=Expires,cvc=Code")
+	require.NoError(t, err)
+
+	result, err := reader.Read(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 2)
+	assert.Equal(t, &value.LoginPassword{Login: "me", Password: "pw"}, result.Entries[0].Value)
+	assert.Equal(t, &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}, result.Entries[1].Value)
+}
+
+func TestCSVReader_Read_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping string
+		data    string
+	}{
+		{name: "empty", data: ""},
+		{name: "missing mapped column", mapping: "key=Account", data: "Name\nvalue\n"},
+		{name: "no name column", data: "Username,Password\nuser,pass\n"},
+		{name: "broken quotes", data: "Name\n\"unterminated\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewCSVReader(tt.mapping)
+			require.NoError(t, err)
+
+			result, err := reader.Read(strings.NewReader(tt.data))
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestParseMapping_Invalid(t *testing.T) {
+	for _, mapping := range []string{"key", "key=", "unknown=Name"} {
+		_, err := parseMapping(mapping)
+		assert.Error(t, err, mapping)
+	}
+}
+
+func TestOnePasswordReader_Read(t *testing.T) {
+	data := "Title,Url,Username,Password,OTPAuth,Favorite,Archived,Tags,Notes\n" +
+		"GitHub,https://github.com,octocat,s3cr3t,,true,false,dev;git,2fa enabled\n" +
+		"Visa,,,,,false,false,,\n"
+
+	result, err := NewOnePasswordReader().Read(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, "GitHub", result.Entries[0].Key)
+	assert.Equal(t, &value.LoginPassword{Login: "octocat", Password: "s3cr3t"}, result.Entries[0].Value)
+	assert.True(t, result.Entries[0].Meta.Favorite)
+	assert.Equal(t, []string{"dev", "git"}, result.Entries[0].Meta.Tags)
+	assert.Equal(t, "2fa enabled", result.Entries[0].Meta.Note)
+	assert.Equal(t, []Skipped{{Name: "Visa", Reason: "entry has no importable data"}}, result.Skipped)
+}
+
+func TestOnePasswordReader_Read_Card(t *testing.T) {
+	data := "Title,Number,Cardholder Name,Expiry Date,Verification Number\n" +
+		"Visa,4111111111111111,JOHN DOE,202705,123\n"
+
+	result, err := NewOnePasswordReader().Read(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}, result.Entries[0].Value)
+}
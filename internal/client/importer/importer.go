@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+const (
+	FormatKeePass     = "keepass"
+	FormatBitwarden   = "bitwarden"
+	FormatOnePassword = "1password"
+	FormatCSV         = "csv"
+)
+
+type Entry struct {
+	Key   string
+	Value value.Value
+	Meta  *metadata.Metadata
+}
+
+type Skipped struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type Result struct {
+	Entries []*Entry
+	Skipped []Skipped
+}
+
+type Reader interface {
+	Read(r io.Reader) (*Result, error)
+}
+
+func NewReader(format, mapping string) (Reader, error) {
+	if mapping != "" && format != FormatCSV {
+		return nil, fmt.Errorf("column mapping is supported only for %s format", FormatCSV)
+	}
+
+	switch format {
+	case FormatKeePass:
+		return &KeePassReader{}, nil
+	case FormatBitwarden:
+		return &BitwardenReader{}, nil
+	case FormatOnePassword:
+		return NewOnePasswordReader(), nil
+	case FormatCSV:
+		return NewCSVReader(mapping)
+	default:
+		return nil, fmt.Errorf("unknown import format: %s", format)
+	}
+}
+
+// fields are normalized record attributes shared by every reader:
+// key, login, password, url, note, folder, tags, favorite, text,
+// number, holder, expire_month, expire_year, cvc.
+type fields map[string]string
+
+func (r *Result) add(f fields) {
+	name := f["key"]
+	entry, err := newEntry(f)
+	if err != nil {
+		r.Skipped = append(r.Skipped, Skipped{Name: name, Reason: err.Error()})
+		return
+	}
+
+	r.addEntry(entry)
+}
+
+func (r *Result) addEntry(entry *Entry) {
+	base := entry.Key
+	for i := 2; r.hasKey(entry.Key); i++ {
+		entry.Key = fmt.Sprintf("%s (%d)", base, i)
+	}
+
+	r.Entries = append(r.Entries, entry)
+}
+
+func (r *Result) hasKey(key string) bool {
+	for _, entry := range r.Entries {
+		if entry.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newEntry(f fields) (*Entry, error) {
+	key := strings.TrimSpace(f["key"])
+	if key == "" {
+		key = strings.TrimSpace(f["url"])
+	}
+	if key == "" {
+		return nil, errors.New("entry has no name")
+	}
+
+	meta := &metadata.Metadata{Note: f["note"]}
+	meta.SetFolder(f["folder"])
+	meta.AddTags(splitTags(f["tags"])...)
+	if url := strings.TrimSpace(f["url"]); url != "" {
+		meta.AddURL(url)
+	}
+	if favorite, err := strconv.ParseBool(f["favorite"]); err == nil {
+		meta.Favorite = favorite
+	}
+
+	val, err := newValue(f)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := val.(*value.TextValue); ok && f["text"] == "" {
+		meta.Note = ""
+	}
+
+	if err := val.Validate(); err != nil {
+		return nil, err
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Entry{Key: key, Value: val, Meta: meta}, nil
+}
+
+func newValue(f fields) (value.Value, error) {
+	switch {
+	case f["number"] != "":
+		month, err := strconv.Atoi(strings.TrimSpace(f["expire_month"]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid card expire month: %q", f["expire_month"])
+		}
+		year, err := strconv.Atoi(strings.TrimSpace(f["expire_year"]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid card expire year: %q", f["expire_year"])
+		}
+		if year < 100 {
+			year += 2000
+		}
+		return &value.CardValue{
+			Number:      strings.ReplaceAll(f["number"], " ", ""),
+			Holder:      f["holder"],
+			ExpireMonth: month,
+			ExpireYear:  year,
+			CVC:         f["cvc"],
+		}, nil
+	case f["login"] != "" || f["password"] != "":
+		return &value.LoginPassword{
+			Login:    f["login"],
+			Password: f["password"],
+		}, nil
+	case f["text"] != "":
+		return &value.TextValue{Text: f["text"]}, nil
+	case f["note"] != "":
+		return &value.TextValue{Text: f["note"]}, nil
+	default:
+		return nil, errors.New("entry has no importable data")
+	}
+}
+
+func splitTags(tags string) []string {
+	parts := strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		tag := strings.Join(strings.Fields(part), "-")
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
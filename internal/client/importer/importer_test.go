@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReader(t *testing.T) {
+	tests := []struct {
+		format  string
+		mapping string
+		want    Reader
+		wantErr bool
+	}{
+		{format: FormatKeePass, want: &KeePassReader{}},
+		{format: FormatBitwarden, want: &BitwardenReader{}},
+		{format: FormatOnePassword, want: NewOnePasswordReader()},
+		{format: FormatCSV, mapping: "key=Name", want: &CSVReader{mapping: map[string]string{"key": "Name"}, aliases: csvAliases}},
+		{format: FormatKeePass, mapping: "key=Name", wantErr: true},
+		{format: FormatCSV, mapping: "color=Name", wantErr: true},
+		{format: "lastpass", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+tt.mapping, func(t *testing.T) {
+			got, err := NewReader(tt.format, tt.mapping)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResult_Add(t *testing.T) {
+	result := &Result{}
+	result.add(fields{"key": "site", "login": "user", "password": "pass", "url": "https://example.com", "tags": "work, two words;db", "favorite": "true", "folder": "/a//b/"})
+	result.add(fields{"key": "site", "text": "duplicate name"})
+	result.add(fields{"key": "note", "note": "only a note"})
+	result.add(fields{"key": "card", "number": "4111 1111 1111 1111", "holder": "JOHN DOE", "expire_month": "05", "expire_year": "27", "cvc": "123"})
+	result.add(fields{"key": "empty"})
+	result.add(fields{"key": "no-login", "password": "pass"})
+	result.add(fields{"login": "user", "password": "pass"})
+
+	require.Len(t, result.Entries, 4)
+	assert.Equal(t, &Entry{
+		Key:   "site",
+		Value: &value.LoginPassword{Login: "user", Password: "pass"},
+		Meta: &metadata.Metadata{
+			Tags:     []string{"db", "two-words", "work"},
+			Folder:   "a/b",
+			Favorite: true,
+			URLs:     []string{"https://example.com"},
+		},
+	}, result.Entries[0])
+	assert.Equal(t, "site (2)", result.Entries[1].Key)
+	assert.Equal(t, &value.TextValue{Text: "duplicate name"}, result.Entries[1].Value)
+	assert.Equal(t, &value.TextValue{Text: "only a note"}, result.Entries[2].Value)
+	assert.Equal(t, "", result.Entries[2].Meta.Note)
+	assert.Equal(t, &value.CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}, result.Entries[3].Value)
+
+	assert.Equal(t, []Skipped{
+		{Name: "empty", Reason: "entry has no importable data"},
+		{Name: "no-login", Reason: "login is empty"},
+		{Name: "", Reason: "entry has no name"},
+	}, result.Skipped)
+}
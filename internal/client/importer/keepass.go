@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/metadata"
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+)
+
+type KeePassReader struct{}
+
+type keePassFile struct {
+	Binaries []keePassBinary `xml:"Meta>Binaries>Binary"`
+	// RecycleBin is the UUID of the recycle bin group, its name depends on the language of KeePass
+	RecycleBin string         `xml:"Meta>RecycleBinUUID"`
+	Groups     []keePassGroup `xml:"Root>Group"`
+}
+
+type keePassBinary struct {
+	ID         string `xml:"ID,attr"`
+	Compressed bool   `xml:"Compressed,attr"`
+	Content    string `xml:",chardata"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+type keePassEntry struct {
+	Strings  []keePassString      `xml:"String"`
+	Binaries []keePassEntryBinary `xml:"Binary"`
+	Tags     string               `xml:"Tags"`
+}
+
+type keePassString struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type keePassEntryBinary struct {
+	Key   string `xml:"Key"`
+	Value struct {
+		Ref     string `xml:"Ref,attr"`
+		Content string `xml:",chardata"`
+	} `xml:"Value"`
+}
+
+func (r *KeePassReader) Read(reader io.Reader) (*Result, error) {
+	var file keePassFile
+	if err := xml.NewDecoder(reader).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid KeePass XML: %w", err)
+	}
+
+	binaries := make(map[string]keePassBinary, len(file.Binaries))
+	for _, binary := range file.Binaries {
+		binaries[binary.ID] = binary
+	}
+
+	result := &Result{}
+	for _, group := range file.Groups {
+		// the top-level group is the database itself, its name is not a folder
+		r.readGroup(result, binaries, strings.TrimSpace(file.RecycleBin), group, "")
+	}
+
+	return result, nil
+}
+
+func (r *KeePassReader) readGroup(result *Result, binaries map[string]keePassBinary, recycleBin string, group keePassGroup, folder string) {
+	for _, entry := range group.Entries {
+		r.readEntry(result, binaries, entry, folder)
+	}
+
+	for _, child := range group.Groups {
+		if recycleBin != "" && strings.TrimSpace(child.UUID) == recycleBin {
+			continue
+		}
+		r.readGroup(result, binaries, recycleBin, child, metadata.NormalizeFolder(folder+"/"+child.Name))
+	}
+}
+
+func (r *KeePassReader) readEntry(result *Result, binaries map[string]keePassBinary, entry keePassEntry, folder string) {
+	f := fields{"folder": folder, "tags": entry.Tags}
+	var custom []string
+	for _, s := range entry.Strings {
+		switch s.Key {
+		case "Title":
+			f["key"] = s.Value
+		case "UserName":
+			f["login"] = s.Value
+		case "Password":
+			f["password"] = s.Value
+		case "URL":
+			f["url"] = s.Value
+		case "Notes":
+			f["note"] = s.Value
+		default:
+			if s.Value != "" {
+				custom = append(custom, s.Key+": "+s.Value)
+			}
+		}
+	}
+	if len(custom) > 0 {
+		f["note"] = strings.TrimSpace(f["note"] + "\n" + strings.Join(custom, "\n"))
+	}
+
+	result.add(f)
+
+	for _, attachment := range entry.Binaries {
+		name := f["key"] + "/" + attachment.Key
+		data, err := r.attachmentData(binaries, attachment)
+		if err != nil {
+			result.Skipped = append(result.Skipped, Skipped{Name: name, Reason: err.Error()})
+			continue
+		}
+
+		val := &value.BinaryValue{Data: data}
+		if err := val.Validate(); err != nil {
+			result.Skipped = append(result.Skipped, Skipped{Name: name, Reason: err.Error()})
+			continue
+		}
+
+		meta := &metadata.Metadata{}
+		meta.SetFolder(folder)
+		result.addEntry(&Entry{Key: name, Value: val, Meta: meta})
+	}
+}
+
+func (r *KeePassReader) attachmentData(binaries map[string]keePassBinary, attachment keePassEntryBinary) ([]byte, error) {
+	if attachment.Value.Ref == "" {
+		return base64.StdEncoding.DecodeString(strings.TrimSpace(attachment.Value.Content))
+	}
+
+	binary, ok := binaries[attachment.Value.Ref]
+	if !ok {
+		return nil, fmt.Errorf("unknown attachment reference: %s", attachment.Value.Ref)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(binary.Content))
+	if err != nil {
+		return nil, err
+	}
+	if !binary.Compressed {
+		return data, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return io.ReadAll(gz)
+}
@@ -0,0 +1,109 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBase64(t *testing.T, data string) string {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestKeePassReader_Read(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+	<Meta>
+		<Binaries>
+			<Binary ID="0" Compressed="True">` + gzipBase64(t, "compressed attachment") + `</Binary>
+			<Binary ID="1">` + base64.StdEncoding.EncodeToString([]byte("plain attachment")) + `</Binary>
+		</Binaries>
+		<RecycleBinEnabled>True</RecycleBinEnabled>
+		<RecycleBinUUID>2nqq5rVjSUGVVTv7w1kpyg==</RecycleBinUUID>
+	</Meta>
+	<Root>
+		<Group>
+			<Name>Database</Name>
+			<Entry>
+				<String><Key>Title</Key><Value>root entry</Value></String>
+				<String><Key>UserName</Key><Value>root</Value></String>
+				<String><Key>Password</Key><Value ProtectInMemory="True">secret</Value></String>
+			</Entry>
+			<Group>
+				<UUID>x0LWkn7XS0mKzNVDMbmObQ==</UUID>
+				<Name>Work</Name>
+				<Group>
+					<Name>Servers</Name>
+					<Entry>
+						<String><Key>Title</Key><Value>db</Value></String>
+						<String><Key>UserName</Key><Value>admin</Value></String>
+						<String><Key>Password</Key><Value>p4ss</Value></String>
+						<String><Key>URL</Key><Value>https://db.example.com</Value></String>
+						<String><Key>Notes</Key><Value>primary</Value></String>
+						<String><Key>Port</Key><Value>5432</Value></String>
+						<Tags>prod;db</Tags>
+						<Binary><Key>ca.pem</Key><Value Ref="0"/></Binary>
+						<Binary><Key>key.pem</Key><Value Ref="1"/></Binary>
+						<Binary><Key>broken</Key><Value Ref="9"/></Binary>
+						<History>
+							<Entry>
+								<String><Key>Title</Key><Value>db</Value></String>
+							</Entry>
+						</History>
+					</Entry>
+				</Group>
+			</Group>
+			<Group>
+				<UUID>2nqq5rVjSUGVVTv7w1kpyg==</UUID>
+				<Name>Papierkorb</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>deleted</Value></String>
+					<String><Key>Notes</Key><Value>deleted note</Value></String>
+				</Entry>
+			</Group>
+		</Group>
+	</Root>
+</KeePassFile>`
+
+	result, err := (&KeePassReader{}).Read(strings.NewReader(xml))
+	require.NoError(t, err)
+
+	require.Len(t, result.Entries, 4)
+	assert.Equal(t, "root entry", result.Entries[0].Key)
+	assert.Equal(t, "", result.Entries[0].Meta.Folder)
+
+	db := result.Entries[1]
+	assert.Equal(t, "db", db.Key)
+	assert.Equal(t, &value.LoginPassword{Login: "admin", Password: "p4ss"}, db.Value)
+	assert.Equal(t, "Work/Servers", db.Meta.Folder)
+	assert.Equal(t, []string{"db", "prod"}, db.Meta.Tags)
+	assert.Equal(t, []string{"https://db.example.com"}, db.Meta.URLs)
+	assert.Equal(t, "primary\nPort: 5432", db.Meta.Note)
+
+	assert.Equal(t, "db/ca.pem", result.Entries[2].Key)
+	assert.Equal(t, &value.BinaryValue{Data: []byte("compressed attachment")}, result.Entries[2].Value)
+	assert.Equal(t, "Work/Servers", result.Entries[2].Meta.Folder)
+	assert.Equal(t, "db/key.pem", result.Entries[3].Key)
+	assert.Equal(t, &value.BinaryValue{Data: []byte("plain attachment")}, result.Entries[3].Value)
+
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, "db/broken", result.Skipped[0].Name)
+}
+
+func TestKeePassReader_Read_Invalid(t *testing.T) {
+	result, err := (&KeePassReader{}).Read(strings.NewReader("not xml"))
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/m1khal3v/gophkeeper/internal/client/repository"
+)
+
+type HistoryRepository interface {
+	GetAll(ctx context.Context) ([][]byte, error)
+	Add(ctx context.Context, entry []byte) error
+	Trim(ctx context.Context, limit int) error
+}
+
+type HistoryManager struct {
+	repo  HistoryRepository
+	limit int
+}
+
+func NewHistoryManager(repo *repository.HistoryRepository, limit int) *HistoryManager {
+	return &HistoryManager{repo: repo, limit: limit}
+}
+
+func (m *HistoryManager) List(ctx context.Context, masterPassword []byte) ([]string, error) {
+	encEntries, err := m.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]string, 0, len(encEntries))
+	for _, encEntry := range encEntries {
+		entry, err := aes.Decrypt(masterPassword, encEntry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, string(entry))
+	}
+
+	return entries, nil
+}
+
+func (m *HistoryManager) Add(ctx context.Context, entry string, masterPassword []byte) error {
+	encEntry, err := aes.Encrypt(masterPassword, []byte(entry))
+	if err != nil {
+		return err
+	}
+
+	if err := m.repo.Add(ctx, encEntry); err != nil {
+		return err
+	}
+
+	return m.repo.Trim(ctx, m.limit)
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRepository) GetAll(ctx context.Context) ([][]byte, error) {
+	args := m.Called(ctx)
+	entries, _ := args.Get(0).([][]byte)
+	return entries, args.Error(1)
+}
+
+func (m *MockHistoryRepository) Add(ctx context.Context, entry []byte) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockHistoryRepository) Trim(ctx context.Context, limit int) error {
+	args := m.Called(ctx, limit)
+	return args.Error(0)
+}
+
+func TestHistoryManager_Add(t *testing.T) {
+	masterPassword := []byte("master")
+	mockRepo := new(MockHistoryRepository)
+	manager := &HistoryManager{repo: mockRepo, limit: 10}
+
+	var stored []byte
+	mockRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).([]byte)
+	}).Return(nil)
+	mockRepo.On("Trim", mock.Anything, 10).Return(nil)
+
+	require.NoError(t, manager.Add(context.Background(), "get github", masterPassword))
+	assert.NotContains(t, string(stored), "github")
+
+	decrypted, err := aes.Decrypt(masterPassword, stored)
+	require.NoError(t, err)
+	assert.Equal(t, "get github", string(decrypted))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHistoryManager_AddError(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	manager := &HistoryManager{repo: mockRepo, limit: 10}
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	assert.EqualError(t, manager.Add(context.Background(), "list", []byte("master")), "db error")
+	mockRepo.AssertNotCalled(t, "Trim", mock.Anything, mock.Anything)
+}
+
+func TestHistoryManager_List(t *testing.T) {
+	masterPassword := []byte("master")
+	first, err := aes.Encrypt(masterPassword, []byte("list"))
+	require.NoError(t, err)
+	second, err := aes.Encrypt(masterPassword, []byte("get github"))
+	require.NoError(t, err)
+
+	mockRepo := new(MockHistoryRepository)
+	manager := &HistoryManager{repo: mockRepo, limit: 10}
+	mockRepo.On("GetAll", mock.Anything).Return([][]byte{first, second}, nil)
+
+	entries, err := manager.List(context.Background(), masterPassword)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list", "get github"}, entries)
+
+	_, err = manager.List(context.Background(), []byte("wrong"))
+	assert.Error(t, err)
+}
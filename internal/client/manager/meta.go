@@ -2,17 +2,23 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
 	"github.com/m1khal3v/gophkeeper/internal/client/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var ErrInvalidMasterPassword = errors.New("invalid master password")
+
 type MetaRepository interface {
 	GetLastSync(ctx context.Context) (time.Time, error)
 	SetLastSync(ctx context.Context, t time.Time) error
 	GetMasterPasswordHash(ctx context.Context) (string, error)
 	SetMasterPasswordHash(ctx context.Context, h string) error
+	GetAuthToken(ctx context.Context) ([]byte, error)
+	SetAuthToken(ctx context.Context, token []byte) error
 }
 
 type MetaManager struct {
@@ -46,7 +52,12 @@ func (m *MetaManager) ValidateMasterPassword(ctx context.Context, password strin
 		return err
 	}
 
-	return bcrypt.CompareHashAndPassword([]byte(h), []byte(password))
+	err = bcrypt.CompareHashAndPassword([]byte(h), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrInvalidMasterPassword
+	}
+
+	return err
 }
 
 func (m *MetaManager) SetMasterPassword(ctx context.Context, password string) error {
@@ -57,3 +68,26 @@ func (m *MetaManager) SetMasterPassword(ctx context.Context, password string) er
 
 	return m.repo.SetMasterPasswordHash(ctx, string(passwordHash))
 }
+
+func (m *MetaManager) GetAuthToken(ctx context.Context, masterPassword []byte) (string, error) {
+	encToken, err := m.repo.GetAuthToken(ctx)
+	if err != nil || len(encToken) == 0 {
+		return "", err
+	}
+
+	token, err := aes.Decrypt(masterPassword, encToken)
+	if err != nil {
+		return "", err
+	}
+
+	return string(token), nil
+}
+
+func (m *MetaManager) SetAuthToken(ctx context.Context, token string, masterPassword []byte) error {
+	encToken, err := aes.Encrypt(masterPassword, []byte(token))
+	if err != nil {
+		return err
+	}
+
+	return m.repo.SetAuthToken(ctx, encToken)
+}
@@ -36,6 +36,17 @@ func (m *MockMetaRepository) SetMasterPasswordHash(ctx context.Context, h string
 	return args.Error(0)
 }
 
+func (m *MockMetaRepository) GetAuthToken(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	token, _ := args.Get(0).([]byte)
+	return token, args.Error(1)
+}
+
+func (m *MockMetaRepository) SetAuthToken(ctx context.Context, token []byte) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
 func TestNewMetaManager(t *testing.T) {
 	mockRepo := new(MockMetaRepository)
 	manager := &MetaManager{repo: mockRepo}
@@ -162,7 +173,7 @@ func TestValidateMasterPassword(t *testing.T) {
 
 		err := manager.ValidateMasterPassword(ctx, wrongPassword)
 
-		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidMasterPassword)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -204,3 +215,43 @@ func TestSetMasterPassword(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestAuthToken(t *testing.T) {
+	mockRepo := new(MockMetaRepository)
+	manager := &MetaManager{repo: mockRepo}
+
+	ctx := context.Background()
+	masterPassword := []byte("1234567890abcdef")
+
+	t.Run("Not Stored", func(t *testing.T) {
+		mockRepo.On("GetAuthToken", ctx).Return(nil, nil).Once()
+
+		token, err := manager.GetAuthToken(ctx, masterPassword)
+
+		require.NoError(t, err)
+		assert.Equal(t, "", token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Round Trip", func(t *testing.T) {
+		var stored []byte
+		mockRepo.On("SetAuthToken", ctx, mock.AnythingOfType("[]uint8")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).([]byte) }).
+			Return(nil).Once()
+
+		require.NoError(t, manager.SetAuthToken(ctx, "jwt-token", masterPassword))
+		assert.NotContains(t, string(stored), "jwt-token")
+
+		mockRepo.On("GetAuthToken", ctx).Return(stored, nil).Once()
+		token, err := manager.GetAuthToken(ctx, masterPassword)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jwt-token", token)
+
+		mockRepo.On("GetAuthToken", ctx).Return(stored, nil).Once()
+		_, err = manager.GetAuthToken(ctx, []byte("wrong-password"))
+
+		require.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -15,8 +15,27 @@ var (
 
 type UserDataRepository interface {
 	Upsert(ctx context.Context, data *model.UserData) error
+	UpsertAll(ctx context.Context, items []*model.UserData) error
 	Get(ctx context.Context, key string) (*model.UserData, error)
 	GetUpdates(ctx context.Context, lastSync time.Time) ([]*model.UserData, error)
+	GetAll(ctx context.Context) ([]*model.UserData, error)
+}
+
+type UserDataGetter interface {
+	Get(ctx context.Context, key string) (*model.UserData, error)
+}
+
+// GetLive is Get without soft-deleted items, they are kept only to sync the deletion
+func GetLive(ctx context.Context, getter UserDataGetter, key string) (*model.UserData, error) {
+	data, err := getter.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if data.DeletedAt.Unix() > 0 {
+		return nil, ErrNotFound
+	}
+
+	return data, nil
 }
 
 type UserDataManager struct {
@@ -33,6 +52,11 @@ func (m *UserDataManager) Upsert(ctx context.Context, data *model.UserData) erro
 	return m.dataRepo.Upsert(ctx, data)
 }
 
+// UpsertAll writes all items or none of them
+func (m *UserDataManager) UpsertAll(ctx context.Context, items []*model.UserData) error {
+	return m.dataRepo.UpsertAll(ctx, items)
+}
+
 func (m *UserDataManager) Get(ctx context.Context, key string) (*model.UserData, error) {
 	data, err := m.dataRepo.Get(ctx, key)
 	if err != nil {
@@ -47,3 +71,7 @@ func (m *UserDataManager) Get(ctx context.Context, key string) (*model.UserData,
 func (m *UserDataManager) GetUpdates(ctx context.Context, lastSync time.Time) ([]*model.UserData, error) {
 	return m.dataRepo.GetUpdates(ctx, lastSync)
 }
+
+func (m *UserDataManager) List(ctx context.Context) ([]*model.UserData, error) {
+	return m.dataRepo.GetAll(ctx)
+}
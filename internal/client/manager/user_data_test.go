@@ -21,6 +21,11 @@ func (m *MockUserDataRepository) Upsert(ctx context.Context, data *model.UserDat
 	return args.Error(0)
 }
 
+func (m *MockUserDataRepository) UpsertAll(ctx context.Context, items []*model.UserData) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
 func (m *MockUserDataRepository) Get(ctx context.Context, key string) (*model.UserData, error) {
 	args := m.Called(ctx, key)
 	if args.Get(0) == nil {
@@ -37,6 +42,14 @@ func (m *MockUserDataRepository) GetUpdates(ctx context.Context, lastSync time.T
 	return args.Get(0).([]*model.UserData), args.Error(1)
 }
 
+func (m *MockUserDataRepository) GetAll(ctx context.Context) ([]*model.UserData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.UserData), args.Error(1)
+}
+
 func TestNewUserDataManager(t *testing.T) {
 	mockRepo := new(MockUserDataRepository)
 	manager := &UserDataManager{dataRepo: mockRepo}
@@ -77,6 +90,20 @@ func TestUpsert(t *testing.T) {
 	})
 }
 
+func TestUpsertAll(t *testing.T) {
+	mockRepo := new(MockUserDataRepository)
+	manager := &UserDataManager{dataRepo: mockRepo}
+
+	ctx := context.Background()
+	items := []*model.UserData{{DataKey: "first"}, {DataKey: "second"}}
+	mockRepo.On("UpsertAll", ctx, items).Return(errors.New("test error")).Once()
+
+	err := manager.UpsertAll(ctx, items)
+
+	assert.EqualError(t, err, "test error")
+	mockRepo.AssertExpectations(t)
+}
+
 func TestGet(t *testing.T) {
 	mockRepo := new(MockUserDataRepository)
 	manager := &UserDataManager{dataRepo: mockRepo}
@@ -124,6 +151,30 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetLive(t *testing.T) {
+	mockRepo := new(MockUserDataRepository)
+	manager := &UserDataManager{dataRepo: mockRepo}
+	ctx := context.Background()
+
+	live := &model.UserData{DataKey: "live", DeletedAt: time.Unix(0, 0)}
+	deleted := &model.UserData{DataKey: "deleted", DeletedAt: time.Now()}
+	mockRepo.On("Get", ctx, "live").Return(live, nil).Once()
+	mockRepo.On("Get", ctx, "deleted").Return(deleted, nil).Once()
+	mockRepo.On("Get", ctx, "missing").Return(nil, nil).Once()
+
+	data, err := GetLive(ctx, manager, "live")
+	require.NoError(t, err)
+	assert.Equal(t, live, data)
+
+	data, err = GetLive(ctx, manager, "deleted")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, data)
+
+	_, err = GetLive(ctx, manager, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestGetUpdates(t *testing.T) {
 	mockRepo := new(MockUserDataRepository)
 	manager := &UserDataManager{dataRepo: mockRepo}
@@ -177,3 +228,39 @@ func TestGetUpdates(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestList(t *testing.T) {
+	mockRepo := new(MockUserDataRepository)
+	manager := &UserDataManager{dataRepo: mockRepo}
+
+	ctx := context.Background()
+	expected := []*model.UserData{
+		{
+			DataKey:   "key1",
+			DataValue: []byte("value1"),
+			DataMeta:  []byte("meta1"),
+		},
+	}
+	testError := errors.New("test error")
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetAll", ctx).Return(expected, nil).Once()
+
+		items, err := manager.List(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo.On("GetAll", ctx).Return(nil, testError).Once()
+
+		items, err := manager.List(ctx)
+
+		require.Error(t, err)
+		assert.Equal(t, testError, err)
+		assert.Nil(t, items)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,127 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Metadata struct {
+	Tags     []string `json:"tags,omitempty"`
+	Folder   string   `json:"folder,omitempty"`
+	Note     string   `json:"note,omitempty"`
+	Favorite bool     `json:"favorite,omitempty"`
+	URLs     []string `json:"urls,omitempty"`
+}
+
+func FromBytes(data []byte) (*Metadata, error) {
+	m := &Metadata{}
+	if len(data) == 0 {
+		return m, nil
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	return m, nil
+}
+
+func (m *Metadata) ToBytes() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *Metadata) Validate() error {
+	for _, tag := range m.Tags {
+		if tag == "" || strings.ContainsAny(tag, " \t\n") {
+			return fmt.Errorf("invalid tag: %q", tag)
+		}
+	}
+	for _, url := range m.URLs {
+		if url == "" {
+			return errors.New("url is empty")
+		}
+	}
+
+	return nil
+}
+
+func (m *Metadata) HasTag(tag string) bool {
+	return slices.Contains(m.Tags, tag)
+}
+
+func (m *Metadata) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if !m.HasTag(tag) {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	slices.Sort(m.Tags)
+}
+
+func (m *Metadata) RemoveTags(tags ...string) {
+	m.Tags = slices.DeleteFunc(m.Tags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+}
+
+func (m *Metadata) SetFolder(folder string) {
+	m.Folder = NormalizeFolder(folder)
+}
+
+func (m *Metadata) InFolder(folder string) bool {
+	folder = NormalizeFolder(folder)
+	if folder == "" {
+		return true
+	}
+
+	return m.Folder == folder || strings.HasPrefix(m.Folder, folder+"/")
+}
+
+func (m *Metadata) AddURL(url string) {
+	if !slices.Contains(m.URLs, url) {
+		m.URLs = append(m.URLs, url)
+	}
+}
+
+func (m *Metadata) RemoveURL(url string) {
+	m.URLs = slices.DeleteFunc(m.URLs, func(u string) bool {
+		return u == url
+	})
+}
+
+func (m *Metadata) String() string {
+	parts := make([]string, 0, 5)
+	if m.Folder != "" {
+		parts = append(parts, "Folder: "+m.Folder)
+	}
+	if len(m.Tags) > 0 {
+		parts = append(parts, "Tags: "+strings.Join(m.Tags, ", "))
+	}
+	if m.Favorite {
+		parts = append(parts, "Favorite: yes")
+	}
+	if len(m.URLs) > 0 {
+		parts = append(parts, "URLs: "+strings.Join(m.URLs, ", "))
+	}
+	if m.Note != "" {
+		parts = append(parts, "Note: "+m.Note)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func NormalizeFolder(folder string) string {
+	segments := strings.Split(folder, "/")
+	result := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if segment != "" {
+			result = append(result, segment)
+		}
+	}
+
+	return strings.Join(result, "/")
+}
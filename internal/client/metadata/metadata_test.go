@@ -0,0 +1,97 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBytes_Empty(t *testing.T) {
+	m, err := FromBytes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{}, m)
+}
+
+func TestFromBytes_Invalid(t *testing.T) {
+	m, err := FromBytes([]byte("not-json"))
+	assert.Error(t, err)
+	assert.Nil(t, m)
+}
+
+func TestMetadata_ToBytes_RoundTrip(t *testing.T) {
+	want := &Metadata{
+		Tags:     []string{"db", "prod"},
+		Folder:   "work/servers",
+		Note:     "rotate monthly",
+		Favorite: true,
+		URLs:     []string{"https://example.com"},
+	}
+
+	raw, err := want.ToBytes()
+	require.NoError(t, err)
+
+	got, err := FromBytes(raw)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestMetadata_Tags(t *testing.T) {
+	m := &Metadata{}
+	m.AddTags("prod", "db", "prod")
+	assert.Equal(t, []string{"db", "prod"}, m.Tags)
+	assert.True(t, m.HasTag("db"))
+
+	m.RemoveTags("db", "missing")
+	assert.Equal(t, []string{"prod"}, m.Tags)
+	assert.False(t, m.HasTag("db"))
+}
+
+func TestMetadata_InFolder(t *testing.T) {
+	m := &Metadata{}
+	m.SetFolder("/work//servers/")
+	assert.Equal(t, "work/servers", m.Folder)
+
+	tests := []struct {
+		folder string
+		want   bool
+	}{
+		{folder: "", want: true},
+		{folder: "work", want: true},
+		{folder: "work/", want: true},
+		{folder: "work/servers", want: true},
+		{folder: "wor", want: false},
+		{folder: "work/servers/db", want: false},
+		{folder: "home", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.folder, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.InFolder(tt.folder))
+		})
+	}
+}
+
+func TestMetadata_URLs(t *testing.T) {
+	m := &Metadata{}
+	m.AddURL("https://a.example")
+	m.AddURL("https://b.example")
+	m.AddURL("https://a.example")
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, m.URLs)
+
+	m.RemoveURL("https://a.example")
+	assert.Equal(t, []string{"https://b.example"}, m.URLs)
+}
+
+func TestMetadata_Validate(t *testing.T) {
+	assert.NoError(t, (&Metadata{Tags: []string{"ok"}, URLs: []string{"https://example.com"}}).Validate())
+	assert.Error(t, (&Metadata{Tags: []string{"with space"}}).Validate())
+	assert.Error(t, (&Metadata{Tags: []string{""}}).Validate())
+	assert.Error(t, (&Metadata{URLs: []string{""}}).Validate())
+}
+
+func TestMetadata_String(t *testing.T) {
+	m := &Metadata{Tags: []string{"a", "b"}, Folder: "work", Favorite: true}
+	assert.Equal(t, "Folder: work\nTags: a, b\nFavorite: yes", m.String())
+	assert.Equal(t, "", (&Metadata{}).String())
+}
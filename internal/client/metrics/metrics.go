@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "gophkeeper_client"
+
+// Metrics records sync runs, it is a synchronizer.Recorder
+type Metrics struct {
+	registry    *prometheus.Registry
+	syncs       *prometheus.CounterVec
+	duration    prometheus.Histogram
+	pushed      prometheus.Counter
+	pulled      prometheus.Counter
+	lastSuccess prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		syncs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "syncs_total",
+			Help:      "Number of sync runs by result.",
+		}, []string{"result"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_duration_seconds",
+			Help:      "Duration of sync runs.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		pushed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_pushed_items_total",
+			Help:      "Number of local items pushed to the server.",
+		}),
+		pulled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_pulled_items_total",
+			Help:      "Number of items pulled from the server.",
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_successful_sync_timestamp_seconds",
+			Help:      "Unix time of the last successful sync, 0 if there was none.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.syncs,
+		m.duration,
+		m.pushed,
+		m.pulled,
+		m.lastSuccess,
+		collectors.NewGoCollector(),
+	)
+
+	return m
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) ObserveSync(stats *synchronizer.Stats) {
+	result := "failure"
+	if stats.Succeeded {
+		result = "success"
+		m.lastSuccess.SetToCurrentTime()
+	}
+
+	m.syncs.WithLabelValues(result).Inc()
+	m.duration.Observe(stats.Duration.Seconds())
+	m.pushed.Add(float64(stats.Pushed))
+	m.pulled.Add(float64(stats.Pulled))
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/synchronizer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_ObserveSync(t *testing.T) {
+	m := New()
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.lastSuccess))
+
+	m.ObserveSync(&synchronizer.Stats{Duration: time.Second, Pushed: 2, Pulled: 5, Succeeded: true})
+	lastSuccess := testutil.ToFloat64(m.lastSuccess)
+	assert.InDelta(t, float64(time.Now().Unix()), lastSuccess, 5)
+
+	m.ObserveSync(&synchronizer.Stats{Duration: time.Second, Pushed: 1})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.syncs.WithLabelValues("success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.syncs.WithLabelValues("failure")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.pushed))
+	assert.Equal(t, 5.0, testutil.ToFloat64(m.pulled))
+	assert.Equal(t, lastSuccess, testutil.ToFloat64(m.lastSuccess), "a failed sync keeps the last success")
+
+	_, err := m.Registry().Gather()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,27 @@
+-- Tables created before migrations existed, IF NOT EXISTS keeps such databases working
+CREATE TABLE IF NOT EXISTS meta (
+    id INTEGER PRIMARY KEY CHECK (id = 0),
+    last_sync INTEGER NOT NULL,
+    master_password_hash TEXT NOT NULL,
+    auth_token BLOB,
+    schema_version INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT OR IGNORE INTO meta (id, last_sync, master_password_hash) VALUES (0, 0, '');
+
+CREATE TABLE IF NOT EXISTS user_data (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    data_key TEXT NOT NULL UNIQUE,
+    data_value BLOB NOT NULL,
+    data_meta BLOB,
+    updated_at INTEGER NOT NULL,
+    deleted_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_updated_at ON user_data(updated_at);
+CREATE INDEX IF NOT EXISTS idx_deleted_at ON user_data(deleted_at);
+
+CREATE TABLE IF NOT EXISTS history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entry BLOB NOT NULL
+);
@@ -0,0 +1,234 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNewerSchema means the database was migrated by a newer client, which may store data this one can`t read
+var ErrNewerSchema = errors.New("database schema is newer than this client")
+
+//go:embed *.sql
+var FS embed.FS
+
+// legacyColumns were added by the repositories before migrations, old databases may miss them
+var legacyColumns = []struct{ table, column, definition string }{
+	{"meta", "auth_token", "BLOB"},
+	{"meta", "schema_version", "INTEGER NOT NULL DEFAULT 0"},
+	{"user_data", "data_meta", "BLOB"},
+}
+
+type migration struct {
+	version int
+	file    string
+	query   string
+}
+
+type Result struct {
+	From   int
+	To     int
+	Backup string // empty if the database wasn`t copied
+}
+
+// Migrate applies pending migrations in one transaction.
+// A non-empty database is copied to <dbPath>.v<version>.bak first, an empty dbPath disables the backup.
+func Migrate(ctx context.Context, db *sql.DB, dbPath string) (*Result, error) {
+	return migrate(ctx, db, dbPath, FS)
+}
+
+func migrate(ctx context.Context, db *sql.DB, dbPath string, fsys fs.FS) (*Result, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	latest := migrations[len(migrations)-1].version
+
+	from, err := version(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{From: from, To: from}
+	if from > latest {
+		return nil, fmt.Errorf("%w: version %d, supported %d", ErrNewerSchema, from, latest)
+	}
+	if from == latest {
+		return result, nil
+	}
+
+	if dbPath != "" {
+		if result.Backup, err = backup(ctx, db, fmt.Sprintf("%s.v%d.bak", dbPath, from)); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can`t begin migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	// another process could migrate the database after the version was read
+	if from, err = version(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	for _, m := range migrations {
+		if m.version <= from {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, m.query); err != nil {
+			return nil, fmt.Errorf("can`t apply migration %s: %w", m.file, err)
+		}
+
+		if m.version == 1 {
+			for _, c := range legacyColumns {
+				if err := addColumnIfNotExists(ctx, tx, c.table, c.column, c.definition); err != nil {
+					return nil, fmt.Errorf("can`t add column %s.%s: %w", c.table, c.column, err)
+				}
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE meta SET schema_version = ? WHERE id = 0", latest); err != nil {
+		return nil, fmt.Errorf("can`t set schema version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can`t commit migration: %w", err)
+	}
+	result.To = latest
+
+	return result, nil
+}
+
+// load returns migrations named <version>_<name>.sql ordered by version, versions must go without gaps from 1
+func load(fsys fs.FS) ([]*migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []*migration
+	for _, file := range files {
+		prefix, _, ok := strings.Cut(strings.TrimSuffix(path.Base(file), ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid migration name %s", file)
+		}
+
+		query, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("can`t read migration %s: %w", file, err)
+		}
+
+		migrations = append(migrations, &migration{version: version, file: file, query: string(query)})
+	}
+
+	if len(migrations) == 0 {
+		return nil, errors.New("no migrations found")
+	}
+
+	slices.SortFunc(migrations, func(a, b *migration) int {
+		return a.version - b.version
+	})
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+	}
+
+	return migrations, nil
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// version is 0 for a new database and for one created before migrations
+func version(ctx context.Context, q querier) (int, error) {
+	exists, err := columnExists(ctx, q, "meta", "schema_version")
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var v int
+	err = q.QueryRowContext(ctx, "SELECT schema_version FROM meta WHERE id = 0").Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can`t get schema version: %w", err)
+	}
+
+	return v, nil
+}
+
+// backup copies a non-empty database with VACUUM INTO, which is consistent unlike copying the file
+func backup(ctx context.Context, db *sql.DB, backupPath string) (string, error) {
+	var tables int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&tables); err != nil {
+		return "", fmt.Errorf("can`t check db: %w", err)
+	}
+	if tables == 0 {
+		return "", nil
+	}
+
+	// VACUUM INTO requires a missing or empty file, it is created in advance to keep the vault private
+	if err := os.WriteFile(backupPath, nil, 0600); err != nil {
+		return "", fmt.Errorf("can`t create backup: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", backupPath); err != nil {
+		return "", fmt.Errorf("can`t backup db: %w", err)
+	}
+
+	return backupPath, nil
+}
+
+func addColumnIfNotExists(ctx context.Context, q querier, table, column, definition string) error {
+	exists, err := columnExists(ctx, q, table, column)
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+
+	return err
+}
+
+func columnExists(ctx context.Context, q querier, table, column string) (bool, error) {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name, typ    string
+			notNull, pk  int
+			defaultValue sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
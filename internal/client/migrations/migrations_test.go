@@ -0,0 +1,208 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) (*sql.DB, string) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db, path
+}
+
+func tableCount(t *testing.T, db *sql.DB, kind string, names ...string) int {
+	var count int
+	for _, name := range names {
+		var n int
+		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = ? AND name = ?", kind, name).Scan(&n)
+		require.NoError(t, err)
+		count += n
+	}
+
+	return count
+}
+
+func TestMigrate_NewDatabase(t *testing.T) {
+	ctx := context.Background()
+	db, path := openTestDB(t)
+
+	result, err := Migrate(ctx, db, path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.From)
+	assert.Equal(t, 1, result.To)
+	// nothing to lose in an empty database
+	assert.Empty(t, result.Backup)
+
+	assert.Equal(t, 3, tableCount(t, db, "table", "meta", "user_data", "history"))
+	assert.Equal(t, 2, tableCount(t, db, "index", "idx_updated_at", "idx_deleted_at"))
+
+	var lastSync, schemaVersion int64
+	var masterPasswordHash string
+	err = db.QueryRow("SELECT last_sync, master_password_hash, schema_version FROM meta WHERE id = 0").Scan(&lastSync, &masterPasswordHash, &schemaVersion)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), lastSync)
+	assert.Equal(t, "", masterPasswordHash)
+	assert.Equal(t, int64(1), schemaVersion)
+
+	result, err = Migrate(ctx, db, path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.From)
+	assert.Equal(t, 1, result.To)
+	assert.Empty(t, result.Backup)
+}
+
+func TestMigrate_LegacyDatabase(t *testing.T) {
+	ctx := context.Background()
+	db, path := openTestDB(t)
+
+	// schema of clients before data_meta, auth_token and history
+	_, err := db.Exec(`
+		CREATE TABLE meta (
+			id INTEGER PRIMARY KEY CHECK (id = 0),
+			last_sync INTEGER NOT NULL,
+			master_password_hash TEXT NOT NULL
+		);
+		INSERT INTO meta (id, last_sync, master_password_hash) VALUES (0, 42, 'hash');
+		CREATE TABLE user_data (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			data_key TEXT NOT NULL UNIQUE,
+			data_value BLOB NOT NULL,
+			updated_at INTEGER NOT NULL,
+			deleted_at INTEGER NOT NULL
+		);
+		INSERT INTO user_data (data_key, data_value, updated_at, deleted_at) VALUES ('key', 'value', 1, 0);
+	`)
+	require.NoError(t, err)
+
+	result, err := Migrate(ctx, db, path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.From)
+	assert.Equal(t, 1, result.To)
+	assert.Equal(t, path+".v0.bak", result.Backup)
+
+	var lastSync int64
+	var hash string
+	var token, meta []byte
+	err = db.QueryRow("SELECT last_sync, master_password_hash, auth_token FROM meta WHERE id = 0").Scan(&lastSync, &hash, &token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), lastSync)
+	assert.Equal(t, "hash", hash)
+	assert.Nil(t, token)
+
+	err = db.QueryRow("SELECT data_meta FROM user_data WHERE data_key = 'key'").Scan(&meta)
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+	assert.Equal(t, 1, tableCount(t, db, "table", "history"))
+
+	info, err := os.Stat(result.Backup)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	backup, err := sql.Open("sqlite3", result.Backup)
+	require.NoError(t, err)
+	defer backup.Close()
+
+	// the backup keeps the schema before the migration
+	exists, err := columnExists(ctx, backup, "user_data", "data_meta")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	err = backup.QueryRow("SELECT last_sync FROM meta WHERE id = 0").Scan(&lastSync)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), lastSync)
+}
+
+func TestMigrate_NextVersion(t *testing.T) {
+	ctx := context.Background()
+	db, path := openTestDB(t)
+
+	fsys := fstest.MapFS{}
+	initial, err := FS.ReadFile("001_initial.sql")
+	require.NoError(t, err)
+	fsys["001_initial.sql"] = &fstest.MapFile{Data: initial}
+
+	_, err = migrate(ctx, db, path, fsys)
+	require.NoError(t, err)
+
+	fsys["002_user_data_favorite.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE user_data ADD COLUMN favorite INTEGER NOT NULL DEFAULT 0;")}
+	result, err := migrate(ctx, db, path, fsys)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.From)
+	assert.Equal(t, 2, result.To)
+	assert.Equal(t, path+".v1.bak", result.Backup)
+
+	exists, err := columnExists(ctx, db, "user_data", "favorite")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// the client of the previous version refuses to open the database
+	delete(fsys, "002_user_data_favorite.sql")
+	_, err = migrate(ctx, db, path, fsys)
+	assert.ErrorIs(t, err, ErrNewerSchema)
+}
+
+func TestMigrate_Rollback(t *testing.T) {
+	ctx := context.Background()
+	db, path := openTestDB(t)
+
+	initial, err := FS.ReadFile("001_initial.sql")
+	require.NoError(t, err)
+	fsys := fstest.MapFS{
+		"001_initial.sql": {Data: initial},
+		"002_broken.sql":  {Data: []byte("CREATE TABLE broken (id INTEGER); INSERT INTO missing VALUES (1);")},
+	}
+
+	_, err = migrate(ctx, db, path, fsys)
+	assert.ErrorContains(t, err, "002_broken")
+
+	// all migrations are applied in one transaction
+	assert.Equal(t, 0, tableCount(t, db, "table", "meta", "user_data", "history", "broken"))
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := load(FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].version)
+	assert.Equal(t, "001_initial.sql", migrations[0].file)
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		err  string
+	}{
+		{
+			name: "no migrations",
+			fsys: fstest.MapFS{},
+			err:  "no migrations found",
+		},
+		{
+			name: "invalid name",
+			fsys: fstest.MapFS{"initial.sql": {}},
+			err:  "invalid migration name initial.sql",
+		},
+		{
+			name: "missing version",
+			fsys: fstest.MapFS{"001_initial.sql": {}, "003_next.sql": {}},
+			err:  "migration 2 is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.fsys)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ type UserData struct {
 	ID        uint32
 	DataKey   string
 	DataValue []byte
+	DataMeta  []byte
 	UpdatedAt time.Time
 	DeletedAt time.Time
 }
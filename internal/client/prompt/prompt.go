@@ -0,0 +1,45 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+var ErrNoTerminal = errors.New("terminal is not available")
+
+const ttyPath = "/dev/tty"
+
+type TTYPrompter struct {
+	path string
+}
+
+func NewTTYPrompter() *TTYPrompter {
+	return &TTYPrompter{path: ttyPath}
+}
+
+func (p *TTYPrompter) Password(message string) ([]byte, error) {
+	tty, err := os.OpenFile(p.path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoTerminal, err)
+	}
+	defer tty.Close()
+
+	fd := int(tty.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, ErrNoTerminal
+	}
+
+	if _, err := fmt.Fprint(tty, message); err != nil {
+		return nil, err
+	}
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(tty)
+	if err != nil {
+		return nil, fmt.Errorf("can`t read password: %w", err)
+	}
+
+	return password, nil
+}
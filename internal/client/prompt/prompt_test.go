@@ -0,0 +1,31 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTYPrompter_Password_NoTerminal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tty")
+	require.NoError(t, os.WriteFile(path, []byte("secret\n"), 0600))
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "regular file", path: path},
+		{name: "missing device", path: filepath.Join(t.TempDir(), "missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, err := (&TTYPrompter{path: tt.path}).Password("password: ")
+			assert.ErrorIs(t, err, ErrNoTerminal)
+			assert.Nil(t, password)
+		})
+	}
+}
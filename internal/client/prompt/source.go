@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+const maxSecretSize = 4096
+
+var ErrEmptySecret = errors.New("secret is empty")
+
+func FromFD(fd int) ([]byte, error) {
+	file := os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd))
+	if file == nil {
+		return nil, fmt.Errorf("invalid file descriptor: %d", fd)
+	}
+	defer file.Close()
+
+	return readSecret(file)
+}
+
+func FromEnv(name string) ([]byte, error) {
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	// child processes must not inherit the secret
+	if err := os.Unsetenv(name); err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	return []byte(secret), nil
+}
+
+func FromCommand(ctx context.Context, command string) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("password command failed: %w", err)
+	}
+
+	return readSecret(&stdout)
+}
+
+func readSecret(r io.Reader) ([]byte, error) {
+	line, err := bufio.NewReaderSize(io.LimitReader(r, maxSecretSize), maxSecretSize).ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return nil, ErrEmptySecret
+	}
+
+	return line, nil
+}
@@ -0,0 +1,74 @@
+package prompt
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFD(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	_, err = w.WriteString("pass phrase\nignored\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	secret, err := FromFD(int(r.Fd()))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("pass phrase"), secret)
+}
+
+func TestFromFD_Empty(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	secret, err := FromFD(int(r.Fd()))
+	assert.ErrorIs(t, err, ErrEmptySecret)
+	assert.Nil(t, secret)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("GOPHKEEPER_TEST_PASSWORD", "secret")
+
+	secret, err := FromEnv("GOPHKEEPER_TEST_PASSWORD")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), secret)
+
+	_, ok := os.LookupEnv("GOPHKEEPER_TEST_PASSWORD")
+	assert.False(t, ok)
+
+	_, err = FromEnv("GOPHKEEPER_TEST_PASSWORD")
+	assert.Error(t, err)
+
+	t.Setenv("GOPHKEEPER_TEST_PASSWORD", "")
+	_, err = FromEnv("GOPHKEEPER_TEST_PASSWORD")
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
+
+func TestFromCommand(t *testing.T) {
+	secret, err := FromCommand(context.Background(), "printf 'from command\\n'")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from command"), secret)
+
+	_, err = FromCommand(context.Background(), "exit 3")
+	assert.Error(t, err)
+
+	_, err = FromCommand(context.Background(), "true")
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
+
+func TestReadSecret(t *testing.T) {
+	secret, err := readSecret(strings.NewReader("windows\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("windows"), secret)
+
+	secret, err = readSecret(strings.NewReader("no newline"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("no newline"), secret)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type HistoryRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository(db *sql.DB) *HistoryRepository {
+	return &HistoryRepository{db: db}
+}
+
+func (r *HistoryRepository) GetAll(ctx context.Context) ([][]byte, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT entry FROM history ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries [][]byte
+	for rows.Next() {
+		var entry []byte
+		if err := rows.Scan(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *HistoryRepository) Add(ctx context.Context, entry []byte) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO history (entry) VALUES (?)", entry)
+
+	return err
+}
+
+func (r *HistoryRepository) Trim(ctx context.Context, limit int) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM history WHERE id NOT IN (SELECT id FROM history ORDER BY id DESC LIMIT ?)", limit)
+
+	return err
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewHistoryRepository(db)
+
+	ctx := context.Background()
+
+	entries, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, entry := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.Add(ctx, []byte(entry)))
+	}
+
+	entries, err = repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("first"), []byte("second"), []byte("third")}, entries)
+
+	require.NoError(t, repo.Trim(ctx, 2))
+
+	entries, err = repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("second"), []byte("third")}, entries)
+}
@@ -12,13 +12,8 @@ type MetaRepository struct {
 	db *sql.DB
 }
 
-func NewMetaRepository(db *sql.DB) (*MetaRepository, error) {
-	repo := &MetaRepository{db: db}
-	if err := repo.init(); err != nil {
-		return nil, err
-	}
-
-	return repo, nil
+func NewMetaRepository(db *sql.DB) *MetaRepository {
+	return &MetaRepository{db: db}
 }
 
 func (r *MetaRepository) GetLastSync(ctx context.Context) (time.Time, error) {
@@ -47,23 +42,15 @@ func (r *MetaRepository) SetMasterPasswordHash(ctx context.Context, h string) er
 	return err
 }
 
-func (r *MetaRepository) init() error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS meta (
-		  id INTEGER PRIMARY KEY CHECK (id = 0),
-		  last_sync INTEGER NOT NULL,
-          master_password_hash TEXT NOT NULL
-		)`,
-		`INSERT OR IGNORE INTO meta (id, last_sync, master_password_hash) VALUES (0, 0, "")`,
-	}
-
-	for _, query := range queries {
-		_, err := r.db.Exec(query)
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+func (r *MetaRepository) GetAuthToken(ctx context.Context) ([]byte, error) {
+	var token []byte
+	err := r.db.QueryRowContext(ctx, "SELECT auth_token FROM meta WHERE id = 0").Scan(&token)
+
+	return token, err
+}
+
+func (r *MetaRepository) SetAuthToken(ctx context.Context, token []byte) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE meta SET auth_token = ? WHERE id = 0", token)
+
+	return err
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/migrations"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,12 @@ func setupTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("sqlite3", ":memory:")
 	require.NoError(t, err)
 	require.NotNil(t, db)
+
+	// every connection to :memory: opens a new database
+	db.SetMaxOpenConns(1)
+	_, err = migrations.Migrate(context.Background(), db, "")
+	require.NoError(t, err)
+
 	return db
 }
 
@@ -22,12 +29,11 @@ func TestNewMetaRepository(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	repo, err := NewMetaRepository(db)
-	require.NoError(t, err)
+	repo := NewMetaRepository(db)
 	require.NotNil(t, repo)
 
 	var count int
-	err = db.QueryRow("SELECT COUNT(*) FROM meta WHERE id = 0").Scan(&count)
+	err := db.QueryRow("SELECT COUNT(*) FROM meta WHERE id = 0").Scan(&count)
 	require.NoError(t, err)
 	assert.Equal(t, 1, count)
 }
@@ -36,8 +42,7 @@ func TestMetaRepository_GetSetLastSync(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	repo, err := NewMetaRepository(db)
-	require.NoError(t, err)
+	repo := NewMetaRepository(db)
 
 	ctx := context.Background()
 
@@ -58,8 +63,7 @@ func TestMetaRepository_GetSetMasterPasswordHash(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	repo, err := NewMetaRepository(db)
-	require.NoError(t, err)
+	repo := NewMetaRepository(db)
 
 	ctx := context.Background()
 
@@ -76,26 +80,22 @@ func TestMetaRepository_GetSetMasterPasswordHash(t *testing.T) {
 	assert.Equal(t, testHash, updatedHash)
 }
 
-func TestMetaRepository_Init(t *testing.T) {
+func TestMetaRepository_GetSetAuthToken(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	repo := &MetaRepository{db: db}
-	err := repo.init()
-	require.NoError(t, err)
+	repo := NewMetaRepository(db)
+
+	ctx := context.Background()
 
-	var tableExists bool
-	err = db.QueryRow("SELECT 1 FROM sqlite_master WHERE type='table' AND name='meta'").Scan(&tableExists)
+	initialToken, err := repo.GetAuthToken(ctx)
 	require.NoError(t, err)
-	assert.True(t, tableExists)
+	assert.Empty(t, initialToken)
 
-	var lastSync int64
-	var masterPasswordHash string
-	err = db.QueryRow("SELECT last_sync, master_password_hash FROM meta WHERE id = 0").Scan(&lastSync, &masterPasswordHash)
+	err = repo.SetAuthToken(ctx, []byte("encrypted-token"))
 	require.NoError(t, err)
-	assert.Equal(t, int64(0), lastSync)
-	assert.Equal(t, "", masterPasswordHash)
 
-	err = repo.init()
+	updatedToken, err := repo.GetAuthToken(ctx)
 	require.NoError(t, err)
+	assert.Equal(t, []byte("encrypted-token"), updatedToken)
 }
@@ -15,29 +15,26 @@ type UserDataRepository struct {
 	db *sql.DB
 }
 
-func NewUserDataRepository(db *sql.DB) (*UserDataRepository, error) {
-	repo := &UserDataRepository{db: db}
-	if err := repo.init(); err != nil {
-		return nil, err
-	}
-
-	return repo, nil
+func NewUserDataRepository(db *sql.DB) *UserDataRepository {
+	return &UserDataRepository{db: db}
 }
 
-func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) error {
-	query := `
-		INSERT INTO user_data (data_key, data_value, updated_at, deleted_at)
-		VALUES (?, ?, ?, ?)
-		ON CONFLICT(data_key) DO UPDATE SET
-			data_value=excluded.data_value,
-			updated_at=excluded.updated_at,
-			deleted_at=excluded.deleted_at
-	`
+const upsertUserDataQuery = `
+	INSERT INTO user_data (data_key, data_value, data_meta, updated_at, deleted_at)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT(data_key) DO UPDATE SET
+		data_value=excluded.data_value,
+		data_meta=excluded.data_meta,
+		updated_at=excluded.updated_at,
+		deleted_at=excluded.deleted_at
+`
 
+func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) error {
 	_, err := r.db.ExecContext(
-		ctx, query,
+		ctx, upsertUserDataQuery,
 		data.DataKey,
 		data.DataValue,
+		data.DataMeta,
 		data.UpdatedAt.Unix(),
 		data.DeletedAt.Unix(),
 	)
@@ -45,13 +42,44 @@ func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) e
 	return err
 }
 
+// UpsertAll writes all items in one transaction, nothing is written if one of them fails
+func (r *UserDataRepository) UpsertAll(ctx context.Context, items []*model.UserData) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, upsertUserDataQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, data := range items {
+		_, err := stmt.ExecContext(
+			ctx,
+			data.DataKey,
+			data.DataValue,
+			data.DataMeta,
+			data.UpdatedAt.Unix(),
+			data.DeletedAt.Unix(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (r *UserDataRepository) Get(ctx context.Context, key string) (*model.UserData, error) {
-	query := `SELECT id, data_key, data_value, updated_at, deleted_at FROM user_data WHERE data_key=?`
+	query := `SELECT id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE data_key=?`
 	row := r.db.QueryRowContext(ctx, query, key)
 	d := &model.UserData{}
 
 	var updatedAt, deletedAt int64
-	err := row.Scan(&d.ID, &d.DataKey, &d.DataValue, &updatedAt, &deletedAt)
+	err := row.Scan(&d.ID, &d.DataKey, &d.DataValue, &d.DataMeta, &updatedAt, &deletedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -66,10 +94,24 @@ func (r *UserDataRepository) Get(ctx context.Context, key string) (*model.UserDa
 }
 
 func (r *UserDataRepository) GetUpdates(ctx context.Context, after time.Time) ([]*model.UserData, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT id, data_key, data_value, updated_at, deleted_at FROM user_data WHERE updated_at > ?", after.Unix())
+	rows, err := r.db.QueryContext(ctx, "SELECT id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE updated_at > ?", after.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	return scanUserData(rows)
+}
+
+func (r *UserDataRepository) GetAll(ctx context.Context) ([]*model.UserData, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE deleted_at <= 0 ORDER BY data_key")
 	if err != nil {
 		return nil, err
 	}
+
+	return scanUserData(rows)
+}
+
+func scanUserData(rows *sql.Rows) ([]*model.UserData, error) {
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
@@ -79,7 +121,7 @@ func (r *UserDataRepository) GetUpdates(ctx context.Context, after time.Time) ([
 	for rows.Next() {
 		var ud model.UserData
 		var updatedAt, deletedAt int64
-		if err := rows.Scan(&ud.ID, &ud.DataKey, &ud.DataValue, &updatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&ud.ID, &ud.DataKey, &ud.DataValue, &ud.DataMeta, &updatedAt, &deletedAt); err != nil {
 			return nil, err
 		}
 		ud.UpdatedAt = time.Unix(updatedAt, 0)
@@ -88,27 +130,3 @@ func (r *UserDataRepository) GetUpdates(ctx context.Context, after time.Time) ([
 	}
 	return result, nil
 }
-
-func (r *UserDataRepository) init() error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS user_data (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			data_key TEXT NOT NULL UNIQUE,
-			data_value BLOB NOT NULL,
-			updated_at INTEGER NOT NULL,
-			deleted_at INTEGER NOT NULL
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_updated_at ON user_data(updated_at)`,
-		`CREATE INDEX IF NOT EXISTS idx_deleted_at ON user_data(deleted_at)`,
-	}
-
-	for _, query := range queries {
-		_, err := r.db.Exec(query)
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/client/migrations"
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,12 @@ func setupUserDataTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("sqlite3", ":memory:")
 	require.NoError(t, err)
 	require.NotNil(t, db)
+
+	// every connection to :memory: opens a new database
+	db.SetMaxOpenConns(1)
+	_, err = migrations.Migrate(context.Background(), db, "")
+	require.NoError(t, err)
+
 	return db
 }
 
@@ -23,12 +30,11 @@ func TestNewUserDataRepository(t *testing.T) {
 	db := setupUserDataTestDB(t)
 	defer db.Close()
 
-	repo, err := NewUserDataRepository(db)
-	require.NoError(t, err)
+	repo := NewUserDataRepository(db)
 	require.NotNil(t, repo)
 
 	var count int
-	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='user_data'").Scan(&count)
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='user_data'").Scan(&count)
 	require.NoError(t, err)
 	assert.Equal(t, 1, count)
 }
@@ -37,8 +43,7 @@ func TestUserDataRepository_Upsert(t *testing.T) {
 	db := setupUserDataTestDB(t)
 	defer db.Close()
 
-	repo, err := NewUserDataRepository(db)
-	require.NoError(t, err)
+	repo := NewUserDataRepository(db)
 
 	ctx := context.Background()
 
@@ -50,7 +55,7 @@ func TestUserDataRepository_Upsert(t *testing.T) {
 		DeletedAt: time.Unix(0, 0),
 	}
 
-	err = repo.Upsert(ctx, data)
+	err := repo.Upsert(ctx, data)
 	require.NoError(t, err)
 
 	var count int
@@ -72,12 +77,44 @@ func TestUserDataRepository_Upsert(t *testing.T) {
 	assert.Equal(t, data.UpdatedAt.Unix(), updatedAt)
 }
 
-func TestUserDataRepository_Get(t *testing.T) {
+func TestUserDataRepository_UpsertAll(t *testing.T) {
 	db := setupUserDataTestDB(t)
 	defer db.Close()
 
-	repo, err := NewUserDataRepository(db)
+	repo := NewUserDataRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	err := repo.UpsertAll(ctx, []*model.UserData{
+		{DataKey: "first", DataValue: []byte("first"), UpdatedAt: now, DeletedAt: time.Unix(0, 0)},
+		{DataKey: "second", DataValue: []byte("second"), UpdatedAt: now, DeletedAt: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+
+	all, err := repo.GetAll(ctx)
 	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// data_value is NOT NULL, the failed item rolls back the whole batch
+	err = repo.UpsertAll(ctx, []*model.UserData{
+		{DataKey: "first", DataValue: []byte("changed"), UpdatedAt: now, DeletedAt: time.Unix(0, 0)},
+		{DataKey: "broken", UpdatedAt: now, DeletedAt: time.Unix(0, 0)},
+	})
+	require.Error(t, err)
+
+	first, err := repo.Get(ctx, "first")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), first.DataValue)
+	broken, err := repo.Get(ctx, "broken")
+	require.NoError(t, err)
+	assert.Nil(t, broken)
+}
+
+func TestUserDataRepository_Get(t *testing.T) {
+	db := setupUserDataTestDB(t)
+	defer db.Close()
+
+	repo := NewUserDataRepository(db)
 
 	ctx := context.Background()
 
@@ -89,7 +126,7 @@ func TestUserDataRepository_Get(t *testing.T) {
 		DeletedAt: time.Unix(0, 0),
 	}
 
-	err = repo.Upsert(ctx, data)
+	err := repo.Upsert(ctx, data)
 	require.NoError(t, err)
 
 	result, err := repo.Get(ctx, data.DataKey)
@@ -109,8 +146,7 @@ func TestUserDataRepository_GetUpdates(t *testing.T) {
 	db := setupUserDataTestDB(t)
 	defer db.Close()
 
-	repo, err := NewUserDataRepository(db)
-	require.NoError(t, err)
+	repo := NewUserDataRepository(db)
 
 	ctx := context.Background()
 
@@ -137,7 +173,7 @@ func TestUserDataRepository_GetUpdates(t *testing.T) {
 	}
 
 	for _, data := range testData {
-		err = repo.Upsert(ctx, data)
+		err := repo.Upsert(ctx, data)
 		require.NoError(t, err)
 	}
 
@@ -158,24 +194,47 @@ func TestUserDataRepository_GetUpdates(t *testing.T) {
 	assert.Len(t, allUpdates, 3)
 }
 
-func TestUserDataRepository_Init(t *testing.T) {
+func TestUserDataRepository_GetAll(t *testing.T) {
 	db := setupUserDataTestDB(t)
 	defer db.Close()
 
-	repo := &UserDataRepository{db: db}
-	err := repo.init()
-	require.NoError(t, err)
+	repo := NewUserDataRepository(db)
 
-	var tableExists int
-	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='user_data'").Scan(&tableExists)
-	require.NoError(t, err)
-	assert.Equal(t, 1, tableExists)
+	ctx := context.Background()
 
-	var indexCount int
-	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name IN ('idx_updated_at', 'idx_deleted_at')").Scan(&indexCount)
-	require.NoError(t, err)
-	assert.Equal(t, 2, indexCount)
+	now := time.Now().UTC()
+	testData := []*model.UserData{
+		{
+			DataKey:   "b-key",
+			DataValue: []byte("value2"),
+			DataMeta:  []byte("meta2"),
+			UpdatedAt: now,
+			DeletedAt: time.Unix(0, 0),
+		},
+		{
+			DataKey:   "a-key",
+			DataValue: []byte("value1"),
+			UpdatedAt: now,
+			DeletedAt: time.Time{},
+		},
+		{
+			DataKey:   "deleted-key",
+			DataValue: []byte("value3"),
+			UpdatedAt: now,
+			DeletedAt: now,
+		},
+	}
+
+	for _, data := range testData {
+		err := repo.Upsert(ctx, data)
+		require.NoError(t, err)
+	}
 
-	err = repo.init()
+	all, err := repo.GetAll(ctx)
 	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "a-key", all[0].DataKey)
+	assert.Nil(t, all[0].DataMeta)
+	assert.Equal(t, "b-key", all[1].DataKey)
+	assert.Equal(t, []byte("meta2"), all[1].DataMeta)
 }
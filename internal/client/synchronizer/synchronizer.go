@@ -2,18 +2,31 @@ package synchronizer
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/common/logger"
 	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/m1khal3v/gophkeeper/internal/common/protocol"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var ErrSyncFailed = errors.New("synchronization failed")
+
+var tracer = otel.Tracer("github.com/m1khal3v/gophkeeper/internal/client/synchronizer")
+
 type GRPCClient interface {
 	Upsert(ctx context.Context, data *model.UserData) (*proto.DataResponse, error)
 	GetUpdates(ctx context.Context, lastSync time.Time) (*proto.DataListResponse, error)
+	ServerInfo(ctx context.Context) (*proto.ServerInfoResponse, error)
 }
 
 type UserDataManager interface {
@@ -26,6 +39,23 @@ type MetaManager interface {
 	SetLastSync(ctx context.Context, lastSync time.Time) error
 }
 
+// Stats describes a single sync run, items pushed before a failure are counted too
+type Stats struct {
+	Duration  time.Duration
+	Pushed    int
+	Pulled    int
+	Succeeded bool
+}
+
+// Recorder observes sync runs, e.g. to export metrics
+type Recorder interface {
+	ObserveSync(stats *Stats)
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) ObserveSync(*Stats) {}
+
 type Synchronizer struct {
 	client      GRPCClient
 	userDataMgr UserDataManager
@@ -33,6 +63,8 @@ type Synchronizer struct {
 	interval    time.Duration
 	stopCh      chan struct{}
 	wg          sync.WaitGroup
+	checked     atomic.Bool
+	recorder    Recorder
 }
 
 func New(
@@ -47,9 +79,14 @@ func New(
 		metaManager: metaManager,
 		interval:    interval,
 		stopCh:      make(chan struct{}),
+		recorder:    noopRecorder{},
 	}
 }
 
+func (s *Synchronizer) SetRecorder(recorder Recorder) {
+	s.recorder = recorder
+}
+
 func (s *Synchronizer) Start(ctx context.Context) {
 	s.wg.Add(1)
 	go func() {
@@ -79,57 +116,125 @@ func (s *Synchronizer) Stop() {
 	s.wg.Wait()
 }
 
-func (s *Synchronizer) syncOnce(ctx context.Context) {
+func (s *Synchronizer) SyncOnce(ctx context.Context) error {
+	if !s.syncOnce(ctx) {
+		return ErrSyncFailed
+	}
+
+	return nil
+}
+
+func (s *Synchronizer) syncOnce(ctx context.Context) bool {
+	ctx, span := tracer.Start(ctx, "Synchronizer.syncOnce")
+	start := time.Now()
+	stats := &Stats{}
+	defer func() {
+		stats.Duration = time.Since(start)
+		s.recorder.ObserveSync(stats)
+
+		span.SetAttributes(
+			attribute.Int("sync.pushed", stats.Pushed),
+			attribute.Int("sync.pulled", stats.Pulled),
+		)
+		if !stats.Succeeded {
+			span.SetStatus(codes.Error, ErrSyncFailed.Error())
+		}
+		span.End()
+	}()
+
+	s.checkServer(ctx)
+
 	lastSync, err := s.metaManager.GetLastSync(ctx)
 	if err != nil {
-		logger.Logger.Fatal("sync: get lastSync error:", zap.Error(err))
+		logger.FromContext(ctx).Fatal("sync: get lastSync error:", zap.Error(err))
 	}
 
-	if s.pushLocalUpdates(ctx, lastSync) && s.fetchRemoteUpdates(ctx, lastSync) {
-		if err := s.metaManager.SetLastSync(ctx, time.Now().UTC()); err != nil {
-			logger.Logger.Fatal("sync: set lastSync error:", zap.Error(err))
+	var ok bool
+	if stats.Pushed, ok = s.pushLocalUpdates(ctx, lastSync); !ok {
+		return false
+	}
+	if stats.Pulled, ok = s.fetchRemoteUpdates(ctx, lastSync); !ok {
+		return false
+	}
+
+	if err := s.metaManager.SetLastSync(ctx, time.Now().UTC()); err != nil {
+		logger.FromContext(ctx).Fatal("sync: set lastSync error:", zap.Error(err))
+	}
+	stats.Succeeded = true
+
+	return true
+}
+
+// checkServer warns once about a server of another protocol version or without required features
+func (s *Synchronizer) checkServer(ctx context.Context) {
+	if s.checked.Load() {
+		return
+	}
+
+	info, err := s.client.ServerInfo(ctx)
+	switch {
+	case status.Code(err) == grpccodes.Unimplemented:
+		logger.FromContext(ctx).Warn("sync: server doesn`t report its version, it may be outdated")
+	case err != nil:
+		// connection errors are reported by the sync itself, check again next time
+		return
+	case info.ProtocolVersion != protocol.Version:
+		logger.FromContext(ctx).Warn("sync: incompatible server protocol version",
+			zap.Uint32("server_protocol", info.ProtocolVersion),
+			zap.Uint32("client_protocol", protocol.Version),
+			zap.String("server_version", info.Version),
+		)
+	default:
+		if missing := protocol.Missing(info.Features); len(missing) > 0 {
+			logger.FromContext(ctx).Warn("sync: server doesn`t support required features",
+				zap.Strings("features", missing),
+				zap.String("server_version", info.Version),
+			)
 		}
 	}
+
+	s.checked.Store(true)
 }
 
-func (s *Synchronizer) pushLocalUpdates(ctx context.Context, lastSync time.Time) bool {
+func (s *Synchronizer) pushLocalUpdates(ctx context.Context, lastSync time.Time) (int, bool) {
 	localUpdates, err := s.userDataMgr.GetUpdates(ctx, lastSync)
 	if err != nil {
-		logger.Logger.Fatal("sync: can't get local updates:", zap.Error(err))
+		logger.FromContext(ctx).Fatal("sync: can't get local updates:", zap.Error(err))
 	}
 
-	for _, data := range localUpdates {
+	for i, data := range localUpdates {
 		_, err := s.client.Upsert(ctx, data)
 		if err != nil {
-			logger.Logger.Warn("sync: can't push local update to server", zap.Error(err))
+			logger.FromContext(ctx).Warn("sync: can't push local update to server", zap.Error(err))
 
-			return false
+			return i, false
 		}
 	}
 
-	return true
+	return len(localUpdates), true
 }
 
-func (s *Synchronizer) fetchRemoteUpdates(ctx context.Context, lastSync time.Time) bool {
+func (s *Synchronizer) fetchRemoteUpdates(ctx context.Context, lastSync time.Time) (int, bool) {
 	resp, err := s.client.GetUpdates(ctx, lastSync)
 	if err != nil {
-		logger.Logger.Warn("sync: can`t get updates:", zap.Error(err))
+		logger.FromContext(ctx).Warn("sync: can`t get updates:", zap.Error(err))
 
-		return false
+		return 0, false
 	}
 
 	for _, item := range resp.Items {
 		data := &model.UserData{
 			DataKey:   item.DataKey,
 			DataValue: item.DataValue,
+			DataMeta:  item.DataMeta,
 			UpdatedAt: item.UpdatedAt.AsTime(),
 			DeletedAt: item.DeletedAt.AsTime(),
 		}
 
 		if err := s.userDataMgr.Upsert(ctx, data); err != nil {
-			logger.Logger.Fatal("sync: can't update local data:", zap.Error(err))
+			logger.FromContext(ctx).Fatal("sync: can't update local data:", zap.Error(err))
 		}
 	}
 
-	return true
+	return len(resp.Items), true
 }
@@ -8,8 +8,18 @@ import (
 
 	"github.com/m1khal3v/gophkeeper/internal/client/model"
 	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/m1khal3v/gophkeeper/internal/common/protocol"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockGRPCClient struct {
@@ -26,6 +36,15 @@ func (m *MockGRPCClient) GetUpdates(ctx context.Context, lastSync time.Time) (*p
 	return args.Get(0).(*proto.DataListResponse), args.Error(1)
 }
 
+func (m *MockGRPCClient) ServerInfo(ctx context.Context) (*proto.ServerInfoResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*proto.ServerInfoResponse), args.Error(1)
+}
+
+func compatibleServer() *proto.ServerInfoResponse {
+	return &proto.ServerInfoResponse{Version: "v1.0.0", ProtocolVersion: protocol.Version, Features: protocol.Features}
+}
+
 type MockUserDataManager struct {
 	mock.Mock
 }
@@ -71,6 +90,7 @@ func TestNew(t *testing.T) {
 
 func TestSynchronizer_StartStop(t *testing.T) {
 	client := &MockGRPCClient{}
+	client.On("ServerInfo", mock.Anything).Return(compatibleServer(), nil)
 	userDataMgr := &MockUserDataManager{}
 	metaManager := &MockMetaManager{}
 	interval := time.Millisecond * 50
@@ -98,6 +118,7 @@ func TestSynchronizer_StartStop(t *testing.T) {
 
 func TestSynchronizer_syncOnce(t *testing.T) {
 	client := &MockGRPCClient{}
+	client.On("ServerInfo", mock.Anything).Return(compatibleServer(), nil)
 	userDataMgr := &MockUserDataManager{}
 	metaManager := &MockMetaManager{}
 	interval := time.Second * 30
@@ -127,15 +148,129 @@ func TestSynchronizer_syncOnce(t *testing.T) {
 
 	s := New(client, userDataMgr, metaManager, interval)
 
-	ctx := context.Background()
+	// the calls get the sync span context derived from ctx
+	ctx := context.WithValue(context.Background(), ctxKey{}, "caller")
+	derived := mock.MatchedBy(func(c context.Context) bool {
+		return c.Value(ctxKey{}) == "caller"
+	})
 	s.syncOnce(ctx)
 
-	metaManager.AssertCalled(t, "GetLastSync", ctx)
-	userDataMgr.AssertCalled(t, "GetUpdates", ctx, lastSyncTime)
-	client.AssertCalled(t, "Upsert", ctx, localUpdate)
-	client.AssertCalled(t, "GetUpdates", ctx, lastSyncTime)
-	userDataMgr.AssertCalled(t, "Upsert", ctx, mock.AnythingOfType("*model.UserData"))
-	metaManager.AssertCalled(t, "SetLastSync", ctx, mock.AnythingOfType("time.Time"))
+	metaManager.AssertCalled(t, "GetLastSync", derived)
+	userDataMgr.AssertCalled(t, "GetUpdates", derived, lastSyncTime)
+	client.AssertCalled(t, "Upsert", derived, localUpdate)
+	client.AssertCalled(t, "GetUpdates", derived, lastSyncTime)
+	userDataMgr.AssertCalled(t, "Upsert", derived, mock.AnythingOfType("*model.UserData"))
+	metaManager.AssertCalled(t, "SetLastSync", derived, mock.AnythingOfType("time.Time"))
+}
+
+type ctxKey struct{}
+
+func TestSynchronizer_SyncOnce(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := &MockGRPCClient{}
+		client.On("ServerInfo", mock.Anything).Return(compatibleServer(), nil)
+		userDataMgr := &MockUserDataManager{}
+		metaManager := &MockMetaManager{}
+
+		metaManager.On("GetLastSync", mock.Anything).Return(time.Unix(0, 0), nil)
+		userDataMgr.On("GetUpdates", mock.Anything, mock.Anything).Return([]*model.UserData{}, nil)
+		client.On("GetUpdates", mock.Anything, mock.Anything).Return(&proto.DataListResponse{}, nil)
+		metaManager.On("SetLastSync", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+
+		err := New(client, userDataMgr, metaManager, time.Minute).SyncOnce(context.Background())
+
+		assert.NoError(t, err)
+		metaManager.AssertExpectations(t)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		client := &MockGRPCClient{}
+		client.On("ServerInfo", mock.Anything).Return(compatibleServer(), nil)
+		userDataMgr := &MockUserDataManager{}
+		metaManager := &MockMetaManager{}
+
+		metaManager.On("GetLastSync", mock.Anything).Return(time.Unix(0, 0), nil)
+		userDataMgr.On("GetUpdates", mock.Anything, mock.Anything).Return([]*model.UserData{}, nil)
+		client.On("GetUpdates", mock.Anything, mock.Anything).Return((*proto.DataListResponse)(nil), errors.New("unauthenticated"))
+
+		err := New(client, userDataMgr, metaManager, time.Minute).SyncOnce(context.Background())
+
+		assert.ErrorIs(t, err, ErrSyncFailed)
+		metaManager.AssertNotCalled(t, "SetLastSync", mock.Anything, mock.Anything)
+	})
+}
+
+type statsRecorder struct {
+	stats []*Stats
+}
+
+func (r *statsRecorder) ObserveSync(stats *Stats) {
+	r.stats = append(r.stats, stats)
+}
+
+func TestSynchronizer_Recorder(t *testing.T) {
+	client := &MockGRPCClient{}
+	client.On("ServerInfo", mock.Anything).Return(compatibleServer(), nil)
+	userDataMgr := &MockUserDataManager{}
+	metaManager := &MockMetaManager{}
+
+	localUpdates := []*model.UserData{{DataKey: "key1"}, {DataKey: "key2"}}
+	metaManager.On("GetLastSync", mock.Anything).Return(time.Time{}, nil)
+	metaManager.On("SetLastSync", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	userDataMgr.On("GetUpdates", mock.Anything, mock.Anything).Return(localUpdates, nil)
+	userDataMgr.On("Upsert", mock.Anything, mock.Anything).Return(nil)
+	client.On("Upsert", mock.Anything, localUpdates[0]).Return(&proto.DataResponse{}, nil)
+	client.On("Upsert", mock.Anything, localUpdates[1]).Return(&proto.DataResponse{}, nil).Once()
+	client.On("Upsert", mock.Anything, localUpdates[1]).Return((*proto.DataResponse)(nil), errors.New("unavailable"))
+	client.On("GetUpdates", mock.Anything, mock.Anything).Return(&proto.DataListResponse{
+		Items: []*proto.DataResponse{{DataKey: "remote"}},
+	}, nil)
+
+	recorder := &statsRecorder{}
+	s := New(client, userDataMgr, metaManager, time.Minute)
+	s.SetRecorder(recorder)
+
+	assert.NoError(t, s.SyncOnce(context.Background()))
+	assert.ErrorIs(t, s.SyncOnce(context.Background()), ErrSyncFailed)
+
+	assert.Len(t, recorder.stats, 2)
+	assert.Equal(t, 2, recorder.stats[0].Pushed)
+	assert.Equal(t, 1, recorder.stats[0].Pulled)
+	assert.True(t, recorder.stats[0].Succeeded)
+	assert.Positive(t, recorder.stats[0].Duration)
+	assert.Equal(t, 1, recorder.stats[1].Pushed)
+	assert.Equal(t, 0, recorder.stats[1].Pulled)
+	assert.False(t, recorder.stats[1].Succeeded)
+}
+
+func TestSynchronizer_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	client := &MockGRPCClient{}
+	userDataMgr := &MockUserDataManager{}
+	metaManager := &MockMetaManager{}
+
+	// the server calls carry the sync span, the gRPC stats handler propagates it
+	inSpan := mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).IsValid()
+	})
+	client.On("ServerInfo", inSpan).Return(compatibleServer(), nil)
+	client.On("GetUpdates", inSpan, mock.Anything).Return((*proto.DataListResponse)(nil), errors.New("unavailable"))
+	metaManager.On("GetLastSync", mock.Anything).Return(time.Time{}, nil)
+	userDataMgr.On("GetUpdates", mock.Anything, mock.Anything).Return([]*model.UserData{}, nil)
+
+	err := New(client, userDataMgr, metaManager, time.Minute).SyncOnce(context.Background())
+	assert.ErrorIs(t, err, ErrSyncFailed)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "Synchronizer.syncOnce", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), attribute.Int("sync.pushed", 0))
+	}
+	client.AssertExpectations(t)
 }
 
 func TestSynchronizer_pushLocalUpdates(t *testing.T) {
@@ -143,6 +278,7 @@ func TestSynchronizer_pushLocalUpdates(t *testing.T) {
 		name           string
 		setupMocks     func(*MockUserDataManager, *MockGRPCClient)
 		expectedResult bool
+		expectedCount  int
 	}{
 		{
 			name: "success_no_updates",
@@ -166,6 +302,7 @@ func TestSynchronizer_pushLocalUpdates(t *testing.T) {
 				}
 			},
 			expectedResult: true,
+			expectedCount:  2,
 		},
 		{
 			name: "failure_upsert_error",
@@ -191,9 +328,10 @@ func TestSynchronizer_pushLocalUpdates(t *testing.T) {
 			tt.setupMocks(userDataMgr, client)
 
 			s := New(client, userDataMgr, metaManager, interval)
-			result := s.pushLocalUpdates(context.Background(), time.Now())
+			count, result := s.pushLocalUpdates(context.Background(), time.Now())
 
 			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedCount, count)
 			userDataMgr.AssertExpectations(t)
 			client.AssertExpectations(t)
 		})
@@ -205,6 +343,7 @@ func TestSynchronizer_fetchRemoteUpdates(t *testing.T) {
 		name           string
 		setupMocks     func(*MockGRPCClient, *MockUserDataManager)
 		expectedResult bool
+		expectedCount  int
 	}{
 		{
 			name: "success_no_updates",
@@ -228,6 +367,7 @@ func TestSynchronizer_fetchRemoteUpdates(t *testing.T) {
 				}
 			},
 			expectedResult: true,
+			expectedCount:  2,
 		},
 		{
 			name: "failure_get_updates_error",
@@ -249,11 +389,44 @@ func TestSynchronizer_fetchRemoteUpdates(t *testing.T) {
 			tt.setupMocks(client, userDataMgr)
 
 			s := New(client, userDataMgr, metaManager, interval)
-			result := s.fetchRemoteUpdates(context.Background(), time.Now())
+			count, result := s.fetchRemoteUpdates(context.Background(), time.Now())
 
 			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedCount, count)
 			client.AssertExpectations(t)
 			userDataMgr.AssertExpectations(t)
 		})
 	}
 }
+
+func TestSynchronizer_checkServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        *proto.ServerInfoResponse
+		err         error
+		wantChecked bool
+	}{
+		{name: "compatible", info: compatibleServer(), wantChecked: true},
+		{name: "other protocol", info: &proto.ServerInfoResponse{ProtocolVersion: protocol.Version + 1}, wantChecked: true},
+		{name: "missing features", info: &proto.ServerInfoResponse{ProtocolVersion: protocol.Version}, wantChecked: true},
+		{name: "outdated server", info: (*proto.ServerInfoResponse)(nil), err: status.Error(grpccodes.Unimplemented, "unknown service"), wantChecked: true},
+		{name: "unavailable", info: (*proto.ServerInfoResponse)(nil), err: status.Error(grpccodes.Unavailable, "connection refused"), wantChecked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGRPCClient{}
+			client.On("ServerInfo", mock.Anything).Return(tt.info, tt.err).Once()
+
+			s := New(client, &MockUserDataManager{}, &MockMetaManager{}, time.Second)
+			s.checkServer(context.Background())
+			assert.Equal(t, tt.wantChecked, s.checked.Load())
+
+			if tt.wantChecked {
+				// the server is asked only once
+				s.checkServer(context.Background())
+				client.AssertNumberOfCalls(t, "ServerInfo", 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package value
+
+import "github.com/m1khal3v/gophkeeper/internal/client/aes"
+
+// Decrypt decodes a value stored encrypted with the master password
+func Decrypt(masterPassword, encrypted []byte) (Value, error) {
+	raw, err := aes.Decrypt(masterPassword, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromBytes(raw)
+}
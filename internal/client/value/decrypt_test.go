@@ -0,0 +1,30 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/client/aes"
+)
+
+func TestDecrypt(t *testing.T) {
+	raw, err := (&TextValue{Text: "note"}).ToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := aes.Encrypt([]byte("master"), raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := Decrypt([]byte("master"), encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if text, ok := v.(*TextValue); !ok || text.Text != "note" {
+		t.Errorf("Decrypt() = %v, want note", v)
+	}
+
+	if _, err := Decrypt([]byte("wrong"), encrypted); err == nil {
+		t.Error("Decrypt() with wrong password did not return error")
+	}
+}
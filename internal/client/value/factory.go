@@ -1,7 +1,6 @@
 package value
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,12 +32,7 @@ func FromBytes(data []byte) (Value, error) {
 		}
 		return &v, nil
 	case typeBinary:
-		data, err := base64.StdEncoding.DecodeString(string(payload))
-		if err != nil {
-			return nil, fmt.Errorf("invalid binary: %w", err)
-		}
-
-		return &BinaryValue{Data: data}, nil
+		return &BinaryValue{Data: append([]byte(nil), payload...)}, nil
 	case typeCard:
 		var v CardValue
 		if err := json.Unmarshal(payload, &v); err != nil {
@@ -1,7 +1,6 @@
 package value
 
 import (
-	"encoding/base64"
 	"os"
 	"testing"
 )
@@ -86,9 +85,7 @@ func TestFromBytes(t *testing.T) {
 		{
 			name: "valid binary",
 			setup: func() []byte {
-				binData := []byte("test binary data")
-				encoded := base64.StdEncoding.EncodeToString(binData)
-				return append([]byte{byte(typeBinary)}, encoded...)
+				return append([]byte{byte(typeBinary)}, []byte("test binary data")...)
 			},
 			validate: func(t *testing.T, v Value, err error) {
 				if err != nil {
@@ -157,13 +154,18 @@ func TestFromBytes(t *testing.T) {
 			},
 		},
 		{
-			name: "invalid binary data",
+			name: "binary data is not base64",
 			setup: func() []byte {
 				return append([]byte{byte(typeBinary)}, []byte("invalid base64")...)
 			},
 			validate: func(t *testing.T, v Value, err error) {
-				if err == nil {
-					t.Errorf("Expected error for invalid base64, got nil")
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				bv, ok := v.(*BinaryValue)
+				if !ok || string(bv.Data) != "invalid base64" {
+					t.Errorf("Wrong binary value: %v", v)
 				}
 			},
 		},
@@ -189,6 +191,24 @@ func TestFromBytes(t *testing.T) {
 	}
 }
 
+// binary items are stored by ToBytes as raw data, FromBytes must read them back unchanged
+func TestFromBytes_BinaryRoundTrip(t *testing.T) {
+	want := []byte{0, 1, 2, 255}
+	data, err := (&BinaryValue{Data: want}).ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+
+	v, err := FromBytes(data)
+	if err != nil {
+		t.Fatalf("FromBytes() error = %v", err)
+	}
+	bv, ok := v.(*BinaryValue)
+	if !ok || string(bv.Data) != string(want) {
+		t.Errorf("FromBytes() = %v, want %v", v, want)
+	}
+}
+
 func TestFromUserInput(t *testing.T) {
 	// Create a temporary file for binary tests
 	binaryFile, err := os.CreateTemp("", "binary_test")
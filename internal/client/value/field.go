@@ -0,0 +1,88 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const mask = "********"
+
+var ErrUnknownField = errors.New("unknown field")
+
+func FieldNames() []string {
+	return []string{"login", "password", "number", "holder", "expire", "cvc", "text"}
+}
+
+// DefaultField returns the field copied when no field is given
+func DefaultField(v Value) string {
+	switch v.(type) {
+	case *LoginPassword:
+		return "password"
+	case *CardValue:
+		return "number"
+	case *TextValue:
+		return "text"
+	default:
+		return ""
+	}
+}
+
+// Field returns a single field of the value, an empty name selects the default field
+func Field(v Value, name string) (string, error) {
+	if name == "" {
+		name = DefaultField(v)
+	}
+
+	switch v := v.(type) {
+	case *LoginPassword:
+		switch name {
+		case "login":
+			return v.Login, nil
+		case "password":
+			return v.Password, nil
+		}
+	case *CardValue:
+		switch name {
+		case "number":
+			return v.Number, nil
+		case "holder":
+			return v.Holder, nil
+		case "expire":
+			return fmt.Sprintf("%02d/%d", v.ExpireMonth, v.ExpireYear), nil
+		case "cvc":
+			return v.CVC, nil
+		}
+	case *TextValue:
+		if name == "text" {
+			return v.Text, nil
+		}
+	case *BinaryValue:
+		return "", errors.New("binary value has no text fields")
+	}
+
+	return "", fmt.Errorf("%w %q for %s", ErrUnknownField, name, TypeName(v))
+}
+
+// Masked returns a copy of the value with passwords, card number and CVC hidden
+func Masked(v Value) Value {
+	switch v := v.(type) {
+	case *LoginPassword:
+		return &LoginPassword{Login: v.Login, Password: mask}
+	case *CardValue:
+		masked := *v
+		masked.Number = maskCardNumber(v.Number)
+		masked.CVC = mask
+		return &masked
+	default:
+		return v
+	}
+}
+
+func maskCardNumber(number string) string {
+	if len(number) <= 4 {
+		return mask
+	}
+
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
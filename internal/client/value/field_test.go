@@ -0,0 +1,85 @@
+package value
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestField(t *testing.T) {
+	login := &LoginPassword{Login: "user", Password: "secret"}
+	card := &CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}
+	text := &TextValue{Text: "note"}
+
+	tests := []struct {
+		name  string
+		value Value
+		field string
+		want  string
+	}{
+		{name: "default password", value: login, field: "", want: "secret"},
+		{name: "login", value: login, field: "login", want: "user"},
+		{name: "default card number", value: card, field: "", want: "4111111111111111"},
+		{name: "card holder", value: card, field: "holder", want: "JOHN DOE"},
+		{name: "card expire", value: card, field: "expire", want: "05/2027"},
+		{name: "card cvc", value: card, field: "cvc", want: "123"},
+		{name: "text", value: text, field: "text", want: "note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Field(tt.value, tt.field)
+			if err != nil {
+				t.Fatalf("Field() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Field() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField_Errors(t *testing.T) {
+	_, err := Field(&LoginPassword{}, "cvc")
+	if !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Field() error = %v, want %v", err, ErrUnknownField)
+	}
+	if err.Error() != `unknown field "cvc" for login_password` {
+		t.Errorf("Field() error = %v", err)
+	}
+
+	if _, err := Field(&BinaryValue{Data: []byte{1}}, ""); err == nil {
+		t.Error("Field() expected error for binary value")
+	}
+}
+
+func TestDefaultField(t *testing.T) {
+	if got := DefaultField(&CardValue{}); got != "number" {
+		t.Errorf("DefaultField() = %v, want number", got)
+	}
+	if got := DefaultField(&BinaryValue{}); got != "" {
+		t.Errorf("DefaultField() = %v, want empty", got)
+	}
+}
+
+func TestMasked(t *testing.T) {
+	login := &LoginPassword{Login: "user", Password: "secret"}
+	if got := Masked(login).String(); got != "Login: user, Password: ********" {
+		t.Errorf("Masked() = %v", got)
+	}
+	if login.Password != "secret" {
+		t.Error("Masked() changed the original value")
+	}
+
+	card := &CardValue{Number: "4111111111111111", Holder: "JOHN DOE", ExpireMonth: 5, ExpireYear: 2027, CVC: "123"}
+	if got := Masked(card).String(); got != "Card: ************1111, JOHN DOE, 5/2027, ********" {
+		t.Errorf("Masked() = %v", got)
+	}
+	if card.CVC != "123" {
+		t.Error("Masked() changed the original value")
+	}
+
+	text := &TextValue{Text: "note"}
+	if got := Masked(text); got != text {
+		t.Errorf("Masked() = %v, want unchanged text", got)
+	}
+}
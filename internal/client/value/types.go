@@ -33,3 +33,31 @@ func newValueTypeFromString(s string) (vType, error) {
 		return 0, fmt.Errorf("invalid value type: %s", s)
 	}
 }
+
+func (t vType) String() string {
+	switch t {
+	case typeLoginPassword:
+		return "login_password"
+	case typeText:
+		return "text"
+	case typeBinary:
+		return "binary"
+	case typeCard:
+		return "card"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(t))
+	}
+}
+
+func TypeName(v Value) string {
+	return v.vType().String()
+}
+
+func TypeNames() []string {
+	return []string{
+		typeLoginPassword.String(),
+		typeText.String(),
+		typeBinary.String(),
+		typeCard.String(),
+	}
+}
@@ -115,3 +115,38 @@ func TestNewValueTypeFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestValueType_String(t *testing.T) {
+	for _, name := range []string{"login_password", "text", "binary", "card"} {
+		typ, err := newValueTypeFromString(name)
+		if err != nil {
+			t.Fatalf("newValueTypeFromString(%q) error = %v", name, err)
+		}
+		if typ.String() != name {
+			t.Errorf("String() = %v, want %v", typ.String(), name)
+		}
+	}
+
+	if got := vType(42).String(); got != "unknown(42)" {
+		t.Errorf("String() = %v, want unknown(42)", got)
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	if got := TypeName(&CardValue{}); got != "card" {
+		t.Errorf("TypeName() = %v, want card", got)
+	}
+}
+
+func TestTypeNames(t *testing.T) {
+	names := TypeNames()
+	if len(names) != 4 {
+		t.Fatalf("TypeNames() = %v, want 4 names", names)
+	}
+
+	for _, name := range names {
+		if _, err := newValueTypeFromString(name); err != nil {
+			t.Errorf("newValueTypeFromString(%q) error = %v", name, err)
+		}
+	}
+}
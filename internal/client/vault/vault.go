@@ -0,0 +1,135 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrLocked = errors.New("vault is locked, run unlock")
+
+type PasswordValidator interface {
+	ValidateMasterPassword(ctx context.Context, password string) error
+}
+
+type Vault struct {
+	mu             sync.Mutex
+	validator      PasswordValidator
+	masterPassword []byte
+	idleTimeout    time.Duration
+	timer          *time.Timer
+	// generation invalidates timer callbacks that fired while the vault was unlocked or touched again
+	generation uint64
+	onLock     func()
+}
+
+// New creates a locked vault, idleTimeout <= 0 disables auto-lock
+func New(validator PasswordValidator, idleTimeout time.Duration) *Vault {
+	return &Vault{
+		validator:   validator,
+		idleTimeout: idleTimeout,
+	}
+}
+
+// OnAutoLock sets a callback called when the vault is locked by the idle timer
+func (v *Vault) OnAutoLock(callback func()) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.onLock = callback
+}
+
+func (v *Vault) Unlock(ctx context.Context, password []byte) error {
+	if err := v.validator.ValidateMasterPassword(ctx, string(password)); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.lock()
+	v.masterPassword = bytes.Clone(password)
+	v.startTimer()
+
+	return nil
+}
+
+func (v *Vault) Lock() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.lock()
+}
+
+func (v *Vault) Locked() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.masterPassword == nil
+}
+
+// MasterPassword returns a copy of the master password and postpones auto-lock.
+// Callers should clear the copy once it is no longer needed.
+func (v *Vault) MasterPassword() ([]byte, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.masterPassword == nil {
+		return nil, ErrLocked
+	}
+	v.startTimer()
+
+	return bytes.Clone(v.masterPassword), nil
+}
+
+// Touch postpones auto-lock of an unlocked vault, it reports user activity that doesn't need the key
+func (v *Vault) Touch() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.masterPassword != nil {
+		v.startTimer()
+	}
+}
+
+func (v *Vault) startTimer() {
+	v.stopTimer()
+	if v.idleTimeout <= 0 {
+		return
+	}
+
+	generation := v.generation
+	v.timer = time.AfterFunc(v.idleTimeout, func() { v.autoLock(generation) })
+}
+
+func (v *Vault) stopTimer() {
+	v.generation++
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+}
+
+func (v *Vault) autoLock(generation uint64) {
+	v.mu.Lock()
+	// the timer was stopped or restarted after it had fired
+	if v.masterPassword == nil || v.generation != generation {
+		v.mu.Unlock()
+		return
+	}
+	v.lock()
+	callback := v.onLock
+	v.mu.Unlock()
+
+	if callback != nil {
+		callback()
+	}
+}
+
+func (v *Vault) lock() {
+	v.stopTimer()
+	clear(v.masterPassword)
+	v.masterPassword = nil
+}
@@ -0,0 +1,140 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockValidator struct {
+	password string
+}
+
+func (m *mockValidator) ValidateMasterPassword(ctx context.Context, password string) error {
+	if password != m.password {
+		return errors.New("invalid master password")
+	}
+	return nil
+}
+
+func TestVault_LockUnlock(t *testing.T) {
+	v := New(&mockValidator{password: "secret"}, 0)
+	assert.True(t, v.Locked())
+
+	_, err := v.MasterPassword()
+	assert.ErrorIs(t, err, ErrLocked)
+
+	err = v.Unlock(context.Background(), []byte("wrong"))
+	assert.EqualError(t, err, "invalid master password")
+	assert.True(t, v.Locked())
+
+	password := []byte("secret")
+	require.NoError(t, v.Unlock(context.Background(), password))
+	assert.False(t, v.Locked())
+
+	clear(password)
+	got, err := v.MasterPassword()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), got)
+
+	// callers own their copy
+	clear(got)
+	got, err = v.MasterPassword()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), got)
+
+	stored := v.masterPassword
+	v.Lock()
+	assert.True(t, v.Locked())
+	assert.Equal(t, make([]byte, len(stored)), stored)
+
+	_, err = v.MasterPassword()
+	assert.ErrorIs(t, err, ErrLocked)
+}
+
+func TestVault_AutoLock(t *testing.T) {
+	v := New(&mockValidator{password: "secret"}, 50*time.Millisecond)
+	locked := make(chan struct{})
+	v.OnAutoLock(func() { close(locked) })
+
+	require.NoError(t, v.Unlock(context.Background(), []byte("secret")))
+
+	// activity postpones auto-lock
+	for range 4 {
+		time.Sleep(20 * time.Millisecond)
+		_, err := v.MasterPassword()
+		require.NoError(t, err)
+	}
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("vault was not locked after idle timeout")
+	}
+	assert.True(t, v.Locked())
+}
+
+func TestVault_ManualLockStopsTimer(t *testing.T) {
+	v := New(&mockValidator{password: "secret"}, 20*time.Millisecond)
+	called := false
+	v.OnAutoLock(func() { called = true })
+
+	require.NoError(t, v.Unlock(context.Background(), []byte("secret")))
+	v.Lock()
+	time.Sleep(50 * time.Millisecond)
+
+	assert.False(t, called)
+}
+
+func TestVault_StaleAutoLock(t *testing.T) {
+	v := New(&mockValidator{password: "secret"}, time.Hour)
+	called := false
+	v.OnAutoLock(func() { called = true })
+
+	require.NoError(t, v.Unlock(context.Background(), []byte("secret")))
+	stale := v.generation
+
+	// a callback that fired before the vault was unlocked again must not lock it
+	v.Lock()
+	require.NoError(t, v.Unlock(context.Background(), []byte("secret")))
+	v.autoLock(stale)
+	assert.False(t, v.Locked())
+
+	// same for a callback that fired before activity restarted the timer
+	stale = v.generation
+	v.Touch()
+	v.autoLock(stale)
+	assert.False(t, v.Locked())
+	assert.False(t, called)
+
+	v.autoLock(v.generation)
+	assert.True(t, v.Locked())
+	assert.True(t, called)
+}
+
+func TestVault_Touch(t *testing.T) {
+	v := New(&mockValidator{password: "secret"}, 50*time.Millisecond)
+	locked := make(chan struct{})
+	v.OnAutoLock(func() { close(locked) })
+
+	// touching a locked vault doesn't start the timer
+	v.Touch()
+	assert.Nil(t, v.timer)
+
+	require.NoError(t, v.Unlock(context.Background(), []byte("secret")))
+	for range 4 {
+		time.Sleep(20 * time.Millisecond)
+		v.Touch()
+	}
+	assert.False(t, v.Locked())
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("vault was not locked after idle timeout")
+	}
+}
@@ -2,6 +2,12 @@ package buildlog
 
 import "fmt"
 
+type Info struct {
+	Version string
+	Date    string
+	Commit  string
+}
+
 func Print(version, date, commit string) {
 	fmt.Printf("Build version: %s\n", version)
 	fmt.Printf("Build date: %s\n", date)
@@ -1,8 +1,10 @@
 package logger
 
 import (
+	"context"
 	"sync"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -26,3 +28,16 @@ func Init(name, level string) {
 		Logger = logger.Named(name)
 	})
 }
+
+// FromContext adds trace and span ids of the current span, so log lines can be found by a trace
+func FromContext(ctx context.Context) *zap.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return Logger
+	}
+
+	return Logger.With(
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	)
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	oldLogger := Logger
+	Logger = zap.New(core)
+	defer func() {
+		Logger = oldLogger
+	}()
+
+	FromContext(context.Background()).Info("without span")
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02},
+		SpanID:  trace.SpanID{0x03},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	FromContext(ctx).Info("with span")
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Empty(t, entries[0].Context)
+	assert.Equal(t, map[string]interface{}{
+		"trace_id": "01020000000000000000000000000000",
+		"span_id":  "0300000000000000",
+	}, entries[1].ContextMap())
+}
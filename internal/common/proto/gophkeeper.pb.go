@@ -192,6 +192,7 @@ type UpsertRequest struct {
 	DataValue     []byte                 `protobuf:"bytes,2,opt,name=data_value,json=dataValue,proto3" json:"data_value,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DataMeta      []byte                 `protobuf:"bytes,5,opt,name=data_meta,json=dataMeta,proto3" json:"data_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -254,6 +255,13 @@ func (x *UpsertRequest) GetDeletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpsertRequest) GetDataMeta() []byte {
+	if x != nil {
+		return x.DataMeta
+	}
+	return nil
+}
+
 type GetUpdatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
@@ -304,6 +312,7 @@ type DataResponse struct {
 	DataValue     []byte                 `protobuf:"bytes,2,opt,name=data_value,json=dataValue,proto3" json:"data_value,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DataMeta      []byte                 `protobuf:"bytes,5,opt,name=data_meta,json=dataMeta,proto3" json:"data_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -366,6 +375,13 @@ func (x *DataResponse) GetDeletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DataResponse) GetDataMeta() []byte {
+	if x != nil {
+		return x.DataMeta
+	}
+	return nil
+}
+
 type DataListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*DataResponse        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -410,6 +426,118 @@ func (x *DataListResponse) GetItems() []*DataResponse {
 	return nil
 }
 
+type ServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerInfoRequest) Reset() {
+	*x = ServerInfoRequest{}
+	mi := &file_gophkeeper_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfoRequest) ProtoMessage() {}
+
+func (x *ServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*ServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_proto_rawDescGZIP(), []int{7}
+}
+
+type ServerInfoResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Commit          string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	BuildDate       string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	ProtocolVersion uint32                 `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Features        []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ServerInfoResponse) Reset() {
+	*x = ServerInfoResponse{}
+	mi := &file_gophkeeper_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfoResponse) ProtoMessage() {}
+
+func (x *ServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gophkeeper_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*ServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_gophkeeper_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
 var File_gophkeeper_proto protoreflect.FileDescriptor
 
 const file_gophkeeper_proto_rawDesc = "" +
@@ -424,7 +552,7 @@ const file_gophkeeper_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12'\n" +
 	"\x0fmaster_password\x18\x03 \x01(\tR\x0emasterPassword\"%\n" +
 	"\rTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\xbf\x01\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xdc\x01\n" +
 	"\rUpsertRequest\x12\x19\n" +
 	"\bdata_key\x18\x01 \x01(\tR\adataKey\x12\x1d\n" +
 	"\n" +
@@ -432,9 +560,10 @@ const file_gophkeeper_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
 	"\n" +
-	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"T\n" +
+	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1b\n" +
+	"\tdata_meta\x18\x05 \x01(\fR\bdataMeta\"T\n" +
 	"\x11GetUpdatesRequest\x12?\n" +
-	"\rupdated_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\"\xbe\x01\n" +
+	"\rupdated_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\"\xdb\x01\n" +
 	"\fDataResponse\x12\x19\n" +
 	"\bdata_key\x18\x01 \x01(\tR\adataKey\x12\x1d\n" +
 	"\n" +
@@ -442,16 +571,28 @@ const file_gophkeeper_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
 	"\n" +
-	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"E\n" +
+	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1b\n" +
+	"\tdata_meta\x18\x05 \x01(\fR\bdataMeta\"E\n" +
 	"\x10DataListResponse\x121\n" +
-	"\x05items\x18\x01 \x03(\v2\x1b.gophkeeper.v1.DataResponseR\x05items2\x9b\x01\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.gophkeeper.v1.DataResponseR\x05items\"\x13\n" +
+	"\x11ServerInfoRequest\"\xac\x01\n" +
+	"\x12ServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\tR\tbuildDate\x12)\n" +
+	"\x10protocol_version\x18\x04 \x01(\rR\x0fprotocolVersion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures2\x9b\x01\n" +
 	"\vAuthService\x12H\n" +
 	"\bRegister\x12\x1e.gophkeeper.v1.RegisterRequest\x1a\x1c.gophkeeper.v1.TokenResponse\x12B\n" +
 	"\x05Login\x12\x1b.gophkeeper.v1.LoginRequest\x1a\x1c.gophkeeper.v1.TokenResponse2\xa3\x01\n" +
 	"\vDataService\x12C\n" +
 	"\x06Upsert\x12\x1c.gophkeeper.v1.UpsertRequest\x1a\x1b.gophkeeper.v1.DataResponse\x12O\n" +
 	"\n" +
-	"GetUpdates\x12 .gophkeeper.v1.GetUpdatesRequest\x1a\x1f.gophkeeper.v1.DataListResponseB6Z4github.com/m1khal3v/gophkeeper/internal/common/protob\x06proto3"
+	"GetUpdates\x12 .gophkeeper.v1.GetUpdatesRequest\x1a\x1f.gophkeeper.v1.DataListResponse2`\n" +
+	"\vInfoService\x12Q\n" +
+	"\n" +
+	"ServerInfo\x12 .gophkeeper.v1.ServerInfoRequest\x1a!.gophkeeper.v1.ServerInfoResponseB6Z4github.com/m1khal3v/gophkeeper/internal/common/protob\x06proto3"
 
 var (
 	file_gophkeeper_proto_rawDescOnce sync.Once
@@ -465,7 +606,7 @@ func file_gophkeeper_proto_rawDescGZIP() []byte {
 	return file_gophkeeper_proto_rawDescData
 }
 
-var file_gophkeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gophkeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_gophkeeper_proto_goTypes = []any{
 	(*RegisterRequest)(nil),       // 0: gophkeeper.v1.RegisterRequest
 	(*LoginRequest)(nil),          // 1: gophkeeper.v1.LoginRequest
@@ -474,25 +615,29 @@ var file_gophkeeper_proto_goTypes = []any{
 	(*GetUpdatesRequest)(nil),     // 4: gophkeeper.v1.GetUpdatesRequest
 	(*DataResponse)(nil),          // 5: gophkeeper.v1.DataResponse
 	(*DataListResponse)(nil),      // 6: gophkeeper.v1.DataListResponse
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*ServerInfoRequest)(nil),     // 7: gophkeeper.v1.ServerInfoRequest
+	(*ServerInfoResponse)(nil),    // 8: gophkeeper.v1.ServerInfoResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
 }
 var file_gophkeeper_proto_depIdxs = []int32{
-	7,  // 0: gophkeeper.v1.UpsertRequest.updated_at:type_name -> google.protobuf.Timestamp
-	7,  // 1: gophkeeper.v1.UpsertRequest.deleted_at:type_name -> google.protobuf.Timestamp
-	7,  // 2: gophkeeper.v1.GetUpdatesRequest.updated_after:type_name -> google.protobuf.Timestamp
-	7,  // 3: gophkeeper.v1.DataResponse.updated_at:type_name -> google.protobuf.Timestamp
-	7,  // 4: gophkeeper.v1.DataResponse.deleted_at:type_name -> google.protobuf.Timestamp
+	9,  // 0: gophkeeper.v1.UpsertRequest.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: gophkeeper.v1.UpsertRequest.deleted_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: gophkeeper.v1.GetUpdatesRequest.updated_after:type_name -> google.protobuf.Timestamp
+	9,  // 3: gophkeeper.v1.DataResponse.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: gophkeeper.v1.DataResponse.deleted_at:type_name -> google.protobuf.Timestamp
 	5,  // 5: gophkeeper.v1.DataListResponse.items:type_name -> gophkeeper.v1.DataResponse
 	0,  // 6: gophkeeper.v1.AuthService.Register:input_type -> gophkeeper.v1.RegisterRequest
 	1,  // 7: gophkeeper.v1.AuthService.Login:input_type -> gophkeeper.v1.LoginRequest
 	3,  // 8: gophkeeper.v1.DataService.Upsert:input_type -> gophkeeper.v1.UpsertRequest
 	4,  // 9: gophkeeper.v1.DataService.GetUpdates:input_type -> gophkeeper.v1.GetUpdatesRequest
-	2,  // 10: gophkeeper.v1.AuthService.Register:output_type -> gophkeeper.v1.TokenResponse
-	2,  // 11: gophkeeper.v1.AuthService.Login:output_type -> gophkeeper.v1.TokenResponse
-	5,  // 12: gophkeeper.v1.DataService.Upsert:output_type -> gophkeeper.v1.DataResponse
-	6,  // 13: gophkeeper.v1.DataService.GetUpdates:output_type -> gophkeeper.v1.DataListResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
+	7,  // 10: gophkeeper.v1.InfoService.ServerInfo:input_type -> gophkeeper.v1.ServerInfoRequest
+	2,  // 11: gophkeeper.v1.AuthService.Register:output_type -> gophkeeper.v1.TokenResponse
+	2,  // 12: gophkeeper.v1.AuthService.Login:output_type -> gophkeeper.v1.TokenResponse
+	5,  // 13: gophkeeper.v1.DataService.Upsert:output_type -> gophkeeper.v1.DataResponse
+	6,  // 14: gophkeeper.v1.DataService.GetUpdates:output_type -> gophkeeper.v1.DataListResponse
+	8,  // 15: gophkeeper.v1.InfoService.ServerInfo:output_type -> gophkeeper.v1.ServerInfoResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
 	6,  // [6:6] is the sub-list for extension type_name
 	6,  // [6:6] is the sub-list for extension extendee
 	0,  // [0:6] is the sub-list for field type_name
@@ -509,9 +654,9 @@ func file_gophkeeper_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gophkeeper_proto_rawDesc), len(file_gophkeeper_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_gophkeeper_proto_goTypes,
 		DependencyIndexes: file_gophkeeper_proto_depIdxs,
@@ -0,0 +1,26 @@
+package protocol
+
+import "slices"
+
+// Version is bumped on incompatible changes of the gRPC API
+const Version uint32 = 1
+
+const (
+	FeatureDataMeta   = "data_meta"
+	FeatureSoftDelete = "soft_delete"
+)
+
+// Features are implemented by this build, the server advertises them and the client relies on them
+var Features = []string{FeatureDataMeta, FeatureSoftDelete}
+
+// Missing returns features of this build which are not in features
+func Missing(features []string) []string {
+	var missing []string
+	for _, feature := range Features {
+		if !slices.Contains(features, feature) {
+			missing = append(missing, feature)
+		}
+	}
+
+	return missing
+}
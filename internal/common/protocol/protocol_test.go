@@ -0,0 +1,14 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissing(t *testing.T) {
+	assert.Empty(t, Missing(Features))
+	assert.Empty(t, Missing(append([]string{"future"}, Features...)))
+	assert.Equal(t, []string{FeatureSoftDelete}, Missing([]string{FeatureDataMeta}))
+	assert.Equal(t, Features, Missing(nil))
+}
@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	// ExporterFile is followed by the path, e.g. file:/tmp/traces.json
+	ExporterFile = "file:"
+)
+
+var ErrUnknownExporter = errors.New("unknown traces exporter")
+
+// ValidateExporter checks the exporter without creating it
+func ValidateExporter(exporter string) error {
+	switch {
+	case exporter == ExporterNone, exporter == ExporterOTLP, exporter == ExporterStdout:
+		return nil
+	case strings.HasPrefix(exporter, ExporterFile) && len(exporter) > len(ExporterFile):
+		return nil
+	default:
+		return fmt.Errorf("%w %q, expected otlp, stdout or file:<path>", ErrUnknownExporter, exporter)
+	}
+}
+
+// Init installs the global tracer provider and the W3C propagator.
+// The propagator is installed even without an exporter, so trace context still passes through.
+// OTLP is configured by the standard OTEL_EXPORTER_OTLP_* variables.
+func Init(ctx context.Context, service, version, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	spanExporter, closer, err := newExporter(ctx, exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("can`t create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, exporter string) (sdktrace.SpanExporter, io.Closer, error) {
+	if err := ValidateExporter(exporter); err != nil {
+		return nil, nil, err
+	}
+
+	switch exporter {
+	case ExporterOTLP:
+		spanExporter, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can`t create OTLP exporter: %w", err)
+		}
+		return spanExporter, nil, nil
+	case ExporterStdout:
+		spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return spanExporter, nil, err
+	}
+
+	path := strings.TrimPrefix(exporter, ExporterFile)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can`t open traces file: %w", err)
+	}
+	spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return spanExporter, file, nil
+}
+
+// Fail marks the span as failed and returns err
+func Fail(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestValidateExporter(t *testing.T) {
+	for _, exporter := range []string{"", "otlp", "stdout", "file:/tmp/traces.json"} {
+		assert.NoError(t, ValidateExporter(exporter), exporter)
+	}
+	for _, exporter := range []string{"jaeger", "file:", "OTLP"} {
+		assert.ErrorIs(t, ValidateExporter(exporter), ErrUnknownExporter, exporter)
+	}
+}
+
+func TestInit_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	shutdown, err := Init(context.Background(), "gophkeeper-test", "v1.0.0", ExporterFile+path)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"Name":"test-span"`)
+	assert.Contains(t, string(raw), `"Value":"gophkeeper-test"`)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestInit_None(t *testing.T) {
+	shutdown, err := Init(context.Background(), "gophkeeper-test", "v1.0.0", ExporterNone)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+
+	_, err = Init(context.Background(), "gophkeeper-test", "v1.0.0", "jaeger")
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+func TestFail(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(context.Background(), "ok")
+	assert.NoError(t, Fail(span, nil))
+	span.End()
+
+	_, span = provider.Tracer("test").Start(context.Background(), "failed")
+	assert.EqualError(t, Fail(span, errors.New("db error")), "db error")
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "db error", spans[1].Status().Description)
+}
@@ -10,45 +10,69 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
 	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	commonmetrics "github.com/m1khal3v/gophkeeper/internal/common/metrics"
 	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
+	"github.com/m1khal3v/gophkeeper/internal/server/certs"
 	"github.com/m1khal3v/gophkeeper/internal/server/config"
 	grpcs "github.com/m1khal3v/gophkeeper/internal/server/grpc"
+	servicehealth "github.com/m1khal3v/gophkeeper/internal/server/health"
 	"github.com/m1khal3v/gophkeeper/internal/server/jwt"
 	"github.com/m1khal3v/gophkeeper/internal/server/manager"
+	"github.com/m1khal3v/gophkeeper/internal/server/metrics"
 	"github.com/m1khal3v/gophkeeper/internal/server/repository"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+const (
+	healthCheckTimeout     = 2 * time.Second
+	tracingShutdownTimeout = 5 * time.Second
+	migrateTimeout         = 5 * time.Minute
+)
+
+// ErrConfig wraps config loading and validation errors, they are reported without a stack trace
+var ErrConfig = errors.New("invalid config")
+
 type App struct {
+	build    buildlog.Info
 	cfg      *config.Config
 	db       *sql.DB
 	services *services
 }
 
-func New() (*App, error) {
-	cfg, err := config.Load()
+// New loads the config from args without the program name, the environment and the config file
+func New(build buildlog.Info, args []string) (*App, error) {
+	cfg, err := config.Load(args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrConfig, err)
 	}
 
-	var level string
-	if cfg.Debug {
-		level = "debug"
-	} else {
-		level = "info"
-	}
-	logger.Init("server", level)
+	initLogger(cfg)
 
 	db, err := initDB(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init db: %w", err)
 	}
 
+	if err := migrate(db, cfg.Migrate); err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	return &App{
+		build:    build,
 		cfg:      cfg,
 		db:       db,
 		services: initServices(db, cfg),
@@ -59,14 +83,74 @@ func (a *App) Run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	grpcServer := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			grpcs.NewAuthInterceptor(a.services.userManager).Unary(),
-		),
+	shutdownTracing, err := tracing.Init(ctx, "gophkeeper-server", a.build.Version, a.cfg.TracesExporter)
+	if err != nil {
+		return fmt.Errorf("failed to init tracing: %w", err)
+	}
+	defer func() {
+		// ctx is already canceled here, the remaining spans are flushed with a fresh one
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Logger.Error("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	// metrics go first to count rejected requests too
+	interceptors := []grpc.UnaryServerInterceptor{}
+	if a.cfg.MetricsListen != "" {
+		serverMetrics := metrics.New(a.db, a.services.dataManager)
+		if err := a.serveMetrics(ctx, serverMetrics); err != nil {
+			return err
+		}
+		interceptors = append(interceptors, serverMetrics.UnaryInterceptor())
+	}
+	interceptors = append(interceptors,
+		grpcs.NewDeadlineInterceptor(a.cfg.RequestTimeout).Unary(),
+		grpcs.NewAuthInterceptor(a.services.userManager).Unary(),
 	)
+
+	options := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithFilter(filters.Not(filters.HealthCheck())),
+		)),
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ConnectionTimeout(a.cfg.ConnectionTimeout),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: a.cfg.MaxConnectionIdle,
+			Time:              a.cfg.KeepaliveTime,
+			Timeout:           a.cfg.KeepaliveTimeout,
+		}),
+	}
+
+	if a.cfg.TLSEnabled() {
+		tlsOption, err := a.tlsOption(ctx)
+		if err != nil {
+			return err
+		}
+		options = append(options, tlsOption)
+	} else {
+		logger.Logger.Warn("TLS is disabled, tokens and passwords are sent in cleartext")
+	}
+
+	grpcServer := grpc.NewServer(options...)
 	grpcInternal := grpcs.NewServer(a.services.userManager, a.services.dataManager)
 	proto.RegisterAuthServiceServer(grpcServer, grpcInternal)
 	proto.RegisterDataServiceServer(grpcServer, grpcInternal)
+	proto.RegisterInfoServiceServer(grpcServer, grpcs.NewInfoServer(a.build))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	checker := servicehealth.NewChecker(a.db, healthServer, healthCheckTimeout,
+		proto.AuthService_ServiceDesc.ServiceName,
+		proto.DataService_ServiceDesc.ServiceName,
+	)
+	go checker.Run(ctx, a.cfg.HealthCheckInterval)
+
+	if a.cfg.Reflection {
+		reflection.Register(grpcServer)
+		logger.Logger.Info("gRPC reflection enabled")
+	}
 
 	listener, err := net.Listen("tcp", a.cfg.Listen)
 	if err != nil {
@@ -87,6 +171,8 @@ func (a *App) Run() error {
 
 	logger.Logger.Info("Shutting down server...")
 
+	// let load balancers drain before the connections are closed
+	healthServer.Shutdown()
 	grpcServer.GracefulStop()
 	if err := a.db.Close(); err != nil {
 		logger.Logger.Error("Failed to close db connection", zap.Error(err))
@@ -97,15 +183,65 @@ func (a *App) Run() error {
 	return nil
 }
 
+func (a *App) serveMetrics(ctx context.Context, serverMetrics *metrics.Metrics) error {
+	listener, err := commonmetrics.Listen(a.cfg.MetricsListen)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics listener: %w", err)
+	}
+
+	go func() {
+		logger.Logger.Info("Starting metrics server", zap.String("addr", a.cfg.MetricsListen))
+		if err := commonmetrics.Serve(ctx, listener, serverMetrics.Registry()); err != nil {
+			logger.Logger.Error("Metrics server failed", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (a *App) tlsOption(ctx context.Context) (grpc.ServerOption, error) {
+	reloader, err := certs.NewReloader(a.cfg.TLSCertFile, a.cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	tlsConfig, err := certs.ServerConfig(reloader, a.cfg.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TLS config: %w", err)
+	}
+
+	if a.cfg.TLSReloadInterval > 0 {
+		go reloader.Watch(ctx, a.cfg.TLSReloadInterval)
+	}
+	logger.Logger.Info("TLS enabled", zap.Bool("client_auth", a.cfg.TLSClientCAFile != ""))
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
+
+func initLogger(cfg *config.Config) {
+	var level string
+	if cfg.Debug {
+		level = "debug"
+	} else {
+		level = "info"
+	}
+	logger.Init("server", level)
+
+	for _, warning := range cfg.Warnings {
+		logger.Logger.Warn(warning)
+	}
+}
+
 func initDB(cfg *config.Config) (*sql.DB, error) {
 	db, err := sql.Open("mysql", cfg.DatabaseDSN+"?parseTime=true")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
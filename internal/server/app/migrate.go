@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"github.com/m1khal3v/gophkeeper/internal/server/config"
+	"github.com/m1khal3v/gophkeeper/internal/server/migrations"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+)
+
+var ErrUnknownMigrateCommand = errors.New("unknown migrate command, expected up, down or status")
+
+// Migrate runs `server migrate <up|down|status> [flags]`, args are the flags after the command
+func Migrate(command string, args []string) error {
+	if command != MigrateUp && command != MigrateDown && command != MigrateStatus {
+		return ErrUnknownMigrateCommand
+	}
+
+	// only the database settings are required, APP_SECRET and the rest are used by the server
+	cfg, err := config.LoadDB(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConfig, err)
+	}
+	initLogger(cfg)
+
+	db, err := initDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init db: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case MigrateUp:
+		results, err := migrator.Up(ctx)
+		logMigrations("Migration applied", results...)
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	case MigrateDown:
+		result, err := migrator.Down(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration: %w", err)
+		}
+		logMigrations("Migration rolled back", result)
+	case MigrateStatus:
+		return printMigrationStatus(ctx, os.Stdout, migrator)
+	}
+
+	return nil
+}
+
+func logMigrations(msg string, results ...*goose.MigrationResult) {
+	for _, result := range results {
+		logger.Logger.Info(msg, zap.String("migration", result.Source.Path), zap.Duration("duration", result.Duration))
+	}
+}
+
+func printMigrationStatus(ctx context.Context, w io.Writer, migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migrations status: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MIGRATION\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if !status.AppliedAt.IsZero() {
+			appliedAt = status.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", status.Source.Path, status.State, appliedAt)
+	}
+
+	return tw.Flush()
+}
+
+// migrate applies migrations if enabled and refuses to start with an outdated schema
+func migrate(db *sql.DB, apply bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	if apply {
+		results, err := migrator.Up(ctx)
+		logMigrations("Migration applied", results...)
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+
+	if err := migrator.Check(ctx); err != nil {
+		return fmt.Errorf("%w, run `server migrate up` or start with -migrate", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, name string, isCA bool, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}),
+	}
+}
+
+func writeKeyPair(t *testing.T, dir string, cert *testCert) (string, string) {
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, cert.certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, cert.keyPEM, 0600))
+
+	return certFile, keyFile
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	first := newTestCert(t, "first.local", false, nil)
+	certFile, keyFile := writeKeyPair(t, dir, first)
+
+	reloader, err := NewReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.cert.Raw, cert.Certificate[0])
+
+	reloaded, err := reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	second := newTestCert(t, "second.local", false, nil)
+	writeKeyPair(t, dir, second)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	reloaded, err = reloader.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, cert.Certificate[0])
+
+	// a broken pair keeps the previous certificate
+	require.NoError(t, os.WriteFile(keyFile, first.keyPEM, 0600))
+	require.NoError(t, os.Chtimes(keyFile, future.Add(time.Minute), future.Add(time.Minute)))
+	_, err = reloader.Reload()
+	assert.Error(t, err)
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, cert.Certificate[0])
+}
+
+func TestReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, newTestCert(t, "first.local", false, nil))
+	reloader, err := NewReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx, 10*time.Millisecond)
+
+	second := newTestCert(t, "second.local", false, nil)
+	writeKeyPair(t, dir, second)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	assert.Eventually(t, func() bool {
+		cert, _ := reloader.GetCertificate(nil)
+		return string(cert.Certificate[0]) == string(second.cert.Raw)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewReloader_Missing(t *testing.T) {
+	_, err := NewReloader("missing.crt", "missing.key")
+	assert.Error(t, err)
+}
+
+func TestServerConfig_ClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", true, nil)
+	server := newTestCert(t, "server.local", false, ca)
+	certFile, keyFile := writeKeyPair(t, dir, server)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0600))
+
+	reloader, err := NewReloader(certFile, keyFile)
+	require.NoError(t, err)
+	config, err := ServerConfig(reloader, caFile)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	handshake := func(clientCert *testCert) error {
+		listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		serverErr := make(chan error, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				serverErr <- err
+				return
+			}
+			defer conn.Close()
+			serverErr <- conn.(*tls.Conn).Handshake()
+		}()
+
+		clientConfig := &tls.Config{RootCAs: roots, ServerName: "server.local"}
+		if clientCert != nil {
+			clientConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{clientCert.cert.Raw}, PrivateKey: clientCert.key}}
+		}
+		conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+		if err == nil {
+			defer conn.Close()
+		}
+
+		return <-serverErr
+	}
+
+	assert.NoError(t, handshake(newTestCert(t, "client", false, ca)))
+	assert.Error(t, handshake(nil))
+	assert.Error(t, handshake(newTestCert(t, "client", false, nil)))
+
+	_, err = ServerConfig(reloader, filepath.Join(dir, "tls.key"))
+	assert.EqualError(t, err, "CA bundle contains no certificates")
+}
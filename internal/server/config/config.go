@@ -1,70 +1,328 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
+	"gopkg.in/yaml.v3"
 )
 
+// Config is loaded from defaults, the YAML file, env and flags, each layer overrides the previous one.
+// Secrets have no flags, they would leak to the process list.
 type Config struct {
-	Env          string        // APP_ENV
-	DatabaseDSN  string        // DATABASE_DSN
-	AppSecret    string        // APP_SECRET
-	Listen       string        // LISTEN
-	ReadTimeout  time.Duration // READ_TIMEOUT
-	WriteTimeout time.Duration // WRITE_TIMEOUT
-	Debug        bool          // DEBUG
+	Env         string `yaml:"env"`          // APP_ENV
+	DatabaseDSN string `yaml:"database_dsn"` // DATABASE_DSN
+	AppSecret   string `yaml:"app_secret"`   // APP_SECRET
+	Listen      string `yaml:"listen"`       // LISTEN
+	Debug       bool   `yaml:"debug"`        // DEBUG
+	Migrate     bool   `yaml:"migrate"`      // MIGRATE, apply migrations on start, otherwise only check them
+
+	ConnectionTimeout time.Duration `yaml:"connection_timeout"`  // CONNECTION_TIMEOUT, TLS and HTTP/2 handshake
+	RequestTimeout    time.Duration `yaml:"request_timeout"`     // REQUEST_TIMEOUT, upper bound of the RPC deadline
+	KeepaliveTime     time.Duration `yaml:"keepalive_time"`      // KEEPALIVE_TIME, ping idle connections after it
+	KeepaliveTimeout  time.Duration `yaml:"keepalive_timeout"`   // KEEPALIVE_TIMEOUT, close the connection if a ping isn`t answered
+	MaxConnectionIdle time.Duration `yaml:"max_connection_idle"` // MAX_CONNECTION_IDLE, 0 keeps idle connections forever
+
+	DBMaxOpenConns    int           `yaml:"db_max_open_conns"`     // DB_MAX_OPEN_CONNS
+	DBMaxIdleConns    int           `yaml:"db_max_idle_conns"`     // DB_MAX_IDLE_CONNS
+	DBConnMaxLifetime time.Duration `yaml:"db_conn_max_lifetime"`  // DB_CONN_MAX_LIFETIME, 0 is unlimited
+	DBConnMaxIdleTime time.Duration `yaml:"db_conn_max_idle_time"` // DB_CONN_MAX_IDLE_TIME, 0 is unlimited
+
+	TLSCertFile       string        `yaml:"tls_cert_file"`       // TLS_CERT_FILE
+	TLSKeyFile        string        `yaml:"tls_key_file"`        // TLS_KEY_FILE
+	TLSClientCAFile   string        `yaml:"tls_client_ca_file"`  // TLS_CLIENT_CA_FILE, requires client certificates signed by this CA
+	TLSReloadInterval time.Duration `yaml:"tls_reload_interval"` // TLS_RELOAD_INTERVAL
+
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"` // HEALTH_CHECK_INTERVAL
+	Reflection          bool          `yaml:"reflection"`            // GRPC_REFLECTION
+	MetricsListen       string        `yaml:"metrics_listen"`        // METRICS_LISTEN, empty disables /metrics
+	TracesExporter      string        `yaml:"traces_exporter"`       // TRACES_EXPORTER: otlp, stdout or file:<path>, empty disables tracing
+
+	// Warnings are logged once the logger is initialized, e.g. about deprecated variables
+	Warnings []string `yaml:"-"`
 }
 
 const (
-	defaultListen       = ":50051"
-	defaultReadTimeout  = 5 * time.Second
-	defaultWriteTimeout = 10 * time.Second
+	ConfigFileEnv = "CONFIG_FILE"
+
+	defaultListen            = ":50051"
+	defaultConnectionTimeout = 5 * time.Second
+	defaultRequestTimeout    = 10 * time.Second
+	defaultKeepaliveTime     = 5 * time.Minute
+	defaultKeepaliveTimeout  = 20 * time.Second
+	defaultDBMaxConns        = 25
+	defaultDBConnMaxLifetime = 5 * time.Minute
+	defaultTLSReload         = 30 * time.Second
+	defaultHealthCheck       = 10 * time.Second
 )
 
-func Load() (*Config, error) {
-	cfg := &Config{
-		Env:          getEnv("APP_ENV", "dev"),
-		DatabaseDSN:  os.Getenv("DATABASE_DSN"),
-		AppSecret:    os.Getenv("APP_SECRET"),
-		Listen:       getEnv("LISTEN", defaultListen),
-		ReadTimeout:  parseDuration("READ_TIMEOUT", defaultReadTimeout),
-		WriteTimeout: parseDuration("WRITE_TIMEOUT", defaultWriteTimeout),
-		Debug:        getBoolEnv("DEBUG"),
+func defaults() *Config {
+	return &Config{
+		Env:                 "dev",
+		Listen:              defaultListen,
+		ConnectionTimeout:   defaultConnectionTimeout,
+		RequestTimeout:      defaultRequestTimeout,
+		KeepaliveTime:       defaultKeepaliveTime,
+		KeepaliveTimeout:    defaultKeepaliveTimeout,
+		DBMaxOpenConns:      defaultDBMaxConns,
+		DBMaxIdleConns:      defaultDBMaxConns,
+		DBConnMaxLifetime:   defaultDBConnMaxLifetime,
+		TLSReloadInterval:   defaultTLSReload,
+		HealthCheckInterval: defaultHealthCheck,
 	}
+}
 
-	if cfg.DatabaseDSN == "" {
-		return nil, fmt.Errorf("DATABASE_DSN is required")
+// Load reads the config file from -config or $CONFIG_FILE, then env, then the other flags in args
+func Load(args []string) (*Config, error) {
+	return load(args, (*Config).validate)
+}
+
+// LoadDB loads the config like Load but validates only the database settings, for `server migrate`
+func LoadDB(args []string) (*Config, error) {
+	return load(args, (*Config).validateDB)
+}
+
+func load(args []string, validate func(*Config) error) (*Config, error) {
+	cfg := defaults()
+	b := newBinder(cfg)
+
+	var configFile string
+	b.flags.StringVar(&configFile, "config", os.Getenv(ConfigFileEnv), "YAML config file, $"+ConfigFileEnv+" by default")
+
+	// flags are parsed first to find the config file, and applied again after the file and env
+	if err := b.flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if b.flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", b.flags.Args())
+	}
+	set := map[string]string{}
+	b.flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+
+	if configFile != "" {
+		if err := loadFile(configFile, cfg); err != nil {
+			return nil, err
+		}
 	}
 
-	if cfg.AppSecret == "" {
-		return nil, fmt.Errorf("APP_SECRET is required")
+	envErr := b.loadEnv(cfg)
+
+	for name, value := range set {
+		if err := b.flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("-%s: %w", name, err)
+		}
+	}
+
+	// env errors are reported together with the validation ones
+	if err := errors.Join(envErr, validate(cfg)); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-	return value
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
 }
 
-func getBoolEnv(key string) bool {
-	return os.Getenv(key) == "true" || os.Getenv(key) == "1"
+// validate reports all problems at once, so they can be fixed in one go
+func (c *Config) validate() error {
+	errs := []error{c.validateDB()}
+
+	if c.AppSecret == "" {
+		errs = append(errs, errors.New("APP_SECRET is required"))
+	}
+
+	if c.ConnectionTimeout <= 0 {
+		errs = append(errs, errors.New("CONNECTION_TIMEOUT must be positive"))
+	}
+
+	if c.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("REQUEST_TIMEOUT must be positive"))
+	}
+
+	if c.KeepaliveTime <= 0 || c.KeepaliveTimeout <= 0 {
+		errs = append(errs, errors.New("KEEPALIVE_TIME and KEEPALIVE_TIMEOUT must be positive"))
+	}
+
+	if c.MaxConnectionIdle < 0 {
+		errs = append(errs, errors.New("MAX_CONNECTION_IDLE can`t be negative"))
+	}
+
+	if c.HealthCheckInterval <= 0 {
+		errs = append(errs, errors.New("HEALTH_CHECK_INTERVAL must be positive"))
+	}
+
+	if err := tracing.ValidateExporter(c.TracesExporter); err != nil {
+		errs = append(errs, fmt.Errorf("TRACES_EXPORTER: %w", err))
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		errs = append(errs, errors.New("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE"))
+	}
+
+	return errors.Join(errs...)
 }
 
-func parseDuration(key string, defaultValue time.Duration) time.Duration {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// validateDB checks only the settings needed to connect to the database
+func (c *Config) validateDB() error {
+	var errs []error
+
+	if c.DatabaseDSN == "" {
+		errs = append(errs, errors.New("DATABASE_DSN is required"))
 	}
 
-	duration, err := time.ParseDuration(value)
+	if c.DBConnMaxLifetime < 0 || c.DBConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME can`t be negative"))
+	}
+
+	if c.DBMaxOpenConns <= 0 {
+		errs = append(errs, errors.New("DB_MAX_OPEN_CONNS must be positive"))
+	}
+
+	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS (%d)", c.DBMaxOpenConns))
+	}
+
+	return errors.Join(errs...)
+}
+
+func loadFile(path string, cfg *Config) error {
+	file, err := os.Open(path)
 	if err != nil {
-		return defaultValue
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// binder defines every option as a flag, secrets only in the env set
+type binder struct {
+	flags *flag.FlagSet
+	all   *flag.FlagSet
+	envs  map[string]string
+}
+
+func newBinder(cfg *Config) *binder {
+	b := &binder{
+		flags: flag.NewFlagSet("server", flag.ContinueOnError),
+		all:   flag.NewFlagSet("env", flag.ContinueOnError),
+		envs:  map[string]string{},
+	}
+
+	b.secret(&cfg.DatabaseDSN, "DATABASE_DSN")
+	b.secret(&cfg.AppSecret, "APP_SECRET")
+
+	b.string(&cfg.Env, "env", "APP_ENV", "environment name")
+	b.string(&cfg.Listen, "listen", "LISTEN", "gRPC listen address")
+	b.bool(&cfg.Debug, "debug", "DEBUG", "debug logging")
+	b.bool(&cfg.Migrate, "migrate", "MIGRATE", "apply database migrations on start, otherwise refuse to start with an outdated schema")
+
+	b.duration(&cfg.ConnectionTimeout, "connection-timeout", "CONNECTION_TIMEOUT", "timeout of the TLS and HTTP/2 handshake")
+	b.duration(&cfg.RequestTimeout, "request-timeout", "REQUEST_TIMEOUT", "maximum RPC deadline, shorter client deadlines are kept")
+	b.duration(&cfg.KeepaliveTime, "keepalive-time", "KEEPALIVE_TIME", "ping a connection after this idle time")
+	b.duration(&cfg.KeepaliveTimeout, "keepalive-timeout", "KEEPALIVE_TIMEOUT", "close a connection if the ping isn`t answered in this time")
+	b.duration(&cfg.MaxConnectionIdle, "max-connection-idle", "MAX_CONNECTION_IDLE", "close connections without RPCs after this time, 0 disables")
+
+	b.int(&cfg.DBMaxOpenConns, "db-max-open-conns", "DB_MAX_OPEN_CONNS", "maximum open database connections")
+	b.int(&cfg.DBMaxIdleConns, "db-max-idle-conns", "DB_MAX_IDLE_CONNS", "maximum idle database connections")
+	b.duration(&cfg.DBConnMaxLifetime, "db-conn-max-lifetime", "DB_CONN_MAX_LIFETIME", "maximum lifetime of a database connection, 0 is unlimited")
+	b.duration(&cfg.DBConnMaxIdleTime, "db-conn-max-idle-time", "DB_CONN_MAX_IDLE_TIME", "maximum idle time of a database connection, 0 is unlimited")
+
+	b.string(&cfg.TLSCertFile, "tls-cert-file", "TLS_CERT_FILE", "TLS certificate")
+	b.string(&cfg.TLSKeyFile, "tls-key-file", "TLS_KEY_FILE", "TLS certificate key")
+	b.string(&cfg.TLSClientCAFile, "tls-client-ca-file", "TLS_CLIENT_CA_FILE", "CA of client certificates, enables mutual TLS")
+	b.duration(&cfg.TLSReloadInterval, "tls-reload-interval", "TLS_RELOAD_INTERVAL", "check the certificate files for changes, 0 disables")
+
+	b.duration(&cfg.HealthCheckInterval, "health-check-interval", "HEALTH_CHECK_INTERVAL", "database health check interval")
+	b.bool(&cfg.Reflection, "reflection", "GRPC_REFLECTION", "enable gRPC server reflection")
+	b.string(&cfg.MetricsListen, "metrics-listen", "METRICS_LISTEN", "Prometheus metrics listen address, empty disables metrics")
+	b.string(&cfg.TracesExporter, "traces-exporter", "TRACES_EXPORTER", "traces exporter: otlp, stdout or file:<path>, empty disables tracing")
+
+	return b
+}
+
+func (b *binder) secret(p *string, env string) {
+	b.all.StringVar(p, env, *p, "")
+	b.envs[env] = env
+}
+
+func (b *binder) string(p *string, name, env, usage string) {
+	b.flags.StringVar(p, name, *p, usage+", $"+env)
+	b.all.StringVar(p, name, *p, usage)
+	b.envs[name] = env
+}
+
+func (b *binder) bool(p *bool, name, env, usage string) {
+	b.flags.BoolVar(p, name, *p, usage+", $"+env)
+	b.all.BoolVar(p, name, *p, usage)
+	b.envs[name] = env
+}
+
+func (b *binder) int(p *int, name, env, usage string) {
+	b.flags.IntVar(p, name, *p, usage+", $"+env)
+	b.all.IntVar(p, name, *p, usage)
+	b.envs[name] = env
+}
+
+func (b *binder) duration(p *time.Duration, name, env, usage string) {
+	b.flags.DurationVar(p, name, *p, usage+", $"+env)
+	b.all.DurationVar(p, name, *p, usage)
+	b.envs[name] = env
+}
+
+// deprecatedEnvs were accepted by older versions, they set the option that replaced them
+// unless its own variable is set
+var deprecatedEnvs = []struct{ env, name string }{
+	{"READ_TIMEOUT", "connection-timeout"},
+	{"WRITE_TIMEOUT", "request-timeout"},
+}
+
+// loadEnv ignores empty variables, like unset ones
+func (b *binder) loadEnv(cfg *Config) error {
+	var errs []error
+	for _, deprecated := range deprecatedEnvs {
+		value, replacement := os.Getenv(deprecated.env), b.envs[deprecated.name]
+		if value == "" {
+			continue
+		}
+		cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("%s is deprecated, use %s", deprecated.env, replacement))
+		if os.Getenv(replacement) != "" {
+			continue
+		}
+		if err := b.all.Set(deprecated.name, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q", deprecated.env, value))
+		}
 	}
-	return duration
+
+	b.all.VisitAll(func(f *flag.Flag) {
+		env := b.envs[f.Name]
+		value := os.Getenv(env)
+		if value == "" {
+			return
+		}
+		if err := f.Value.Set(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q", env, value))
+		}
+	})
+
+	return errors.Join(errs...)
 }
@@ -4,14 +4,24 @@ import (
 	"context"
 	"strings"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
 	"github.com/m1khal3v/gophkeeper/internal/server/jwt"
 	"github.com/m1khal3v/gophkeeper/internal/server/manager"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// publicMethods are called without a token, health and reflection streams bypass unary interceptors anyway
+var publicMethods = map[string]struct{}{
+	proto.AuthService_Register_FullMethodName:   {},
+	proto.AuthService_Login_FullMethodName:      {},
+	proto.InfoService_ServerInfo_FullMethodName: {},
+	healthpb.Health_Check_FullMethodName:        {},
+}
+
 type AuthInterceptor struct {
 	userManager UserManagerInterface
 }
@@ -29,7 +39,7 @@ func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		if info.FullMethod == "/gophkeeper.v1.AuthService/Register" || info.FullMethod == "/gophkeeper.v1.AuthService/Login" {
+		if _, ok := publicMethods[info.FullMethod]; ok {
 			return handler(ctx, req)
 		}
 
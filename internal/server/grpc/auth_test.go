@@ -22,11 +22,11 @@ func (m *mockAuthUserManager) DecodeToken(token string) (*jwt.Claims, error) {
 }
 
 // Реализация интерфейса UserManagerInterface
-func (m *mockAuthUserManager) Register(login, password, masterPassword string) (string, error) {
+func (m *mockAuthUserManager) Register(ctx context.Context, login, password, masterPassword string) (string, error) {
 	return "", errors.New("not implemented")
 }
 
-func (m *mockAuthUserManager) Login(login, password, masterPassword string) (string, error) {
+func (m *mockAuthUserManager) Login(ctx context.Context, login, password, masterPassword string) (string, error) {
 	return "", errors.New("not implemented")
 }
 
@@ -161,6 +161,29 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 	}
 }
 
+func TestAuthInterceptor_PublicMethods(t *testing.T) {
+	interceptor := (&AuthInterceptor{userManager: &mockAuthUserManager{}}).Unary()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	for _, method := range []string{
+		"/gophkeeper.v1.AuthService/Login",
+		"/gophkeeper.v1.InfoService/ServerInfo",
+		"/grpc.health.v1.Health/Check",
+	} {
+		resp, err := interceptor(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		if err != nil || resp != "ok" {
+			t.Errorf("Unary(%s) = %v, %v, want ok without token", method, resp, err)
+		}
+	}
+
+	_, err := interceptor(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.v1.DataService/GetUpdates"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Unary(GetUpdates) error = %v, want Unauthenticated", err)
+	}
+}
+
 func TestGetClaimsFromContext(t *testing.T) {
 	tests := []struct {
 		name    string
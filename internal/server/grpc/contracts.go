@@ -9,8 +9,8 @@ import (
 )
 
 type UserManagerInterface interface {
-	Register(login, password, masterPassword string) (string, error)
-	Login(login, password, masterPassword string) (string, error)
+	Register(ctx context.Context, login, password, masterPassword string) (string, error)
+	Login(ctx context.Context, login, password, masterPassword string) (string, error)
 	DecodeToken(token string) (*jwt.Claims, error)
 }
 
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DeadlineInterceptor limits the RPC deadline, shorter deadlines of the client are kept
+type DeadlineInterceptor struct {
+	timeout time.Duration
+}
+
+func NewDeadlineInterceptor(timeout time.Duration) *DeadlineInterceptor {
+	return &DeadlineInterceptor{
+		timeout: timeout,
+	}
+}
+
+func (i *DeadlineInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= i.timeout {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
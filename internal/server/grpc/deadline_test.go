@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestDeadlineInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientTimeout time.Duration
+		serverTimeout time.Duration
+		minRemaining  time.Duration
+		maxRemaining  time.Duration
+	}{
+		{
+			name:          "no client deadline",
+			serverTimeout: time.Second,
+			minRemaining:  900 * time.Millisecond,
+			maxRemaining:  time.Second,
+		},
+		{
+			name:          "longer client deadline is limited",
+			clientTimeout: time.Hour,
+			serverTimeout: time.Second,
+			minRemaining:  900 * time.Millisecond,
+			maxRemaining:  time.Second,
+		},
+		{
+			name:          "shorter client deadline is kept",
+			clientTimeout: 100 * time.Millisecond,
+			serverTimeout: time.Second,
+			minRemaining:  0,
+			maxRemaining:  100 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.clientTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.clientTimeout)
+				defer cancel()
+			}
+
+			var remaining time.Duration
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				deadline, ok := ctx.Deadline()
+				if !ok {
+					t.Fatal("handler context has no deadline")
+				}
+				remaining = time.Until(deadline)
+
+				return "ok", nil
+			}
+
+			interceptor := NewDeadlineInterceptor(tt.serverTimeout).Unary()
+			resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+			if err != nil || resp != "ok" {
+				t.Fatalf("interceptor() = %v, %v", resp, err)
+			}
+
+			if remaining <= tt.minRemaining || remaining > tt.maxRemaining {
+				t.Errorf("remaining = %v, want in (%v, %v]", remaining, tt.minRemaining, tt.maxRemaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/m1khal3v/gophkeeper/internal/common/protocol"
+)
+
+// InfoServer is public, clients call it before login to check compatibility
+type InfoServer struct {
+	proto.UnimplementedInfoServiceServer
+
+	build buildlog.Info
+}
+
+func NewInfoServer(build buildlog.Info) *InfoServer {
+	return &InfoServer{
+		build: build,
+	}
+}
+
+func (s *InfoServer) ServerInfo(ctx context.Context, req *proto.ServerInfoRequest) (*proto.ServerInfoResponse, error) {
+	return &proto.ServerInfoResponse{
+		Version:         s.build.Version,
+		Commit:          s.build.Commit,
+		BuildDate:       s.build.Date,
+		ProtocolVersion: protocol.Version,
+		Features:        protocol.Features,
+	}, nil
+}
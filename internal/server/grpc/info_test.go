@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/buildlog"
+	"github.com/m1khal3v/gophkeeper/internal/common/proto"
+	"github.com/m1khal3v/gophkeeper/internal/common/protocol"
+)
+
+func TestInfoServer_ServerInfo(t *testing.T) {
+	server := NewInfoServer(buildlog.Info{Version: "v1.2.0", Date: "2026-10-19", Commit: "abc123"})
+
+	resp, err := server.ServerInfo(context.Background(), &proto.ServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("ServerInfo() error = %v", err)
+	}
+
+	if resp.Version != "v1.2.0" || resp.Commit != "abc123" || resp.BuildDate != "2026-10-19" {
+		t.Errorf("ServerInfo() build = %s %s %s, want v1.2.0 abc123 2026-10-19", resp.Version, resp.Commit, resp.BuildDate)
+	}
+
+	if resp.ProtocolVersion != protocol.Version {
+		t.Errorf("ServerInfo() ProtocolVersion = %d, want %d", resp.ProtocolVersion, protocol.Version)
+	}
+
+	if !slices.Equal(resp.Features, protocol.Features) {
+		t.Errorf("ServerInfo() Features = %v, want %v", resp.Features, protocol.Features)
+	}
+}
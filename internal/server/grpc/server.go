@@ -33,18 +33,18 @@ func NewServer(
 }
 
 func (s *Server) Register(ctx context.Context, req *proto.RegisterRequest) (*proto.TokenResponse, error) {
-	token, err := s.userManager.Register(req.Login, req.Password, req.MasterPassword)
+	token, err := s.userManager.Register(ctx, req.Login, req.Password, req.MasterPassword)
 	if err != nil {
-		return nil, convertError(err)
+		return nil, convertError(ctx, err)
 	}
 
 	return &proto.TokenResponse{Token: token}, nil
 }
 
 func (s *Server) Login(ctx context.Context, req *proto.LoginRequest) (*proto.TokenResponse, error) {
-	token, err := s.userManager.Login(req.Login, req.Password, req.MasterPassword)
+	token, err := s.userManager.Login(ctx, req.Login, req.Password, req.MasterPassword)
 	if err != nil {
-		return nil, convertError(err)
+		return nil, convertError(ctx, err)
 	}
 
 	return &proto.TokenResponse{Token: token}, nil
@@ -60,18 +60,20 @@ func (s *Server) Upsert(ctx context.Context, req *proto.UpsertRequest) (*proto.D
 		UserID:    claims.SubjectID,
 		DataKey:   req.DataKey,
 		DataValue: req.DataValue,
+		DataMeta:  req.DataMeta,
 		UpdatedAt: req.UpdatedAt.AsTime(),
 		DeletedAt: req.DeletedAt.AsTime(),
 	}
 	err = s.dataManager.Upsert(ctx, data)
 
 	if err != nil {
-		return nil, convertError(err)
+		return nil, convertError(ctx, err)
 	}
 
 	return &proto.DataResponse{
 		DataKey:   data.DataKey,
 		DataValue: data.DataValue,
+		DataMeta:  data.DataMeta,
 		UpdatedAt: timestamppb.New(data.UpdatedAt),
 		DeletedAt: timestamppb.New(data.DeletedAt),
 	}, nil
@@ -85,7 +87,7 @@ func (s *Server) GetUpdates(ctx context.Context, req *proto.GetUpdatesRequest) (
 
 	updates, err := s.dataManager.GetUpdates(ctx, claims.SubjectID, req.UpdatedAfter.AsTime())
 	if err != nil {
-		return nil, convertError(err)
+		return nil, convertError(ctx, err)
 	}
 
 	pbUpdates := make([]*proto.DataResponse, 0, len(updates))
@@ -93,6 +95,7 @@ func (s *Server) GetUpdates(ctx context.Context, req *proto.GetUpdatesRequest) (
 		pbUpdates = append(pbUpdates, &proto.DataResponse{
 			DataKey:   data.DataKey,
 			DataValue: data.DataValue,
+			DataMeta:  data.DataMeta,
 			UpdatedAt: timestamppb.New(data.UpdatedAt),
 			DeletedAt: timestamppb.New(data.DeletedAt),
 		})
@@ -101,14 +104,14 @@ func (s *Server) GetUpdates(ctx context.Context, req *proto.GetUpdatesRequest) (
 	return &proto.DataListResponse{Items: pbUpdates}, nil
 }
 
-func convertError(err error) error {
+func convertError(ctx context.Context, err error) error {
 	switch {
 	case errors.Is(err, manager.ErrUserExists):
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, manager.ErrInvalidCredentials):
 		return status.Error(codes.Unauthenticated, err.Error())
 	default:
-		logger.Logger.Error("error occurred", zap.Error(err))
+		logger.FromContext(ctx).Error("error occurred", zap.Error(err))
 
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -20,11 +20,11 @@ type mockServerUserManager struct {
 	loginFunc    func(login, password, masterPassword string) (string, error)
 }
 
-func (m *mockServerUserManager) Register(login, password, masterPassword string) (string, error) {
+func (m *mockServerUserManager) Register(ctx context.Context, login, password, masterPassword string) (string, error) {
 	return m.registerFunc(login, password, masterPassword)
 }
 
-func (m *mockServerUserManager) Login(login, password, masterPassword string) (string, error) {
+func (m *mockServerUserManager) Login(ctx context.Context, login, password, masterPassword string) (string, error) {
 	return m.loginFunc(login, password, masterPassword)
 }
 
@@ -509,7 +509,7 @@ func TestConvertError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := convertError(tt.err)
+			got := convertError(context.Background(), tt.err)
 
 			statusErr, ok := status.FromError(got)
 			if !ok {
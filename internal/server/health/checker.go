@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Checker reports the services as serving while the database is reachable
+type Checker struct {
+	db       Pinger
+	server   *health.Server
+	services []string
+	timeout  time.Duration
+}
+
+// NewChecker updates the overall status "" and the given services
+func NewChecker(db Pinger, server *health.Server, timeout time.Duration, services ...string) *Checker {
+	return &Checker{
+		db:       db,
+		server:   server,
+		services: append([]string{""}, services...),
+		timeout:  timeout,
+	}
+}
+
+// Run checks the database every interval until ctx is done
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) Check(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := c.db.PingContext(ctx); err != nil {
+		logger.Logger.Warn("Database is unreachable", zap.Error(err))
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+
+	return status
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	return p.err
+}
+
+func status(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+
+	return resp.Status
+}
+
+func TestChecker_Check(t *testing.T) {
+	logger.Init("test", "error")
+	pinger := &fakePinger{}
+	server := health.NewServer()
+	checker := NewChecker(pinger, server, time.Second, "gophkeeper.v1.DataService")
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checker.Check(context.Background()))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, server, "gophkeeper.v1.DataService"))
+
+	pinger.err = errors.New("connection refused")
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checker.Check(context.Background()))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, server, "gophkeeper.v1.DataService"))
+}
+
+func TestChecker_Run(t *testing.T) {
+	logger.Init("test", "error")
+	pinger := &fakePinger{err: errors.New("connection refused")}
+	server := health.NewServer()
+	checker := NewChecker(pinger, server, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return status(t, server, "") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
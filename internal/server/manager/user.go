@@ -1,8 +1,10 @@
 package manager
 
 import (
+	"context"
 	"errors"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
 	"github.com/m1khal3v/gophkeeper/internal/server/jwt"
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 	"github.com/m1khal3v/gophkeeper/internal/server/repository"
@@ -16,8 +18,8 @@ var (
 )
 
 type UserRepository interface {
-	GetUserByLogin(login string) (*model.User, error)
-	CreateUser(login, passwordHash, masterPasswordHash string) error
+	GetUserByLogin(ctx context.Context, login string) (*model.User, error)
+	CreateUser(ctx context.Context, login, passwordHash, masterPasswordHash string) error
 }
 
 type UserManager struct {
@@ -35,8 +37,14 @@ func NewUserManager(
 	}
 }
 
-func (m *UserManager) Register(login, password, masterPassword string) (string, error) {
-	existing, err := m.userRepo.GetUserByLogin(login)
+func (m *UserManager) Register(ctx context.Context, login, password, masterPassword string) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "UserManager.Register")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
+	existing, err := m.userRepo.GetUserByLogin(ctx, login)
 	if err != nil {
 		return "", err
 	}
@@ -54,12 +62,12 @@ func (m *UserManager) Register(login, password, masterPassword string) (string,
 		return "", err
 	}
 
-	err = m.userRepo.CreateUser(login, string(passwordHash), string(masterPasswordHash))
+	err = m.userRepo.CreateUser(ctx, login, string(passwordHash), string(masterPasswordHash))
 	if err != nil {
 		return "", err
 	}
 
-	user, err := m.userRepo.GetUserByLogin(login)
+	user, err := m.userRepo.GetUserByLogin(ctx, login)
 	if err != nil || user == nil {
 		return "", err
 	}
@@ -67,8 +75,14 @@ func (m *UserManager) Register(login, password, masterPassword string) (string,
 	return m.jwt.Encode(user.ID, login)
 }
 
-func (m *UserManager) Login(login, password, masterPassword string) (string, error) {
-	user, err := m.userRepo.GetUserByLogin(login)
+func (m *UserManager) Login(ctx context.Context, login, password, masterPassword string) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "UserManager.Login")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
+	user, err := m.userRepo.GetUserByLogin(ctx, login)
 	if err != nil {
 		return "", err
 	}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 	"github.com/m1khal3v/gophkeeper/internal/server/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/m1khal3v/gophkeeper/internal/server/manager")
+
 type UserDataRepository interface {
 	Upsert(ctx context.Context, data *model.UserData) error
 	GetUpdates(ctx context.Context, userID uint32, since time.Time) ([]*model.UserData, error)
+	Stats(ctx context.Context) ([]*model.UserDataStats, error)
 }
 
 type UserDataManager struct {
@@ -24,9 +31,31 @@ func NewUserDataManager(dataRepo *repository.UserDataRepository) *UserDataManage
 }
 
 func (m *UserDataManager) Upsert(ctx context.Context, data *model.UserData) error {
-	return m.dataRepo.Upsert(ctx, data)
+	ctx, span := tracer.Start(ctx, "UserDataManager.Upsert", trace.WithAttributes(
+		attribute.Int64("user.id", int64(data.UserID)),
+	))
+	defer span.End()
+
+	return tracing.Fail(span, m.dataRepo.Upsert(ctx, data))
 }
 
 func (m *UserDataManager) GetUpdates(ctx context.Context, userID uint32, since time.Time) ([]*model.UserData, error) {
-	return m.dataRepo.GetUpdates(ctx, userID, since)
+	ctx, span := tracer.Start(ctx, "UserDataManager.GetUpdates", trace.WithAttributes(
+		attribute.Int64("user.id", int64(userID)),
+	))
+	defer span.End()
+
+	updates, err := m.dataRepo.GetUpdates(ctx, userID, since)
+	span.SetAttributes(attribute.Int("items", len(updates)))
+
+	return updates, tracing.Fail(span, err)
+}
+
+func (m *UserDataManager) Stats(ctx context.Context) ([]*model.UserDataStats, error) {
+	ctx, span := tracer.Start(ctx, "UserDataManager.Stats")
+	defer span.End()
+
+	stats, err := m.dataRepo.Stats(ctx)
+
+	return stats, tracing.Fail(span, err)
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/m1khal3v/gophkeeper/internal/server/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type MockUserDataRepository struct {
@@ -26,6 +29,11 @@ func (m *MockUserDataRepository) GetUpdates(ctx context.Context, userID uint32,
 	return args.Get(0).([]*model.UserData), args.Error(1)
 }
 
+func (m *MockUserDataRepository) Stats(ctx context.Context) ([]*model.UserDataStats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*model.UserDataStats), args.Error(1)
+}
+
 func TestUserDataManager_Upsert(t *testing.T) {
 	mockRepo := new(MockUserDataRepository)
 	manager := NewUserDataManager((*repository.UserDataRepository)(nil))
@@ -41,7 +49,7 @@ func TestUserDataManager_Upsert(t *testing.T) {
 		UpdatedAt: now,
 	}
 
-	mockRepo.On("Upsert", ctx, data).Return(nil)
+	mockRepo.On("Upsert", mock.Anything, data).Return(nil)
 
 	err := manager.Upsert(ctx, data)
 
@@ -65,7 +73,7 @@ func TestUserDataManager_Upsert_Error(t *testing.T) {
 	}
 
 	expectedError := errors.New("db error")
-	mockRepo.On("Upsert", ctx, data).Return(expectedError)
+	mockRepo.On("Upsert", mock.Anything, data).Return(expectedError)
 
 	err := manager.Upsert(ctx, data)
 
@@ -101,7 +109,7 @@ func TestUserDataManager_GetUpdates(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetUpdates", ctx, userID, since).Return(expectedData, nil)
+	mockRepo.On("GetUpdates", mock.Anything, userID, since).Return(expectedData, nil)
 
 	result, err := manager.GetUpdates(ctx, userID, since)
 
@@ -121,7 +129,7 @@ func TestUserDataManager_GetUpdates_Error(t *testing.T) {
 	since := time.Now().Add(-24 * time.Hour)
 
 	expectedError := errors.New("db error")
-	mockRepo.On("GetUpdates", ctx, userID, since).Return([]*model.UserData{}, expectedError)
+	mockRepo.On("GetUpdates", mock.Anything, userID, since).Return([]*model.UserData{}, expectedError)
 
 	result, err := manager.GetUpdates(ctx, userID, since)
 
@@ -141,7 +149,7 @@ func TestUserDataManager_GetUpdates_EmptyResult(t *testing.T) {
 	since := time.Now().Add(-24 * time.Hour)
 
 	emptyData := []*model.UserData{}
-	mockRepo.On("GetUpdates", ctx, userID, since).Return(emptyData, nil)
+	mockRepo.On("GetUpdates", mock.Anything, userID, since).Return(emptyData, nil)
 
 	result, err := manager.GetUpdates(ctx, userID, since)
 
@@ -149,3 +157,40 @@ func TestUserDataManager_GetUpdates_EmptyResult(t *testing.T) {
 	assert.Empty(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUserDataManager_Stats(t *testing.T) {
+	mockRepo := new(MockUserDataRepository)
+	manager := NewUserDataManager((*repository.UserDataRepository)(nil))
+	manager.dataRepo = mockRepo
+
+	expected := []*model.UserDataStats{{UserID: 1, Items: 2, Bytes: 64}}
+	mockRepo.On("Stats", mock.Anything).Return(expected, nil)
+
+	stats, err := manager.Stats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, stats)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserDataManager_Tracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	mockRepo := new(MockUserDataRepository)
+	manager := NewUserDataManager((*repository.UserDataRepository)(nil))
+	manager.dataRepo = mockRepo
+
+	// the repository gets the context of the manager span
+	mockRepo.On("Upsert", mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).IsValid()
+	}), mock.Anything).Return(errors.New("db error"))
+
+	err := manager.Upsert(context.Background(), &model.UserData{UserID: 7})
+	assert.EqualError(t, err, "db error")
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "UserDataManager.Upsert", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), attribute.Int64("user.id", 7))
+	}
+}
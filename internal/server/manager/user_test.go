@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -17,7 +19,7 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) GetUserByLogin(login string) (*model.User, error) {
+func (m *MockUserRepository) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
 	args := m.Called(login)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -25,7 +27,7 @@ func (m *MockUserRepository) GetUserByLogin(login string) (*model.User, error) {
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (m *MockUserRepository) CreateUser(login, passwordHash, masterPasswordHash string) error {
+func (m *MockUserRepository) CreateUser(ctx context.Context, login, passwordHash, masterPasswordHash string) error {
 	args := m.Called(login, passwordHash, masterPasswordHash)
 	return args.Error(0)
 }
@@ -60,7 +62,7 @@ func TestUserManager_Register_Success(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", login).Return(&model.User{ID: userID, Login: login}, nil).Once()
 
-	token, err := manager.Register(login, password, masterPassword)
+	token, err := manager.Register(context.Background(), login, password, masterPassword)
 
 	assert.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -88,7 +90,7 @@ func TestUserManager_Register_UserExists(t *testing.T) {
 	existingUser := &model.User{ID: 1, Login: login}
 	mockRepo.On("GetUserByLogin", login).Return(existingUser, nil).Once()
 
-	token, err := manager.Register(login, password, masterPassword)
+	token, err := manager.Register(context.Background(), login, password, masterPassword)
 
 	assert.Equal(t, "", token)
 	assert.Equal(t, ErrUserExists, err)
@@ -110,7 +112,7 @@ func TestUserManager_Register_RepoError(t *testing.T) {
 	dbError := errors.New("db error")
 	mockRepo.On("GetUserByLogin", login).Return(nil, dbError).Once()
 
-	token, err := manager.Register(login, password, masterPassword)
+	token, err := manager.Register(context.Background(), login, password, masterPassword)
 
 	assert.Equal(t, "", token)
 	assert.Error(t, err)
@@ -145,7 +147,7 @@ func TestUserManager_Login_Success(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", login).Return(user, nil).Once()
 
-	token, err := manager.Login(login, password, masterPassword)
+	token, err := manager.Login(context.Background(), login, password, masterPassword)
 
 	assert.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -186,7 +188,7 @@ func TestUserManager_Login_InvalidPassword(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", login).Return(user, nil).Once()
 
-	token, err := manager.Login(login, wrongPassword, masterPassword)
+	token, err := manager.Login(context.Background(), login, wrongPassword, masterPassword)
 
 	assert.Equal(t, "", token)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -221,7 +223,7 @@ func TestUserManager_Login_InvalidMasterPassword(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", login).Return(user, nil).Once()
 
-	token, err := manager.Login(login, password, wrongMasterPassword)
+	token, err := manager.Login(context.Background(), login, password, wrongMasterPassword)
 
 	assert.Equal(t, "", token)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -242,7 +244,7 @@ func TestUserManager_Login_UserNotFound(t *testing.T) {
 
 	mockRepo.On("GetUserByLogin", login).Return(nil, nil).Once()
 
-	token, err := manager.Login(login, password, masterPassword)
+	token, err := manager.Login(context.Background(), login, password, masterPassword)
 
 	assert.Equal(t, "", token)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -285,3 +287,23 @@ func TestUserManager_DecodeToken_Invalid(t *testing.T) {
 	assert.Nil(t, claims)
 	assert.Error(t, err)
 }
+
+func TestUserManager_Tracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	mockRepo := new(MockUserRepository)
+	manager := &UserManager{userRepo: mockRepo, jwt: jwt.New("secret")}
+	mockRepo.On("GetUserByLogin", "user").Return(nil, errors.New("db error")).Twice()
+
+	_, err := manager.Register(context.Background(), "user", "password", "master")
+	assert.EqualError(t, err, "db error")
+	_, err = manager.Login(context.Background(), "user", "password", "master")
+	assert.EqualError(t, err, "db error")
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "UserManager.Register", spans[0].Name())
+		assert.Equal(t, "UserManager.Login", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	}
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"github.com/m1khal3v/gophkeeper/internal/server/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	namespace    = "gophkeeper"
+	statsTimeout = 5 * time.Second
+)
+
+type StatsProvider interface {
+	Stats(ctx context.Context) ([]*model.UserDataStats, error)
+}
+
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func New(db *sql.DB, stats StatsProvider) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Duration of gRPC requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, namespace),
+		newUserStatsCollector(stats),
+	)
+
+	return m
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		m.duration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
+
+// userStatsCollector queries the database on every scrape, so the values are never stale
+type userStatsCollector struct {
+	stats StatsProvider
+	items *prometheus.Desc
+	bytes *prometheus.Desc
+}
+
+func newUserStatsCollector(stats StatsProvider) *userStatsCollector {
+	return &userStatsCollector{
+		stats: stats,
+		items: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "user", "items"),
+			"Number of stored items per user, including deleted ones.",
+			[]string{"user_id"}, nil,
+		),
+		bytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "user", "storage_bytes"),
+			"Size of encrypted values and metadata per user.",
+			[]string{"user_id"}, nil,
+		),
+	}
+}
+
+func (c *userStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.items
+	ch <- c.bytes
+}
+
+func (c *userStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.stats.Stats(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to collect user stats", zap.Error(err))
+		ch <- prometheus.NewInvalidMetric(c.items, err)
+		return
+	}
+
+	for _, s := range stats {
+		userID := strconv.FormatUint(uint64(s.UserID), 10)
+		ch <- prometheus.MustNewConstMetric(c.items, prometheus.GaugeValue, float64(s.Items), userID)
+		ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.GaugeValue, float64(s.Bytes), userID)
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/m1khal3v/gophkeeper/internal/common/logger"
+	"github.com/m1khal3v/gophkeeper/internal/server/model"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStats struct {
+	stats []*model.UserDataStats
+	err   error
+}
+
+func (f *fakeStats) Stats(ctx context.Context) ([]*model.UserDataStats, error) {
+	return f.stats, f.err
+}
+
+func newTestMetrics(t *testing.T, stats StatsProvider) *Metrics {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return New(db, stats)
+}
+
+func TestMetrics_UnaryInterceptor(t *testing.T) {
+	m := newTestMetrics(t, &fakeStats{})
+	interceptor := m.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.v1.DataService/Upsert"}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	failed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	resp, err := interceptor(context.Background(), "request", info, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	_, err = interceptor(context.Background(), "request", info, ok)
+	assert.NoError(t, err)
+	_, err = interceptor(context.Background(), "request", info, failed)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues(info.FullMethod, "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues(info.FullMethod, "Unauthenticated")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.duration))
+}
+
+func TestMetrics_UserStats(t *testing.T) {
+	m := newTestMetrics(t, &fakeStats{stats: []*model.UserDataStats{
+		{UserID: 1, Items: 3, Bytes: 1024},
+		{UserID: 7, Items: 1, Bytes: 16},
+	}})
+
+	expected := `
+# HELP gophkeeper_user_items Number of stored items per user, including deleted ones.
+# TYPE gophkeeper_user_items gauge
+gophkeeper_user_items{user_id="1"} 3
+gophkeeper_user_items{user_id="7"} 1
+# HELP gophkeeper_user_storage_bytes Size of encrypted values and metadata per user.
+# TYPE gophkeeper_user_storage_bytes gauge
+gophkeeper_user_storage_bytes{user_id="1"} 1024
+gophkeeper_user_storage_bytes{user_id="7"} 16
+`
+	err := testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"gophkeeper_user_items", "gophkeeper_user_storage_bytes")
+	assert.NoError(t, err)
+}
+
+func TestMetrics_UserStatsError(t *testing.T) {
+	logger.Init("test", "fatal")
+	m := newTestMetrics(t, &fakeStats{err: errors.New("db error")})
+
+	_, err := m.Registry().Gather()
+	assert.ErrorContains(t, err, "db error")
+}
+
+func TestMetrics_DBStats(t *testing.T) {
+	m := newTestMetrics(t, &fakeStats{})
+
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "go_sql_open_connections")
+	assert.Contains(t, names, "go_goroutines")
+}
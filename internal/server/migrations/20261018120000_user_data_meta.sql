@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE user_data ADD COLUMN data_meta LONGBLOB NULL AFTER data_value;
+
+-- +goose Down
+ALTER TABLE user_data DROP COLUMN data_meta;
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// ErrSchemaOutdated means the database misses migrations of this build
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+//go:embed *.sql
+var FS embed.FS
+
+// Migrator applies the embedded goose migrations, the version table is shared with the goose CLI
+type Migrator struct {
+	provider *goose.Provider
+}
+
+func New(db *sql.DB) (*Migrator, error) {
+	return newMigrator(db, goose.DialectMySQL, FS)
+}
+
+func newMigrator(db *sql.DB, dialect goose.Dialect, fsys fs.FS) (*Migrator, error) {
+	provider, err := goose.NewProvider(dialect, db, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+
+	return &Migrator{
+		provider: provider,
+	}, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+// Down rolls back the last applied migration
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+// Versions returns the applied version and the last version of this build
+func (m *Migrator) Versions(ctx context.Context) (current, target int64, err error) {
+	return m.provider.GetVersions(ctx)
+}
+
+// Check fails with ErrSchemaOutdated if any migration is not applied, a newer schema is allowed to support rollbacks
+func (m *Migrator) Check(ctx context.Context) error {
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err)
+	}
+
+	if pending {
+		current, target, err := m.provider.GetVersions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get schema version: %w", err)
+		}
+
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, current, target)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMigrator(t *testing.T) *Migrator {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	fsys := fstest.MapFS{
+		"1_first.sql":  {Data: []byte("-- +goose Up\nCREATE TABLE first (id INTEGER);\n-- +goose Down\nDROP TABLE first;\n")},
+		"2_second.sql": {Data: []byte("-- +goose Up\nCREATE TABLE second (id INTEGER);\n-- +goose Down\nDROP TABLE second;\n")},
+	}
+
+	migrator, err := newMigrator(db, goose.DialectSQLite3, fsys)
+	require.NoError(t, err)
+
+	return migrator
+}
+
+func TestFS(t *testing.T) {
+	entries, err := FS.ReadDir(".")
+	require.NoError(t, err)
+	assert.NotEmpty(t, entries)
+
+	// the embedded migrations must be valid goose sources
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = goose.NewProvider(goose.DialectMySQL, db, FS)
+	assert.NoError(t, err)
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	migrator := newTestMigrator(t)
+
+	assert.ErrorIs(t, migrator.Check(ctx), ErrSchemaOutdated)
+
+	results, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.NoError(t, migrator.Check(ctx))
+
+	current, target, err := migrator.Versions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), current)
+	assert.Equal(t, int64(2), target)
+
+	result, err := migrator.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Source.Version)
+
+	err = migrator.Check(ctx)
+	assert.ErrorIs(t, err, ErrSchemaOutdated)
+	assert.ErrorContains(t, err, "version 1, expected 2")
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, goose.StateApplied, statuses[0].State)
+	assert.Equal(t, goose.StatePending, statuses[1].State)
+}
@@ -7,6 +7,14 @@ type UserData struct {
 	UserID    uint32
 	DataKey   string
 	DataValue []byte
+	DataMeta  []byte
 	UpdatedAt time.Time
 	DeletedAt time.Time
 }
+
+// UserDataStats includes deleted items, they still take storage until purged
+type UserDataStats struct {
+	UserID uint32
+	Items  int
+	Bytes  int64
+}
@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 )
 
@@ -15,17 +17,29 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) CreateUser(login, passwordHash, masterPasswordHash string) error {
-	_, err := r.db.Exec(
+func (r *UserRepository) CreateUser(ctx context.Context, login, passwordHash, masterPasswordHash string) (err error) {
+	ctx, span := startSpan(ctx, "UserRepository.CreateUser", "INSERT", "user")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
+	_, err = r.db.ExecContext(ctx,
 		"INSERT INTO user (login, password_hash, master_password_hash) VALUES (?, ?, ?)",
 		login, passwordHash, masterPasswordHash,
 	)
 	return err
 }
 
-func (r *UserRepository) GetUserByLogin(login string) (*model.User, error) {
+func (r *UserRepository) GetUserByLogin(ctx context.Context, login string) (_ *model.User, err error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetUserByLogin", "SELECT", "user")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
 	u := &model.User{}
-	err := r.db.QueryRow(
+	err = r.db.QueryRowContext(ctx,
 		"SELECT id, login, password_hash, master_password_hash FROM user WHERE login = ?",
 		login,
 	).Scan(&u.ID, &u.Login, &u.PasswordHash, &u.MasterPasswordHash)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/m1khal3v/gophkeeper/internal/common/tracing"
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 )
 
@@ -17,7 +18,13 @@ func NewUserDataRepository(db *sql.DB) *UserDataRepository {
 	return &UserDataRepository{db: db}
 }
 
-func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) error {
+func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) (err error) {
+	ctx, span := startSpan(ctx, "UserDataRepository.Upsert", "UPSERT", "user_data")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -43,10 +50,10 @@ func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) e
 	if errors.Is(err, sql.ErrNoRows) {
 		_, err = tx.ExecContext(ctx, `
 			INSERT INTO user_data 
-				(user_id, data_key, data_value, updated_at, deleted_at) 
+				(user_id, data_key, data_value, data_meta, updated_at, deleted_at) 
 			VALUES 
-				(?, ?, ?, ?, ?)
-		`, data.UserID, data.DataKey, data.DataValue, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime))
+				(?, ?, ?, ?, ?, ?)
+		`, data.UserID, data.DataKey, data.DataValue, data.DataMeta, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime))
 
 		if err != nil {
 			return err
@@ -60,10 +67,11 @@ func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) e
 		UPDATE user_data 
 		SET 
 			data_value = ?,
+			data_meta = ?,
 			updated_at = ?,
 			deleted_at = ?
 		WHERE user_id = ? AND data_key = ?
-	`, data.DataValue, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime), data.UserID, data.DataKey)
+	`, data.DataValue, data.DataMeta, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime), data.UserID, data.DataKey)
 		if err != nil {
 			return err
 		}
@@ -72,9 +80,15 @@ func (r *UserDataRepository) Upsert(ctx context.Context, data *model.UserData) e
 	return nil
 }
 
-func (r *UserDataRepository) GetUpdates(ctx context.Context, userID uint32, since time.Time) ([]*model.UserData, error) {
+func (r *UserDataRepository) GetUpdates(ctx context.Context, userID uint32, since time.Time) (_ []*model.UserData, err error) {
+	ctx, span := startSpan(ctx, "UserDataRepository.GetUpdates", "SELECT", "user_data")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, user_id, data_key, data_value, updated_at, deleted_at
+		`SELECT id, user_id, data_key, data_value, data_meta, updated_at, deleted_at
 		 FROM user_data
 		 WHERE user_id = ? AND srv_updated_at > ?`,
 		userID, since.Format(time.DateTime),
@@ -96,6 +110,7 @@ func (r *UserDataRepository) GetUpdates(ctx context.Context, userID uint32, sinc
 			&d.UserID,
 			&d.DataKey,
 			&d.DataValue,
+			&d.DataMeta,
 			&d.UpdatedAt,
 			&d.DeletedAt,
 		)
@@ -106,3 +121,32 @@ func (r *UserDataRepository) GetUpdates(ctx context.Context, userID uint32, sinc
 	}
 	return result, nil
 }
+
+func (r *UserDataRepository) Stats(ctx context.Context) (_ []*model.UserDataStats, err error) {
+	ctx, span := startSpan(ctx, "UserDataRepository.Stats", "SELECT", "user_data")
+	defer func() {
+		tracing.Fail(span, err)
+		span.End()
+	}()
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT user_id, COUNT(*), COALESCE(SUM(LENGTH(data_value) + COALESCE(LENGTH(data_meta), 0)), 0)
+		 FROM user_data
+		 GROUP BY user_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*model.UserDataStats
+	for rows.Next() {
+		s := &model.UserDataStats{}
+		if err = rows.Scan(&s.UserID, &s.Items, &s.Bytes); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func TestUserDataRepository_Upsert_Insert(t *testing.T) {
@@ -27,6 +29,7 @@ func TestUserDataRepository_Upsert_Insert(t *testing.T) {
 		UserID:    1,
 		DataKey:   "test-key",
 		DataValue: []byte("test-value"),
+		DataMeta:  []byte("test-meta"),
 		UpdatedAt: now,
 		DeletedAt: deletedAt,
 	}
@@ -37,7 +40,7 @@ func TestUserDataRepository_Upsert_Insert(t *testing.T) {
 		WillReturnError(sql.ErrNoRows)
 
 	mock.ExpectExec("INSERT INTO user_data").
-		WithArgs(data.UserID, data.DataKey, data.DataValue, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime)).
+		WithArgs(data.UserID, data.DataKey, data.DataValue, data.DataMeta, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
@@ -65,6 +68,7 @@ func TestUserDataRepository_Upsert_Update(t *testing.T) {
 		UserID:    1,
 		DataKey:   "test-key",
 		DataValue: []byte("new-value"),
+		DataMeta:  []byte("new-meta"),
 		UpdatedAt: now,
 		DeletedAt: deletedAt,
 	}
@@ -78,7 +82,7 @@ func TestUserDataRepository_Upsert_Update(t *testing.T) {
 		WillReturnRows(rows)
 
 	mock.ExpectExec("UPDATE user_data").
-		WithArgs(data.DataValue, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime), data.UserID, data.DataKey).
+		WithArgs(data.DataValue, data.DataMeta, data.UpdatedAt.Format(time.DateTime), data.DeletedAt.Format(time.DateTime), data.UserID, data.DataKey).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
@@ -173,11 +177,11 @@ func TestUserDataRepository_GetUpdates(t *testing.T) {
 	now := time.Now()
 	deletedAt := time.Now().Add(time.Hour)
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "data_key", "data_value", "updated_at", "deleted_at"}).
-		AddRow(1, userID, "key1", []byte("value1"), now, deletedAt).
-		AddRow(2, userID, "key2", []byte("value2"), now, deletedAt)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "data_key", "data_value", "data_meta", "updated_at", "deleted_at"}).
+		AddRow(1, userID, "key1", []byte("value1"), []byte("meta1"), now, deletedAt).
+		AddRow(2, userID, "key2", []byte("value2"), nil, now, deletedAt)
 
-	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, updated_at, deleted_at FROM user_data WHERE").
+	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE").
 		WithArgs(userID, since.Format(time.DateTime)).
 		WillReturnRows(rows)
 
@@ -187,6 +191,8 @@ func TestUserDataRepository_GetUpdates(t *testing.T) {
 	assert.Equal(t, uint32(1), results[0].ID)
 	assert.Equal(t, "key1", results[0].DataKey)
 	assert.Equal(t, []byte("value1"), results[0].DataValue)
+	assert.Equal(t, []byte("meta1"), results[0].DataMeta)
+	assert.Nil(t, results[1].DataMeta)
 	assert.Equal(t, now, results[0].UpdatedAt)
 	assert.Equal(t, deletedAt, results[0].DeletedAt)
 
@@ -206,7 +212,7 @@ func TestUserDataRepository_GetUpdates_Error(t *testing.T) {
 	since := time.Now().Add(-24 * time.Hour)
 
 	expectedError := errors.New("db error")
-	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, updated_at, deleted_at FROM user_data WHERE").
+	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE").
 		WithArgs(userID, since.Format(time.DateTime)).
 		WillReturnError(expectedError)
 
@@ -231,10 +237,10 @@ func TestUserDataRepository_GetUpdates_ScanError(t *testing.T) {
 	since := time.Now().Add(-24 * time.Hour)
 
 	// Ошибка при сканировании из-за несоответствия типов
-	rows := sqlmock.NewRows([]string{"id", "user_id", "data_key", "data_value", "updated_at", "deleted_at"}).
-		AddRow("not-a-number", userID, "key1", []byte("value1"), time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "user_id", "data_key", "data_value", "data_meta", "updated_at", "deleted_at"}).
+		AddRow("not-a-number", userID, "key1", []byte("value1"), nil, time.Now(), time.Now())
 
-	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, updated_at, deleted_at FROM user_data WHERE").
+	mock.ExpectQuery("SELECT id, user_id, data_key, data_value, data_meta, updated_at, deleted_at FROM user_data WHERE").
 		WithArgs(userID, since.Format(time.DateTime)).
 		WillReturnRows(rows)
 
@@ -246,3 +252,62 @@ func TestUserDataRepository_GetUpdates_ScanError(t *testing.T) {
 		t.Errorf("unfulfilled expectations: %s", err)
 	}
 }
+
+func TestUserDataRepository_Stats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserDataRepository(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "count", "bytes"}).
+		AddRow(1, 3, 1024).
+		AddRow(2, 1, 16)
+	mock.ExpectQuery("SELECT user_id, COUNT\\(\\*\\), .* FROM user_data GROUP BY user_id").
+		WillReturnRows(rows)
+
+	results, err := repo.Stats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.UserDataStats{
+		{UserID: 1, Items: 3, Bytes: 1024},
+		{UserID: 2, Items: 1, Bytes: 16},
+	}, results)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserDataRepository_Stats_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserDataRepository(db)
+
+	expectedError := errors.New("db error")
+	mock.ExpectQuery("SELECT user_id").WillReturnError(expectedError)
+
+	results, err := repo.Stats(context.Background())
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, results)
+}
+
+func TestUserDataRepository_Tracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT user_id").WillReturnError(errors.New("db error"))
+
+	_, err = NewUserDataRepository(db).Stats(context.Background())
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "UserDataRepository.Stats", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system", "mysql"))
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/m1khal3v/gophkeeper/internal/server/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func TestUserRepository_CreateUser(t *testing.T) {
@@ -26,7 +29,7 @@ func TestUserRepository_CreateUser(t *testing.T) {
 		WithArgs(login, passwordHash, masterPasswordHash).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.CreateUser(login, passwordHash, masterPasswordHash)
+	err = repo.CreateUser(context.Background(), login, passwordHash, masterPasswordHash)
 	assert.NoError(t, err)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -50,7 +53,7 @@ func TestUserRepository_CreateUser_Error(t *testing.T) {
 		WithArgs(login, passwordHash, masterPasswordHash).
 		WillReturnError(expectedError)
 
-	err = repo.CreateUser(login, passwordHash, masterPasswordHash)
+	err = repo.CreateUser(context.Background(), login, passwordHash, masterPasswordHash)
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 
@@ -74,7 +77,7 @@ func TestUserRepository_GetUserByLogin_Found(t *testing.T) {
 		WithArgs(login).
 		WillReturnRows(rows)
 
-	user, err := repo.GetUserByLogin(login)
+	user, err := repo.GetUserByLogin(context.Background(), login)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, uint32(1), user.ID)
@@ -99,7 +102,7 @@ func TestUserRepository_GetUserByLogin_NotFound(t *testing.T) {
 		WithArgs(login).
 		WillReturnError(sql.ErrNoRows)
 
-	user, err := repo.GetUserByLogin(login)
+	user, err := repo.GetUserByLogin(context.Background(), login)
 	assert.NoError(t, err)
 	assert.Nil(t, user)
 
@@ -121,7 +124,7 @@ func TestUserRepository_GetUserByLogin_Error(t *testing.T) {
 		WithArgs(login).
 		WillReturnError(expectedError)
 
-	user, err := repo.GetUserByLogin(login)
+	user, err := repo.GetUserByLogin(context.Background(), login)
 	assert.Error(t, err)
 	assert.Equal(t, user, &model.User{})
 	assert.Equal(t, expectedError, err)
@@ -130,3 +133,27 @@ func TestUserRepository_GetUserByLogin_Error(t *testing.T) {
 		t.Errorf("unfulfilled expectations: %s", err)
 	}
 }
+
+func TestUserRepository_Tracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, login").WillReturnError(errors.New("db error"))
+	mock.ExpectExec("INSERT INTO user").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewUserRepository(db)
+	_, err = repo.GetUserByLogin(context.Background(), "user")
+	assert.Error(t, err)
+	assert.NoError(t, repo.CreateUser(context.Background(), "user", "hash", "master"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "UserRepository.GetUserByLogin", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.sql.table", "user"))
+	assert.Equal(t, "UserRepository.CreateUser", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}